@@ -36,11 +36,18 @@ func generateSchema() schema.PackageSpec {
 	maps.Insert(types, maps.All(resources.ClusterTypes()))
 	maps.Insert(types, maps.All(resources.BasicTypes()))
 	maps.Insert(types, maps.All(resources.ApplyTypes()))
+	maps.Insert(types, maps.All(resources.StatusTypes()))
+	maps.Insert(types, maps.All(resources.ProviderTypes()))
 
 	res := make(map[string]schema.ResourceSpec)
 	maps.Insert(res, maps.All(resources.Cluster))
 	maps.Insert(res, maps.All(resources.Apply))
 
+	functions := make(map[string]schema.FunctionSpec)
+	maps.Insert(functions, maps.All(resources.GetClusterStatus))
+	maps.Insert(functions, maps.All(resources.RenderMachineConfig))
+	maps.Insert(functions, maps.All(resources.ClusterMethodFunctions))
+
 	return schema.PackageSpec{
 		Name:              provider.ProviderName,
 		Description:       "Create and manage Talos kubernetes cluster",
@@ -51,6 +58,11 @@ func generateSchema() schema.PackageSpec {
 		PluginDownloadURL: fmt.Sprintf("github://api.github.com/spigell/pulumi-%s", provider.ProviderName),
 		Types:             types,
 		Resources:         res,
+		Functions:         functions,
+		Config: schema.ConfigSpec{
+			Variables: resources.ProviderProperties(),
+		},
+		Provider: resources.Provider,
 		Language: map[string]schema.RawMessage{
 			"csharp": rawMessage(map[string]any{
 				"packageReferences": map[string]string{
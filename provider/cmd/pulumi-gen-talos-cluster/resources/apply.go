@@ -5,15 +5,25 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
 	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/kubeconfig"
 	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
 )
 
 var (
-	ApplyResourceName         = provider.ApplyType()
-	ApplyTypesMachineInfoKey  = "machineInfo"
-	ApplyTypesMachineInfoPath = provider.ProviderName + ":index:" + ApplyTypesMachineInfoKey
-	ApplyTypesCredentialsKey  = "credentials"
-	ApplyTypesCredentialsPath = provider.ProviderName + ":index:" + ApplyTypesCredentialsKey
+	ApplyResourceName           = provider.ApplyType()
+	ApplyTypesMachineInfoKey    = "machineInfo"
+	ApplyTypesMachineInfoPath   = provider.ProviderName + ":index:" + ApplyTypesMachineInfoKey
+	ApplyTypesCredentialsKey    = "credentials"
+	ApplyTypesCredentialsPath   = provider.ProviderName + ":index:" + ApplyTypesCredentialsKey
+	ApplyTypesApplyModePath     = provider.ProviderName + ":index:" + types.ApplyModeKey
+	ApplyTypesMachineStatusKey  = "machineStatus"
+	ApplyTypesMachineStatusPath = provider.ProviderName + ":index:" + ApplyTypesMachineStatusKey
+	ApplyTypesOperationPath     = provider.ProviderName + ":index:" + "operation"
+	ApplyTypesKubeconfigPath    = provider.ProviderName + ":index:" + "kubeconfigOptions"
+	ApplyTypesTalosconfigPath   = provider.ProviderName + ":index:" + "talosconfigSpec"
+
+	ApplyTypesMachineConfigurationPath = provider.ProviderName + ":index:" + "machineConfiguration"
 )
 
 var Apply = map[string]schema.ResourceSpec{
@@ -36,18 +46,84 @@ func ApplyProperties() map[string]schema.PropertySpec {
 				Type: "object",
 				Ref:  fmt.Sprintf("#types/%s", ApplyTypesCredentialsPath),
 			},
+			Description: "Kubeconfig and talosconfig of the cluster.",
+			Secret:      true,
+		},
+		provider.ApplyResourceOutputsDrift: {
+			TypeSpec: schema.TypeSpec{
+				Type: "object",
+				AdditionalProperties: &schema.TypeSpec{
+					Type:  "array",
+					Items: &schema.TypeSpec{Type: "string"},
+				},
+			},
+			Description: "Configuration fields which differ between the desired and the running configuration, keyed by machine ID. \n" +
+				"Populated only if detectDrift is enabled.",
+		},
+		provider.ApplyResourceOutputsPlannedDiff: {
+			TypeSpec: schema.TypeSpec{
+				Type: "object",
+				AdditionalProperties: &schema.TypeSpec{
+					Type: "string",
+				},
+			},
+			Description: "Output of `talosctl apply-config --dry-run` for machines with changed configuration, keyed by machine ID. \n" +
+				"Populated only during preview. Values of keys, certificates and tokens are redacted.",
+			Secret: true,
+		},
+		provider.ApplyResourceOutputsMachines: {
+			TypeSpec: schema.TypeSpec{
+				Type: "object",
+				AdditionalProperties: &schema.TypeSpec{
+					Ref: fmt.Sprintf("#types/%s", ApplyTypesMachineStatusPath),
+				},
+			},
+			Description: "Applied state of every machine keyed by machine ID.",
+		},
+		provider.ApplyResourceOutputsTalosconfigs: {
+			TypeSpec: schema.TypeSpec{
+				Type:                 "object",
+				AdditionalProperties: &schema.TypeSpec{Type: "string"},
+			},
+			Description: "Talosconfigs from additionalTalosconfigs keyed by name.",
+			Secret:      true,
+		},
+		provider.ApplyResourceOutputsCertExpiry: {
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+			Description: "Expiry time of the admin kubeconfig client certificate in RFC 3339 format.",
 		},
 	}
 }
 
 func ApplyInputProperties() map[string]schema.PropertySpec {
 	return map[string]schema.PropertySpec{
-		"applyMachines": {
+		provider.ApplyInputsApplyMachines: {
 			TypeSpec: schema.TypeSpec{
 				Type: "object",
-				Ref:  fmt.Sprintf("#types/%s", BasicMachinesByTypePath),
+				Ref:  fmt.Sprintf("#types/%s", BasicApplyMachinesPath),
+			},
+			Description: "The machine configurations to apply, usually the machines output of the Cluster resource. \n" +
+				"Either applyMachines or machineConfigurations is required.",
+		},
+		provider.ApplyInputsMachineConfigurations: {
+			TypeSpec: schema.TypeSpec{
+				Type:  "array",
+				Items: &schema.TypeSpec{Type: "object", Ref: fmt.Sprintf("#types/%s", ApplyTypesMachineConfigurationPath)},
+			},
+			Description: "Machine configurations generated by other tools, e.g. `talosctl gen config` or talhelper. \n" +
+				"The machine type, Talos image, Kubernetes version and cluster endpoint are taken from every configuration. \n" +
+				"etcd is bootstrapped on the machine of the init type or on the first controlplane. \n" +
+				"Either applyMachines or machineConfigurations is required.",
+		},
+		provider.ApplyInputsClientTalosconfig: {
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
 			},
-			Description: "The machine configurations to apply.",
+			Description: "Talosconfig whose current context gives the client credentials to access the nodes. \n" +
+				"Either clientConfiguration or clientTalosconfig is required.",
+			Secret: true,
 		},
 		"skipInitApply": {
 			TypeSpec: schema.TypeSpec{
@@ -60,17 +136,235 @@ func ApplyInputProperties() map[string]schema.PropertySpec {
 				"Default is false.",
 			Default: false,
 		},
-		provider.ClusterResourceOutputsClientConfiguration: ClusterProperties()[provider.ClusterResourceOutputsClientConfiguration],
+		"detectDrift": {
+			TypeSpec: schema.TypeSpec{
+				Type: "boolean",
+			},
+			Description: "detectDrift fetches the running configuration from every machine after apply \n" +
+				"and compares it with the desired one to find changes made out of band (e.g. via `talosctl edit mc`). \n" +
+				"Images of Kubernetes components are ignored since they are managed by upgrade-k8s. \n" +
+				"Default is false.",
+			Default: false,
+		},
+		"reapplyOnDrift": {
+			TypeSpec: schema.TypeSpec{
+				Type: "boolean",
+			},
+			Description: "reapplyOnDrift applies the desired configuration again if a drift is detected. \n" +
+				"Requires detectDrift. \n" +
+				"Default is false.",
+			Default: false,
+		},
+		types.ApplyModeKey: {
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+				Ref:  fmt.Sprintf("#types/%s", ApplyTypesApplyModePath),
+			},
+			Description: "Default mode of `talosctl apply-config` for machines without their own applyMode. \n" +
+				"Changes requiring a reboot fail in no-reboot and try modes before reaching the node. \n" +
+				"Default is auto.",
+			Default: applier.ApplyModeAuto,
+		},
+		"rebootTimeout": {
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+			Description: "How long to wait for a node to come back after a reboot. \n" +
+				"The node is back when it reports a new boot ID and reaches the running or maintenance stage. \n" +
+				fmt.Sprintf("Default is %s.", applier.DefaultRebootTimeout),
+			Default: applier.DefaultRebootTimeout.String(),
+		},
+		types.TryTimeoutKey: {
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+			Description: "Default duration after which a configuration applied in try mode is rolled back. \n" +
+				fmt.Sprintf("Default is %s.", applier.DefaultTryTimeout),
+			Default: applier.DefaultTryTimeout,
+		},
+		"kubeconfig": {
+			TypeSpec: schema.TypeSpec{
+				Type: "object",
+				Ref:  fmt.Sprintf("#types/%s", ApplyTypesKubeconfigPath),
+			},
+			Description: "Options of the admin kubeconfig in credentials. \n" +
+				"The kubeconfig is signed locally by the Kubernetes CA from the configuration of the init node.",
+		},
+		"additionalTalosconfigs": {
+			TypeSpec: schema.TypeSpec{
+				Type:  "array",
+				Items: &schema.TypeSpec{Ref: fmt.Sprintf("#types/%s", ApplyTypesTalosconfigPath)},
+			},
+			Description: "Talosconfigs with their own roles, e.g. os:reader for on-call engineers. \n" +
+				"Client certificates are signed by the Talos CA from the configuration of the init node.",
+		},
+		provider.ClusterResourceOutputsClientConfiguration: ApplyClientConfigurationProperty(),
+	}
+}
+
+// ApplyClientConfigurationProperty is the clientConfiguration output of the Cluster resource.
+func ApplyClientConfigurationProperty() schema.PropertySpec {
+	p := ClusterProperties()[provider.ClusterResourceOutputsClientConfiguration]
+	p.Description += " \n" +
+		"Either clientConfiguration or clientTalosconfig is required."
+
+	return p
+}
+
+// ApplyModeProperty is the per-machine apply mode.
+func ApplyModeProperty() schema.PropertySpec {
+	return schema.PropertySpec{
+		TypeSpec: schema.TypeSpec{
+			Type: "string",
+			Ref:  fmt.Sprintf("#types/%s", ApplyTypesApplyModePath),
+		},
+		Description: "Mode of `talosctl apply-config` for the machine. \n" +
+			"Overrides the applyMode of the Apply resource.",
 	}
 }
 
+// ApplyTryTimeoutProperty is the timeout of the try apply mode.
+func ApplyTryTimeoutProperty() schema.PropertySpec {
+	return schema.PropertySpec{
+		TypeSpec: schema.TypeSpec{
+			Type: "string",
+		},
+		Description: "Duration after which a configuration applied in try mode is rolled back. \n" +
+			"Overrides the tryTimeout of the Apply resource.",
+	}
+}
+
+// ApplyRequiredInputProperties is empty since machines and client credentials have two alternative inputs each.
 func ApplyRequiredInputProperties() []string {
-	return []string{"applyMachines", provider.ClusterResourceOutputsClientConfiguration}
+	return nil
 }
 
 func ApplyTypes() map[string]schema.ComplexTypeSpec {
 	ty := make(map[string]schema.ComplexTypeSpec)
 
+	ty[ApplyTypesApplyModePath] = schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "string",
+			Description: "Modes of talosctl apply-config",
+		},
+		Enum: enumValues(applier.ApplyModes),
+	}
+
+	ty[ApplyTypesOperationPath] = schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "string",
+			Description: "Operations which change a machine",
+		},
+		Enum: enumValues(applier.Operations),
+	}
+
+	ty[ApplyTypesMachineStatusPath] = schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Properties: map[string]schema.PropertySpec{
+				applier.MachineStatusConfigHash: {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "SHA-256 hash of the last applied machine configuration.",
+				},
+				applier.MachineStatusTalosVersion: {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "Talos version of the machine image. Empty if the image tag is not a version.",
+				},
+				applier.MachineStatusObservedTalosVersion: {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "Talos version reported by the node after apply.",
+				},
+				applier.MachineStatusKubernetesVersion: {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "Kubernetes version of the machine.",
+				},
+				applier.MachineStatusLastOperation: {
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+						Ref:  fmt.Sprintf("#types/%s", ApplyTypesOperationPath),
+					},
+					Description: "The last operation which ran on the machine. " +
+						"It is unknown for machines of migrated stacks until an operation runs on them.",
+				},
+				applier.MachineStatusLastOperationTime: {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "Time of the last operation in RFC 3339 format. Empty if the operation is unknown.",
+				},
+			},
+			Required: []string{
+				applier.MachineStatusConfigHash,
+				applier.MachineStatusTalosVersion,
+				applier.MachineStatusObservedTalosVersion,
+				applier.MachineStatusKubernetesVersion,
+				applier.MachineStatusLastOperation,
+				applier.MachineStatusLastOperationTime,
+			},
+		},
+	}
+
+	ty[ApplyTypesKubeconfigPath] = schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Properties: map[string]schema.PropertySpec{
+				kubeconfig.OptionServer: {
+					TypeSpec: schema.TypeSpec{Type: "string"},
+					Description: "URL of the Kubernetes API server, e.g. https://lb.example.com:6443. \n" +
+						"Default is the cluster endpoint from the configuration of the init node.",
+				},
+				kubeconfig.OptionClusterName: {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "Name of the cluster entry. Default is the cluster name.",
+				},
+				kubeconfig.OptionContextName: {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "Name of the context, which is also the current context. Default is admin@<cluster name>.",
+				},
+				kubeconfig.OptionUserName: {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "Name of the user entry. Default is admin@<cluster name>.",
+				},
+				kubeconfig.OptionCertLifetime: {
+					TypeSpec: schema.TypeSpec{Type: "string"},
+					Description: "Lifetime of the admin client certificate, e.g. 24h. \n" +
+						"The certificate is renewed in the middle of its lifetime. \n" +
+						"Default is cluster.adminKubeconfig.certLifetime of the init node configuration, one year unless it is patched.",
+				},
+			},
+		},
+	}
+
+	ty[ApplyTypesTalosconfigPath] = schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Properties: map[string]schema.PropertySpec{
+				types.TalosconfigNameKey: {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "Name of the talosconfig and its context.",
+				},
+				types.TalosconfigRolesKey: {
+					TypeSpec: schema.TypeSpec{Type: "array", Items: &schema.TypeSpec{Type: "string"}},
+					Description: "Talos API roles of the client certificate. \n" +
+						"Supported roles: os:admin, os:operator, os:reader, os:etcd:backup.",
+				},
+				types.TalosconfigTTLKey: {
+					TypeSpec: schema.TypeSpec{Type: "string"},
+					Description: "Lifetime of the client certificate, e.g. 720h. \n" +
+						"The certificate is renewed in the middle of its lifetime. \n" +
+						fmt.Sprintf("Default is %s.", applier.DefaultTalosconfigTTL),
+				},
+				types.TalosconfigEndpointsKey: {
+					TypeSpec:    schema.TypeSpec{Type: "array", Items: &schema.TypeSpec{Type: "string"}},
+					Description: "Endpoints of the talosconfig. Default is all controlplanes.",
+				},
+				types.TalosconfigNodesKey: {
+					TypeSpec:    schema.TypeSpec{Type: "array", Items: &schema.TypeSpec{Type: "string"}},
+					Description: "Nodes of the talosconfig. Default is all machines.",
+				},
+			},
+			Required: []string{types.TalosconfigNameKey, types.TalosconfigRolesKey},
+		},
+	}
+
 	ty[ApplyTypesCredentialsPath] = schema.ComplexTypeSpec{
 		ObjectTypeSpec: schema.ObjectTypeSpec{
 			Type: "object",
@@ -95,6 +389,34 @@ func ApplyTypes() map[string]schema.ComplexTypeSpec {
 		},
 	}
 
+	ty[ApplyTypesMachineConfigurationPath] = schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Properties: map[string]schema.PropertySpec{
+				types.MachineIDKey: {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "ID or name of the machine.",
+				},
+				types.NodeIPKey: {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "The IP address of the node where configuration will be applied.",
+				},
+				types.ConfigurationKey: {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "Complete machine configuration of the node (YAML).",
+					Secret:      true,
+				},
+				types.ApplyModeKey:  ApplyModeProperty(),
+				types.TryTimeoutKey: ApplyTryTimeoutProperty(),
+			},
+			Required: []string{
+				types.MachineIDKey,
+				types.NodeIPKey,
+				types.ConfigurationKey,
+			},
+		},
+	}
+
 	ty[ApplyTypesMachineInfoPath] = schema.ComplexTypeSpec{
 		ObjectTypeSpec: schema.ObjectTypeSpec{
 			Type: "object",
@@ -105,6 +427,22 @@ func ApplyTypes() map[string]schema.ComplexTypeSpec {
 					},
 					Description: "ID or name of the machine.",
 				},
+				types.MachineInfoVersionKey: {
+					TypeSpec: schema.TypeSpec{
+						Type: "integer",
+					},
+					Description: "Version of the machine info format. \n" +
+						fmt.Sprintf("Set by the Cluster resource, the current version is %d. \n", types.MachineInfoVersion) +
+						"Machines without it are parsed as version 0.",
+				},
+				types.MachineTypeKey: {
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+						Ref:  fmt.Sprintf("#types/%s", ClusterTypesMachinesTypesPath),
+					},
+					Description: "Type of the machine. \n" +
+						"Set by the Cluster resource. The machine is applied according to its group in applyMachines.",
+				},
 				types.NodeIPKey: {
 					TypeSpec: schema.TypeSpec{
 						Type: "string",
@@ -117,6 +455,7 @@ func ApplyTypes() map[string]schema.ComplexTypeSpec {
 					},
 					Description: "Configuration settings for machines to apply. \n" +
 						"This can be retrieved from the cluster resource.",
+					Secret: true,
 				},
 				types.UserConfigPatchesKey: {
 					TypeSpec: schema.TypeSpec{
@@ -129,13 +468,15 @@ func ApplyTypes() map[string]schema.ComplexTypeSpec {
 					TypeSpec: schema.TypeSpec{
 						Type: "string",
 					},
-					Description: "Talos OS image to install or upgrade on the node.",
+					Description: "Talos OS image to install or upgrade on the node. \n" +
+						"The install image of the configuration is used if it is not set.",
 				},
 				types.KubernetesVersionKey: {
 					TypeSpec: schema.TypeSpec{
 						Type: "string",
 					},
-					Description: "Kubernetes version to install or upgrade on the node.",
+					Description: "Kubernetes version to install or upgrade on the node. \n" +
+						"The version of the kubelet image of the configuration is used if it is not set.",
 				},
 				types.ClusterEnpointKey: {
 					TypeSpec: schema.TypeSpec{
@@ -143,6 +484,8 @@ func ApplyTypes() map[string]schema.ComplexTypeSpec {
 					},
 					Description: "cluster endpoint applied to node",
 				},
+				types.ApplyModeKey:  ApplyModeProperty(),
+				types.TryTimeoutKey: ApplyTryTimeoutProperty(),
 			},
 			Required: []string{
 				types.MachineIDKey,
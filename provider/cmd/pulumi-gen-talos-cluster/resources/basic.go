@@ -10,40 +10,19 @@ import (
 
 var (
 	BasicClientConfifgurationPath = provider.ProviderName + ":index:" + provider.ClusterResourceOutputsClientConfiguration
-	BasicMachinesByTypePath       = provider.ProviderName + ":index:" + "applyMachines"
+	BasicApplyMachinesPath        = provider.ProviderName + ":index:" + provider.ApplyInputsApplyMachines
+	BasicMachinesByTypePath       = provider.ProviderName + ":index:" + "machinesByType"
 )
 
 func BasicTypes() map[string]schema.ComplexTypeSpec {
 	types := make(map[string]schema.ComplexTypeSpec)
 
-	types[BasicMachinesByTypePath] = schema.ComplexTypeSpec{
-		ObjectTypeSpec: schema.ObjectTypeSpec{
-			Type: "object",
-			Properties: map[string]schema.PropertySpec{
-				machine.TypeControlPlane.String(): {
-					TypeSpec: schema.TypeSpec{
-						Type:  "array",
-						Items: &schema.TypeSpec{Type: "object", Ref: fmt.Sprintf("#types/%s", ApplyTypesMachineInfoPath)},
-					},
-				},
-				machine.TypeInit.String(): {
-					TypeSpec: schema.TypeSpec{
-						Type:  "array",
-						Items: &schema.TypeSpec{Type: "object", Ref: fmt.Sprintf("#types/%s", ApplyTypesMachineInfoPath)},
-					},
-				},
-				machine.TypeWorker.String(): {
-					TypeSpec: schema.TypeSpec{
-						Type:  "array",
-						Items: &schema.TypeSpec{Type: "object", Ref: fmt.Sprintf("#types/%s", ApplyTypesMachineInfoPath)},
-					},
-				},
-			},
-			Required: []string{
-				machine.TypeInit.String(),
-			},
-		},
-	}
+	// The input of Apply and the output of Cluster have the same shape, but separate types.
+	// The applyMachines type is kept for SDKs generated before the Cluster output got its own type.
+	types[BasicApplyMachinesPath] = machinesByType("Machines to apply grouped by machine type. \n" +
+		"It is usually the machines output of the Cluster resource.")
+	types[BasicMachinesByTypePath] = machinesByType("Machines of the Cluster resource grouped by machine type. \n" +
+		"It is passed to applyMachines of the Apply resource.")
 
 	types[BasicClientConfifgurationPath] = schema.ComplexTypeSpec{
 		ObjectTypeSpec: schema.ObjectTypeSpec{
@@ -60,6 +39,7 @@ func BasicTypes() map[string]schema.ComplexTypeSpec {
 						Type: "string",
 					},
 					Description: "The private key for the client certificate, used for authenticating the client to the Talos API server.",
+					Secret:      true,
 				},
 				provider.ClusterResourceOutputsClientConfigurationClientCertificateKey: {
 					TypeSpec: schema.TypeSpec{
@@ -73,3 +53,38 @@ func BasicTypes() map[string]schema.ComplexTypeSpec {
 
 	return types
 }
+
+// machinesByType returns an object type of machines grouped by the machine type.
+func machinesByType(description string) schema.ComplexTypeSpec {
+	machines := make(map[string]schema.PropertySpec)
+	for _, typ := range []machine.Type{machine.TypeInit, machine.TypeControlPlane, machine.TypeWorker} {
+		machines[typ.String()] = schema.PropertySpec{
+			TypeSpec: schema.TypeSpec{
+				Type:  "array",
+				Items: &schema.TypeSpec{Type: "object", Ref: fmt.Sprintf("#types/%s", ApplyTypesMachineInfoPath)},
+			},
+			Description: fmt.Sprintf("Machines of the %s type.", typ),
+		}
+	}
+
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: description,
+			Properties:  machines,
+			Required: []string{
+				machine.TypeInit.String(),
+			},
+		},
+	}
+}
+
+// enumValues converts supported values of a string option to enum values.
+func enumValues(values []string) []schema.EnumValueSpec {
+	res := make([]schema.EnumValueSpec, 0, len(values))
+	for _, v := range values {
+		res = append(res, schema.EnumValueSpec{Value: v})
+	}
+
+	return res
+}
@@ -17,7 +17,8 @@ var (
 	ClusterTypesMachinesPath            = provider.ProviderName + ":index:" + ClusterTypesMachinesKey
 	ClusterTypesClusterNameKey          = "clusterName"
 	ClusterTypesTalosVersionContractKey = "talosVersionContract"
-	ClusterTypesMachinesMachineTypeKey  = "machineType"
+	ClusterTypesMachinesMachineTypeKey  = types.MachineTypeKey
+	ClusterTypesExistingSecretsKey      = "existingSecrets"
 )
 
 var Cluster = map[string]schema.ResourceSpec{
@@ -32,6 +33,7 @@ var Cluster = map[string]schema.ResourceSpec{
 		},
 		InputProperties: ClusterInputProperties(),
 		RequiredInputs:  ClusterRequiredInputProperties(),
+		Methods:         ClusterMethods,
 	},
 }
 
@@ -106,6 +108,8 @@ func ClusterTypes() map[string]schema.ComplexTypeSpec {
 						"Must be a valid array of YAML strings. \n" +
 						"For structure, see https://www.talos.dev/latest/reference/configuration/v1alpha1/config/",
 				},
+				types.ApplyModeKey:  ApplyModeProperty(),
+				types.TryTimeoutKey: ApplyTryTimeoutProperty(),
 			},
 			Required: []string{
 				ClusterTypesMachinesMachineTypeKey,
@@ -129,9 +133,11 @@ func ClusterProperties() map[string]schema.PropertySpec {
 		},
 		provider.ClusterResourceOutputsGeneratedConfigurations: {
 			TypeSpec: schema.TypeSpec{
-				Type: "object",
+				Type:                 "object",
+				AdditionalProperties: &schema.TypeSpec{Type: "string"},
 			},
 			Description: "Generated machine configuration YAML keyed by machine ID.",
+			Secret:      true,
 		},
 		provider.ClusterResourceOutputsMachines: {
 			TypeSpec: schema.TypeSpec{
@@ -140,6 +146,14 @@ func ClusterProperties() map[string]schema.PropertySpec {
 			},
 			Description: "Machine information grouped by machine type.",
 		},
+		provider.ClusterResourceOutputsMachineSecrets: {
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+			Description: "Cluster secrets bundle and admin client certificate generated on creation (YAML). \n" +
+				"It is kept in the state and never regenerated.",
+			Secret: true,
+		},
 	}
 }
 
@@ -148,6 +162,7 @@ func ClusterRequiredProperties() []string {
 		provider.ClusterResourceOutputsMachines,
 		provider.ClusterResourceOutputsGeneratedConfigurations,
 		provider.ClusterResourceOutputsClientConfiguration,
+		provider.ClusterResourceOutputsMachineSecrets,
 	}
 }
 
@@ -180,8 +195,8 @@ func ClusterInputProperties() map[string]schema.PropertySpec {
 			},
 			Description: fmt.Sprintf("Version of Talos features used for configuration generation. \n"+
 				"Do not confuse this with the talosImage property. \n"+
-				"Used in NewSecrets() and GetConfigurationOutput() resources. \n"+
-				"This property is immutable to prevent version conflicts across provider updates. \n"+
+				"Used to generate the secrets bundle and machine configurations. \n"+
+				"This property is immutable: the value used on creation is kept in machineSecrets. \n"+
 				"See issue: https://github.com/siderolabs/terraform-provider-talos/issues/168 \n"+
 				"The default value is based on gendata.VersionTag, current: %s.", gendata.VersionTag),
 			Default: gendata.VersionTag,
@@ -196,6 +211,15 @@ func ClusterInputProperties() map[string]schema.PropertySpec {
 			},
 			Description: "Configuration settings for machines",
 		},
+		ClusterTypesExistingSecretsKey: {
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+			Description: "Existing secrets bundle (YAML) to use instead of a generated one. \n" +
+				"Accepts the output of `talosctl gen secrets` or the machineSecrets of the pulumiverse talos Secrets resource. \n" +
+				"It is only read on creation of machineSecrets, e.g. to adopt a cluster created outside of the stack.",
+			Secret: true,
+		},
 	}
 }
 
@@ -0,0 +1,128 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/siderolabs/talos/pkg/machinery/config/machine"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+const methodSelfKey = "__self__"
+
+var ClusterMethods = map[string]string{
+	provider.ClusterMethodGetTalosconfig:   provider.ClusterMethodType(provider.ClusterMethodGetTalosconfig),
+	provider.ClusterMethodGetMachineConfig: provider.ClusterMethodType(provider.ClusterMethodGetMachineConfig),
+	provider.ClusterMethodGetJoinConfig:    provider.ClusterMethodType(provider.ClusterMethodGetJoinConfig),
+}
+
+func clusterMethodSelf() schema.PropertySpec {
+	return schema.PropertySpec{
+		TypeSpec: schema.TypeSpec{
+			Ref: fmt.Sprintf("#/resources/%s", ClusterResourceName),
+		},
+	}
+}
+
+func clusterMethodConfigurationOutputs() *schema.ObjectTypeSpec {
+	return &schema.ObjectTypeSpec{
+		Properties: map[string]schema.PropertySpec{
+			provider.ClusterMethodResultConfiguration: {
+				TypeSpec: schema.TypeSpec{
+					Type: "string",
+				},
+				Description: "Machine configuration YAML.",
+				Secret:      true,
+			},
+		},
+		Required: []string{provider.ClusterMethodResultConfiguration},
+	}
+}
+
+var ClusterMethodFunctions = map[string]schema.FunctionSpec{
+	ClusterMethods[provider.ClusterMethodGetTalosconfig]: {
+		Description: "Issue a talosconfig with a new client certificate signed by the Talos CA of the cluster. \n" +
+			"A new certificate is issued on every call.",
+		Inputs: &schema.ObjectTypeSpec{
+			Properties: map[string]schema.PropertySpec{
+				methodSelfKey: clusterMethodSelf(),
+				types.TalosconfigEndpointsKey: {
+					TypeSpec: schema.TypeSpec{
+						Type:  "array",
+						Items: &schema.TypeSpec{Type: "string"},
+					},
+					Description: "Endpoints of the talosconfig context.",
+				},
+				types.TalosconfigNodesKey: {
+					TypeSpec: schema.TypeSpec{
+						Type:  "array",
+						Items: &schema.TypeSpec{Type: "string"},
+					},
+					Description: "Nodes of the talosconfig context.",
+				},
+				types.TalosconfigRolesKey: {
+					TypeSpec: schema.TypeSpec{
+						Type:  "array",
+						Items: &schema.TypeSpec{Type: "string"},
+					},
+					Description: "Talos API roles of the certificate, e.g. os:reader. Default is os:admin.",
+				},
+			},
+			Required: []string{methodSelfKey},
+		},
+		Outputs: &schema.ObjectTypeSpec{
+			Properties: map[string]schema.PropertySpec{
+				provider.ClusterMethodResultTalosconfig: {
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+					Description: "The talosconfig.",
+					Secret:      true,
+				},
+			},
+			Required: []string{provider.ClusterMethodResultTalosconfig},
+		},
+	},
+	ClusterMethods[provider.ClusterMethodGetMachineConfig]: {
+		Description: "Get the generated configuration of a machine merged with extra patches. No node is contacted.",
+		Inputs: &schema.ObjectTypeSpec{
+			Properties: map[string]schema.PropertySpec{
+				methodSelfKey: clusterMethodSelf(),
+				types.MachineIDKey: {
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+					Description: "ID of the machine.",
+				},
+				"extraPatches": {
+					TypeSpec: schema.TypeSpec{
+						Type:  "array",
+						Items: &schema.TypeSpec{Type: "string"},
+					},
+					Description: "Patches to merge into the configuration in the same way as configPatches.",
+				},
+			},
+			Required: []string{methodSelfKey, types.MachineIDKey},
+		},
+		Outputs: clusterMethodConfigurationOutputs(),
+	},
+	ClusterMethods[provider.ClusterMethodGetJoinConfig]: {
+		Description: "Generate a configuration for a new machine of the role, which joins the cluster. \n" +
+			"It uses the cluster secrets and the default installation image.",
+		Inputs: &schema.ObjectTypeSpec{
+			Properties: map[string]schema.PropertySpec{
+				methodSelfKey: clusterMethodSelf(),
+				"role": {
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+					Description: fmt.Sprintf("Machine type of the new machine: %s or %s.",
+						machine.TypeControlPlane.String(), machine.TypeWorker.String()),
+				},
+			},
+			Required: []string{methodSelfKey, "role"},
+		},
+		Outputs: clusterMethodConfigurationOutputs(),
+	},
+}
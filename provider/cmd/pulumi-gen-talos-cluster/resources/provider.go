@@ -0,0 +1,115 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+)
+
+var (
+	ProviderTypesVersionCheckPath  = provider.ProviderName + ":index:" + "talosctlVersionCheck"
+	ProviderTypesMigrationModePath = provider.ProviderName + ":index:" + provider.ConfigMigrationMode
+	ProviderTypesDebugBundlePath   = provider.ProviderName + ":index:" + provider.ConfigDebugBundle
+)
+
+func ProviderTypes() map[string]schema.ComplexTypeSpec {
+	return map[string]schema.ComplexTypeSpec{
+		ProviderTypesVersionCheckPath: {
+			ObjectTypeSpec: schema.ObjectTypeSpec{
+				Type:        "string",
+				Description: "Reactions on talosctl version skew",
+			},
+			Enum: enumValues(talosctl.VersionChecks),
+		},
+		ProviderTypesMigrationModePath: {
+			ObjectTypeSpec: schema.ObjectTypeSpec{
+				Type:        "string",
+				Description: "Modes of migration from pulumiverse/talos resources",
+			},
+			Enum: enumValues(applier.MigrationModes),
+		},
+		ProviderTypesDebugBundlePath: {
+			ObjectTypeSpec: schema.ObjectTypeSpec{
+				Type:        "string",
+				Description: "Diagnostics collected from a node after a failed operation",
+			},
+			Enum: enumValues(applier.DebugBundleModes),
+		},
+	}
+}
+
+// ProviderProperties are options of the provider. Options typed with an enum have no schema default,
+// since the Go SDK can not generate a config getter for it, the provider applies the default itself.
+func ProviderProperties() map[string]schema.PropertySpec {
+	return map[string]schema.PropertySpec{
+		provider.ConfigTalosctlPath: {
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+			Description: "Path to the talosctl binary or to a directory with talosctl binaries for different Talos versions. \n" +
+				"If a directory is set, the binary matching the Talos version of a machine image is used for the machine, \n" +
+				"and the newest one otherwise. \n" +
+				"Default is talosctl from PATH.",
+		},
+		provider.ConfigTalosctlVersionCheck: {
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+				Ref:  fmt.Sprintf("#types/%s", ProviderTypesVersionCheckPath),
+			},
+			Description: "Reaction on skew between the talosctl version and the Talos version of a machine image. \n" +
+				"The version of every binary is checked via `talosctl version --client` on provider start. \n" +
+				fmt.Sprintf("Default is %s.", talosctl.VersionCheckWarn),
+		},
+		provider.ConfigWorkDir: {
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+			Description: "Root directory for talosctl working directories. \n" +
+				"Files with credentials and machine configurations are written to `<workDir>/talos-cluster-<uid>`, \n" +
+				"which is accessible only by the current user. \n" +
+				"Default is the system temporary directory.",
+		},
+		provider.ConfigMigrationMode: {
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+				Ref:  fmt.Sprintf("#types/%s", ProviderTypesMigrationModePath),
+			},
+			Description: "Migration of stacks created with pulumiverse/talos resources. \n" +
+				"`aliases` registers the initial apply and bootstrap with aliases to the former resources, they are rerun, but skip configured nodes. \n" +
+				"`import` takes the initial apply and bootstrap as done without contacting nodes. \n" +
+				"In both modes the preview fails if a node is not configured, its etcd is not bootstrapped \n" +
+				"or it runs other cluster secrets than the generated configuration. \n" +
+				"Unset it after the migration.",
+		},
+		provider.ConfigDebugBundle: {
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+				Ref:  fmt.Sprintf("#types/%s", ProviderTypesDebugBundlePath),
+			},
+			Description: "Diagnostics collected from a node when `talosctl upgrade` or `talosctl apply-config` fails. \n" +
+				"`basic` collects dmesg, the service list, logs of machined (and etcd on controlplanes during upgrade) and the machine status. \n" +
+				"`support` adds a `talosctl support` archive, which takes a few minutes. \n" +
+				"The path of the bundle is a part of the error message. \n" +
+				fmt.Sprintf("Default is %s.", applier.DebugBundleBasic),
+		},
+		provider.ConfigDebugBundleDir: {
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+			Description: "Root directory for debug bundles. \n" +
+				"Bundles are written to `<debugBundleDir>/<stack>/<cluster>/<stage>-<machine>/<time>` and are not removed. \n" +
+				"Default is the debug directory inside the working directories of workDir.",
+		},
+	}
+}
+
+var Provider = schema.ResourceSpec{
+	ObjectTypeSpec: schema.ObjectTypeSpec{
+		Description: "The provider type for the talos-cluster package.",
+		Type:        "object",
+	},
+	InputProperties: ProviderProperties(),
+}
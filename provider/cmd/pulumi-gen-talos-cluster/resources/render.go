@@ -0,0 +1,60 @@
+package resources
+
+import (
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+var RenderMachineConfigFunctionName = provider.RenderMachineConfigType()
+
+var RenderMachineConfig = map[string]schema.FunctionSpec{
+	RenderMachineConfigFunctionName: {
+		Description: "Render the machine configuration exactly as the Apply resource sends it to a node. \n" +
+			"The same merge pipeline is used: user patches are merged into the generated configuration \n" +
+			"and images of Kubernetes components are kept from the running configuration if it is provided. \n" +
+			"No node is contacted.",
+		Inputs: &schema.ObjectTypeSpec{
+			Properties: map[string]schema.PropertySpec{
+				types.ConfigurationKey: {
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+					Description: "Generated machine configuration. \n" +
+						"This can be retrieved from the cluster resource.",
+					Secret: true,
+				},
+				types.UserConfigPatchesKey: {
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+					Description: "User-provided machine configuration patches as a multi-document YAML. \n" +
+						"This can be retrieved from the cluster resource.",
+				},
+				provider.RenderMachineConfigCurrentConfigurationKey: {
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+					Description: "Configuration currently running on the node, e.g. spec of `talosctl get machineconfig`. \n" +
+						"If set, images of Kubernetes components are taken from it to prevent downgrades.",
+					Secret: true,
+				},
+			},
+			Required: []string{
+				types.ConfigurationKey,
+			},
+		},
+		Outputs: &schema.ObjectTypeSpec{
+			Properties: map[string]schema.PropertySpec{
+				provider.RenderMachineConfigMachineConfigurationKey: {
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+					Description: "The final machine configuration YAML.",
+					Secret:      true,
+				},
+			},
+			Required: []string{provider.RenderMachineConfigMachineConfigurationKey},
+		},
+	},
+}
@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider"
+)
+
+var (
+	GetClusterStatusFunctionName = provider.GetClusterStatusType()
+	StatusTypesNodeStatusPath    = provider.ProviderName + ":index:" + "nodeStatus"
+)
+
+var GetClusterStatus = map[string]schema.FunctionSpec{
+	GetClusterStatusFunctionName: {
+		Description: "Get the observed status of Talos nodes: \n" +
+			"- Talos and kubelet versions \n" +
+			"- Machine type and stage \n" +
+			"- Etcd membership \n" +
+			"Nodes which can't be reached are reported with an error instead of failing the whole call.",
+		Inputs: &schema.ObjectTypeSpec{
+			Properties: map[string]schema.PropertySpec{
+				provider.ClusterResourceOutputsClientConfiguration: ClusterProperties()[provider.ClusterResourceOutputsClientConfiguration],
+				provider.GetClusterStatusNodesKey: {
+					TypeSpec: schema.TypeSpec{
+						Type:  "array",
+						Items: &schema.TypeSpec{Type: "string"},
+					},
+					Description: "IP addresses of nodes to query. Every node is used as its own endpoint.",
+				},
+			},
+			Required: []string{
+				provider.ClusterResourceOutputsClientConfiguration,
+				provider.GetClusterStatusNodesKey,
+			},
+		},
+		Outputs: &schema.ObjectTypeSpec{
+			Properties: map[string]schema.PropertySpec{
+				provider.GetClusterStatusNodesKey: {
+					TypeSpec: schema.TypeSpec{
+						Type:  "array",
+						Items: &schema.TypeSpec{Type: "object", Ref: fmt.Sprintf("#types/%s", StatusTypesNodeStatusPath)},
+					},
+					Description: "Status of every requested node in the same order.",
+				},
+			},
+			Required: []string{provider.GetClusterStatusNodesKey},
+		},
+	},
+}
+
+func StatusTypes() map[string]schema.ComplexTypeSpec {
+	ty := make(map[string]schema.ComplexTypeSpec)
+
+	ty[StatusTypesNodeStatusPath] = schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Properties: map[string]schema.PropertySpec{
+				"node": {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "The IP address of the node.",
+				},
+				"stage": {
+					TypeSpec: schema.TypeSpec{Type: "string"},
+					Description: "Machine stage reported by Talos, e.g. maintenance, booting, running. \n" +
+						"It is unknown if the node can't be reached.",
+				},
+				"ready": {
+					TypeSpec: schema.TypeSpec{Type: "boolean"},
+					Description: "The node is running, all Talos conditions are met \n" +
+						"and, for controlplanes, the node is a voting etcd member.",
+				},
+				"machineType": {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "Machine type from the running configuration.",
+				},
+				"talosVersion": {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "Running Talos version.",
+				},
+				"kubeletVersion": {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "Kubelet version from the kubelet image. Empty if kubelet is not configured yet.",
+				},
+				"etcdMember": {
+					TypeSpec:    schema.TypeSpec{Type: "boolean"},
+					Description: "The node is a member of the etcd cluster.",
+				},
+				"etcdMemberId": {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "ID of the etcd member.",
+				},
+				"etcdLearner": {
+					TypeSpec:    schema.TypeSpec{Type: "boolean"},
+					Description: "The etcd member is a learner and does not vote yet.",
+				},
+				"error": {
+					TypeSpec:    schema.TypeSpec{Type: "string"},
+					Description: "Errors occurred while the status was collected.",
+				},
+			},
+			Required: []string{
+				"node",
+				"stage",
+				"ready",
+			},
+		},
+	}
+
+	return ty
+}
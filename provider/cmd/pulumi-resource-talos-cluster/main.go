@@ -3,10 +3,18 @@
 package main
 
 import (
+	"os"
+
 	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
 	"github.com/spigell/pulumi-talos-cluster/provider/pkg/version"
 )
 
 func main() {
+	// talosctl commands are run with retries by the provider binary itself.
+	if len(os.Args) > 1 && os.Args[1] == talosctl.RetryCommand {
+		os.Exit(talosctl.RetryMain(os.Args[2:]))
+	}
+
 	provider.Serve(version.Version, pulumiSchema)
 }
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	talosconfigFile = "talosconfig"
+	summaryFile     = "summary.yaml"
+	secretsFile     = "secrets.yaml"
+)
+
+// summary describes rendered files.
+type summary struct {
+	ClusterName          string            `yaml:"clusterName"`
+	ClusterEndpoint      string            `yaml:"clusterEndpoint"`
+	KubernetesVersion    string            `yaml:"kubernetesVersion"`
+	TalosVersionContract string            `yaml:"talosVersionContract"`
+	Talosconfig          string            `yaml:"talosconfig"`
+	Secrets              string            `yaml:"secrets"`
+	Machines             []*summaryMachine `yaml:"machines"`
+}
+
+type summaryMachine struct {
+	*provider.RenderedMachine `yaml:",inline"`
+	File                      string `yaml:"file"`
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "render" {
+		fmt.Printf("Usage: %s render -f <cluster.yaml> -o <out-dir> [-secrets <secrets.yaml>]\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	if err := render(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "render: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func render(args []string) error {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	specPath := fs.String("f", "", "cluster spec in the form of Cluster arguments (YAML)")
+	outDir := fs.String("o", "", "output directory")
+	secretsPath := fs.String("secrets", "", "secrets bundle to use instead of existingSecrets of the spec, "+
+		"the format of `talosctl gen secrets`. A new bundle is generated if both are empty")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *specPath == "" || *outDir == "" {
+		return fmt.Errorf("-f and -o are required")
+	}
+
+	raw, err := os.ReadFile(*specPath)
+	if err != nil {
+		return err
+	}
+
+	var spec provider.ClusterSpec
+	if err := yaml.Unmarshal(raw, &spec); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", *specPath, err)
+	}
+
+	existing := spec.ExistingSecrets
+
+	if *secretsPath != "" {
+		b, err := os.ReadFile(*secretsPath)
+		if err != nil {
+			return err
+		}
+
+		existing = string(b)
+	}
+
+	spec.WithDefaults()
+
+	secrets, err := provider.NewClusterSecrets(spec.TalosVersionContract, existing)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := provider.RenderCluster(&spec, secrets)
+	if err != nil {
+		return err
+	}
+
+	return write(*outDir, rendered)
+}
+
+// write stores rendered files in dir. All of them contain secrets, so they are readable by the current user only.
+func write(dir string, rendered *provider.RenderedCluster) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	s := &summary{
+		ClusterName:          rendered.Spec.ClusterName,
+		ClusterEndpoint:      rendered.Spec.ClusterEndpoint,
+		KubernetesVersion:    rendered.Spec.KubernetesVersion,
+		TalosVersionContract: rendered.Secrets.TalosVersion,
+		Talosconfig:          talosconfigFile,
+		Secrets:              secretsFile,
+	}
+
+	files := map[string]string{
+		talosconfigFile: rendered.Talosconfig,
+	}
+
+	bundle, err := yaml.Marshal(rendered.Secrets.Bundle)
+	if err != nil {
+		return err
+	}
+
+	// Keep the bundle to render the same configurations next time.
+	files[secretsFile] = string(bundle)
+
+	for _, m := range rendered.Machines {
+		name := talosctl.SanitizePathComponent(m.MachineID) + ".yaml"
+		if _, ok := files[name]; ok {
+			return fmt.Errorf("machine %s: file %s is already written", m.MachineID, name)
+		}
+
+		files[name] = m.Configuration
+		s.Machines = append(s.Machines, &summaryMachine{RenderedMachine: m, File: name})
+	}
+
+	out, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	files[summaryFile] = string(out)
+
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(files[name]), 0o600); err != nil {
+			return err
+		}
+
+		fmt.Println(filepath.Join(dir, name))
+	}
+
+	return nil
+}
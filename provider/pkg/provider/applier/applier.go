@@ -2,14 +2,14 @@ package applier
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
+	"time"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/pulumiverse/pulumi-talos/sdk/go/talos/client"
 	"github.com/pulumiverse/pulumi-talos/sdk/go/talos/machine"
 	tmachine "github.com/siderolabs/talos/pkg/machinery/config/machine"
 	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/hooks"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
 	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
 )
 
@@ -21,6 +21,29 @@ type Applier struct {
 	commnanInterpreter  pulumi.StringArray
 	skipInitNode        bool
 
+	applyMode     string
+	tryTimeout    time.Duration
+	rebootTimeout time.Duration
+
+	detectDrift    bool
+	reapplyOnDrift bool
+	drifts         pulumi.StringArrayMap
+
+	plannedDiffs pulumi.StringMap
+	machines     pulumi.StringMapMap
+	stages       map[string]pulumi.StringMap
+	statuses     []pendingStatus
+
+	talosctl     *talosctl.Binaries
+	versionCheck string
+	workDirs     *talosctl.WorkDirs
+
+	migrationMode   string
+	migrationChecks map[string]pulumi.StringOutput
+
+	debugBundle    string
+	debugBundleDir string
+
 	etcdMembers   int
 	etcdReadyHook *pulumi.ResourceHook
 
@@ -39,13 +62,35 @@ func New(ctx *pulumi.Context, name string, client *machine.ClientConfigurationAr
 		parent:              parent,
 		clientConfiguration: client,
 		// 1 is default value, because we have at least one init node.
-		etcdMembers: 1,
+		etcdMembers:   1,
+		applyMode:     ApplyModeAuto,
+		rebootTimeout: DefaultRebootTimeout,
+		debugBundle:   DebugBundleBasic,
+		drifts:        make(pulumi.StringArrayMap),
+		plannedDiffs:  make(pulumi.StringMap),
+		machines:      make(pulumi.StringMapMap),
+		// Checks are done once per machine.
+		migrationChecks: make(map[string]pulumi.StringOutput),
 		commnanInterpreter: pulumi.StringArray{
 			pulumi.String("/bin/bash"),
 			pulumi.String("-c"),
 		},
 	}
 
+	tryTimeout, err := ParseTryTimeout(DefaultTryTimeout)
+	if err != nil {
+		return a, err
+	}
+
+	a.tryTimeout = tryTimeout
+
+	workDirs, err := talosctl.NewWorkDirs("")
+	if err != nil {
+		return a, err
+	}
+
+	a.workDirs = workDirs
+
 	etcdReadyHook, err := a.ctx.RegisterResourceHook("health-check", hooks.EtcdReadyHook(a.ctx.Log), nil)
 	if err != nil {
 		return a, err
@@ -68,6 +113,72 @@ func (a *Applier) WithEtcdMembersCount(count int) *Applier {
 	return a
 }
 
+// WithApplyMode sets the default apply-config mode for machines without their own mode.
+func (a *Applier) WithApplyMode(mode string, tryTimeout time.Duration) *Applier {
+	if mode != "" {
+		a.applyMode = mode
+	}
+
+	if tryTimeout > 0 {
+		a.tryTimeout = tryTimeout
+	}
+
+	return a
+}
+
+// WithRebootTimeout sets how long to wait for a node to come back after a reboot.
+func (a *Applier) WithRebootTimeout(timeout time.Duration) *Applier {
+	if timeout > 0 {
+		a.rebootTimeout = timeout
+	}
+
+	return a
+}
+
+// WithTalosctl sets talosctl binaries to pick from by the Talos version of a machine.
+// versionCheck is the reaction on machines without a matching binary.
+func (a *Applier) WithTalosctl(binaries *talosctl.Binaries, versionCheck string) *Applier {
+	a.talosctl = binaries
+	a.versionCheck = versionCheck
+
+	return a
+}
+
+// WithWorkDirs sets the root of talosctl working directories.
+func (a *Applier) WithWorkDirs(workDirs *talosctl.WorkDirs) *Applier {
+	if workDirs != nil {
+		a.workDirs = workDirs
+	}
+
+	return a
+}
+
+// WithDriftDetection enables comparison of the running configuration with the desired one after apply.
+// If reapply is true, the desired configuration is applied again when a drift is detected.
+func (a *Applier) WithDriftDetection(detect, reapply bool) *Applier {
+	a.detectDrift = detect
+	a.reapplyOnDrift = detect && reapply
+
+	return a
+}
+
+// Drift returns the drifted fields keyed by machine ID.
+// It is empty if drift detection is disabled.
+func (a *Applier) Drift() pulumi.StringArrayMap {
+	return a.drifts
+}
+
+// PlannedConfigDiffs returns the dry-run output of apply-config keyed by machine ID.
+// It is filled only during preview and only for machines whose changed configuration is applied in the update.
+func (a *Applier) PlannedConfigDiffs() pulumi.StringMap {
+	return a.plannedDiffs
+}
+
+// Machines returns the applied state of every machine keyed by machine ID.
+func (a *Applier) Machines() pulumi.StringMapMap {
+	return a.machines
+}
+
 func (a *Applier) NewTalosconfig(endpoints []string, nodes []string) client.GetConfigurationResultOutput {
 	return client.GetConfigurationOutput(a.ctx, client.GetConfigurationOutputArgs{
 		ClusterName: pulumi.String(a.name),
@@ -83,20 +194,14 @@ func (a *Applier) NewTalosconfig(endpoints []string, nodes []string) client.GetC
 
 func (a *Applier) BootstrapInitNode(m *types.MachineInfo) ([]pulumi.Resource, error) {
 	// The Init node is special. We need to init by ourselves.
-	applied, err := a.initApply(m, nil)
+	applied, err := a.initApply(m, tmachine.TypeInit, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	deps := []pulumi.Resource{applied}
 
-	bootstrap, err := machine.NewBootstrap(a.ctx, fmt.Sprintf("%s:bootstrap:%s", a.name, m.MachineID), &machine.BootstrapArgs{
-		ClientConfiguration: a.clientConfiguration,
-		Node:                pulumi.String(m.NodeIP),
-	}, a.parent,
-		pulumi.Timeouts(&pulumi.CustomTimeouts{Create: "1m", Update: "1m"}),
-		pulumi.DependsOn(deps),
-	)
+	bootstrap, err := a.bootstrap(m, deps)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +218,7 @@ func (a *Applier) BootstrapInitNode(m *types.MachineInfo) ([]pulumi.Resource, er
 
 func (a *Applier) InitControlplane(m *types.MachineInfo, deps []pulumi.Resource) ([]pulumi.Resource, error) {
 	if !a.skipInitNode {
-		applied, err := a.initApply(m, deps)
+		applied, err := a.initApply(m, tmachine.TypeControlPlane, deps)
 		if err != nil {
 			return nil, err
 		}
@@ -134,7 +239,7 @@ func (a *Applier) ApplyToControlplane(m *types.MachineInfo, deps []pulumi.Resour
 
 func (a *Applier) ApplyToWorker(m *types.MachineInfo, deps []pulumi.Resource) ([]pulumi.Resource, error) {
 	if !a.skipInitNode {
-		applied, err := a.initApply(m, deps)
+		applied, err := a.initApply(m, tmachine.TypeWorker, deps)
 		if err != nil {
 			return nil, err
 		}
@@ -155,58 +260,135 @@ func (a *Applier) UpgradeK8S(m *types.MachineInfo, deps []pulumi.Resource) ([]pu
 		return deps, err
 	}
 
+	// upgrade-k8s upgrades Kubernetes of the whole cluster.
+	for _, s := range a.statuses {
+		a.trackStage(s.m, stageUpgradeK8SID, upgraded)
+	}
+
 	return append(deps, upgraded), nil
 }
 
 func (a *Applier) cliApply(m *types.MachineInfo, role tmachine.Type, deps []pulumi.Resource) ([]pulumi.Resource, error) {
+	if err := a.checkTalosctl(m); err != nil {
+		return nil, err
+	}
+
 	upgraded, err := a.upgrade(m, role, deps)
 	if err != nil {
 		return nil, err
 	}
 
+	a.trackStage(m, stageUpgradeID, upgraded)
+
 	deps = append(deps, upgraded)
 
-	apply, err := a.apply(m, deps)
+	current, err := a.currentConfig(m, "cli-get-machine-config", deps)
 	if err != nil {
 		return nil, err
 	}
 
+	desired, err := a.gateMigration(m, role, a.desiredConfig(m, current), deps)
+	if err != nil {
+		return nil, err
+	}
+
+	apply, err := a.apply(m, desired, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.ctx.DryRun() {
+		if err := a.plan(m, current, desired, apply, deps); err != nil {
+			return nil, err
+		}
+	}
+
+	a.trackStage(m, stageApplyConfigID, apply)
+
 	deps = append(deps, apply)
 
+	if a.detectDrift {
+		fixed, err := a.drift(m, desired, deps)
+		if err != nil {
+			return nil, err
+		}
+
+		deps = append(deps, fixed...)
+	}
+
+	a.statuses = append(a.statuses, pendingStatus{m: m, desired: desired, deps: deps})
+
 	return deps, nil
 }
 
-func (a *Applier) initApply(m *types.MachineInfo, deps []pulumi.Resource) (pulumi.Resource, error) {
-	apply, err := machine.NewConfigurationApply(a.ctx, fmt.Sprintf("%s:initial-apply:%s", a.name, m.MachineID), &machine.ConfigurationApplyArgs{
-		Node:                      pulumi.String(m.NodeIP),
-		MachineConfigurationInput: pulumi.String(m.Configuration),
-		// Staged is not supported in maintenance.
-		// NoReboot can lead to failures.
-		ApplyMode: pulumi.String("reboot"),
-		OnDestroy: &machine.ConfigurationApplyOnDestroyArgs{
-			Graceful: pulumi.Bool(true),
-			Reboot:   pulumi.Bool(false),
-			Reset:    pulumi.Bool(false),
-		},
-		Timeouts: &machine.TimeoutArgs{
-			Create: pulumi.String("1m"),
-			Update: pulumi.String("1m"),
-		},
-		ClientConfiguration: a.clientConfiguration,
-	}, a.parent,
-		// Ignore changes to machineConfigurationInput to prevent unnecessary updates since there will be an additional apply via cli.
-		// Generated configuration has a contract with immutable talos version and sometimes new options can be skipped.
-		pulumi.IgnoreChanges([]string{"machineConfigurationInput", "applyMode"}),
-		pulumi.Timeouts(&pulumi.CustomTimeouts{Create: "1m", Update: "1m"}),
-		pulumi.DependsOn(deps),
-	)
+// applyModeFor returns the apply mode and the try timeout of the machine, the defaults of the Applier if it has none.
+func (a *Applier) applyModeFor(m *types.MachineInfo) (mode string, tryTimeout time.Duration, err error) {
+	mode, tryTimeout = a.applyMode, a.tryTimeout
+
+	if m.ApplyMode != "" {
+		mode = m.ApplyMode
+	}
+
+	if m.TryTimeout != "" {
+		tryTimeout, err = ParseTryTimeout(m.TryTimeout)
+		if err != nil {
+			return "", 0, fmt.Errorf("machine %s: %w", m.MachineID, err)
+		}
+	}
+
+	return mode, tryTimeout, nil
+}
+
+// applyModeArgsFor returns talosctl apply-config flags for the mode of the machine.
+func (a *Applier) applyModeArgsFor(m *types.MachineInfo) (string, error) {
+	mode, tryTimeout, err := a.applyModeFor(m)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	deps = append(deps, apply)
+	args, err := applyModeArgs(mode, tryTimeout)
+	if err != nil {
+		return "", fmt.Errorf("machine %s: %w", m.MachineID, err)
+	}
+
+	return args, nil
+}
+
+// cli returns talosctl for the machine.
+// The binary matches the Talos version of the machine image if there is such a binary.
+func (a *Applier) cli(m *types.MachineInfo) *talosctl.Talosctl {
+	t := talosctl.New()
+
+	if a.talosctl != nil {
+		bin, _ := a.talosctl.For(talosctl.VersionFromImage(m.TalosImage))
+		t.WithBinary(bin.Path)
+	}
+
+	return t.WithNodeIP(m.NodeIP)
+}
+
+// checkTalosctl reports skew between talosctl and the Talos version of the machine image.
+func (a *Applier) checkTalosctl(m *types.MachineInfo) error {
+	target := talosctl.VersionFromImage(m.TalosImage)
+	if a.talosctl == nil || a.versionCheck == talosctl.VersionCheckSkip || target == "" {
+		return nil
+	}
 
-	return a.reboot(m, deps)
+	bin, ok := a.talosctl.For(target)
+	// The version of the binary is unknown, it is reported once on provider start.
+	if ok || bin.Version == "" {
+		return nil
+	}
+
+	msg := fmt.Sprintf("talosctl %s (%s) does not match Talos %s of machine %s", bin.Version, bin.Path, target, m.MachineID)
+
+	if a.versionCheck == talosctl.VersionCheckFail {
+		return fmt.Errorf("%s. Set talosctlPath to a matching binary or a directory of binaries", msg)
+	}
+
+	a.ctx.Log.Warn("talos-cluster: "+msg, nil)
+
+	return nil
 }
 
 func (a *Applier) basicClient() client.GetConfigurationResultOutput {
@@ -220,6 +402,16 @@ func (a *Applier) basicClient() client.GetConfigurationResultOutput {
 	})
 }
 
-func generateWorkDirNameForTalosctl(stack, step, machineID string) string {
-	return filepath.Join(os.TempDir(), fmt.Sprintf("talos-home-for-%s", stack), fmt.Sprintf("%s-%s", step, machineID))
+// workDir returns the talosctl directory of a resource command. It is the same in every run.
+func (a *Applier) workDir(step, machineID string) string {
+	return a.workDirs.Dir(a.namespace(), a.name, fmt.Sprintf("%s-%s", step, machineID))
+}
+
+// runDir returns the talosctl directory of a get command. It is unique for every run.
+func (a *Applier) runDir(step, machineID string) string {
+	return a.workDirs.RunDir(a.namespace(), a.name, fmt.Sprintf("%s-%s", step, machineID))
+}
+
+func (a *Applier) namespace() string {
+	return talosctl.Namespace(a.ctx.Organization(), a.ctx.Project(), a.ctx.Stack())
 }
@@ -0,0 +1,134 @@
+package applier
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	ApplyModeAuto     = "auto"
+	ApplyModeNoReboot = "no-reboot"
+	ApplyModeReboot   = "reboot"
+	ApplyModeStaged   = "staged"
+	ApplyModeTry      = "try"
+
+	DefaultTryTimeout = "1m"
+)
+
+// ApplyModes lists all modes supported by `talosctl apply-config --mode`.
+var ApplyModes = []string{
+	ApplyModeAuto,
+	ApplyModeNoReboot,
+	ApplyModeReboot,
+	ApplyModeStaged,
+	ApplyModeTry,
+}
+
+// immediatePaths are v1alpha1 fields which Talos applies without a reboot.
+// The list mirrors CanApplyImmediate of the Talos runtime. Other documents are always applied immediately.
+var immediatePaths = []string{
+	"debug",
+	"cluster",
+	"machine.time",
+	"machine.certSANs",
+	"machine.install",
+	"machine.network",
+	"machine.nodeAnnotations",
+	"machine.nodeLabels",
+	"machine.nodeTaints",
+	"machine.sysfs",
+	"machine.sysctls",
+	"machine.logging",
+	"machine.controlPlane",
+	"machine.kubelet",
+	"machine.kernel",
+	"machine.registries",
+	"machine.pods",
+	"machine.seccompProfiles",
+	"machine.features.kubernetesTalosAPIAccess",
+	"machine.features.kubePrism",
+	"machine.features.hostDNS",
+	"machine.features.imageCache",
+	"machine.features.nodeAddressSortAlgorithm",
+}
+
+// ParseTryTimeout parses the timeout of the try mode. It must be a positive duration.
+func ParseTryTimeout(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tryTimeout: %w", err)
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid tryTimeout %q: must be positive", s)
+	}
+
+	return d, nil
+}
+
+// applyModeArgs returns talosctl apply-config flags for the mode.
+func applyModeArgs(mode string, tryTimeout time.Duration) (string, error) {
+	if !slices.Contains(ApplyModes, mode) {
+		return "", fmt.Errorf("unknown apply mode %q, supported: %s", mode, strings.Join(ApplyModes, ", "))
+	}
+
+	args := fmt.Sprintf("--mode=%s", mode)
+
+	if mode == ApplyModeTry {
+		args = fmt.Sprintf("%s --timeout=%s", args, tryTimeout)
+	}
+
+	return args, nil
+}
+
+// ValidateApplyMode checks that the changes between current and desired configuration can be applied in the mode.
+// Modes which never reboot a node (no-reboot and try) can't apply changes requiring a reboot.
+func ValidateApplyMode(mode, current, desired string) error {
+	if mode != ApplyModeNoReboot && mode != ApplyModeTry {
+		return nil
+	}
+
+	d, err := splitConfigDocuments(desired)
+	if err != nil {
+		return fmt.Errorf("desired config: %w", err)
+	}
+
+	c, err := splitConfigDocuments(current)
+	if err != nil {
+		return fmt.Errorf("current config: %w", err)
+	}
+
+	needReboot := make([]string, 0)
+	for _, p := range diffValues("", documentOrEmpty(d), documentOrEmpty(c)) {
+		if !appliesImmediately(p) {
+			needReboot = append(needReboot, p)
+		}
+	}
+
+	if len(needReboot) > 0 {
+		return fmt.Errorf("changes of %s require a reboot and can't be applied in %q mode. Use %q or %q mode instead",
+			strings.Join(needReboot, ", "), mode, ApplyModeAuto, ApplyModeReboot)
+	}
+
+	return nil
+}
+
+func documentOrEmpty(docs map[string]any) map[string]any {
+	if doc, ok := docs[v1alpha1DocumentKey].(map[string]any); ok {
+		return doc
+	}
+
+	return map[string]any{}
+}
+
+func appliesImmediately(path string) bool {
+	for _, p := range immediatePaths {
+		if path == p || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+
+	return false
+}
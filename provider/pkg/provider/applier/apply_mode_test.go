@@ -0,0 +1,69 @@
+package applier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const applyModeCurrent = `version: v1alpha1
+machine:
+  type: worker
+  install:
+    image: ghcr.io/siderolabs/installer:v1.11.0
+  nodeLabels:
+    role: app
+`
+
+func TestValidateApplyMode_ImmediateChange(t *testing.T) {
+	desired := `version: v1alpha1
+machine:
+  type: worker
+  install:
+    image: ghcr.io/siderolabs/installer:v1.11.0
+  nodeLabels:
+    role: db
+`
+
+	require.NoError(t, ValidateApplyMode(ApplyModeNoReboot, applyModeCurrent, desired))
+	require.NoError(t, ValidateApplyMode(ApplyModeTry, applyModeCurrent, desired))
+}
+
+func TestValidateApplyMode_RebootRequired(t *testing.T) {
+	desired := applyModeCurrent + `  env:
+    http_proxy: http://proxy:3128
+`
+
+	err := ValidateApplyMode(ApplyModeNoReboot, applyModeCurrent, desired)
+	require.ErrorContains(t, err, "machine.env")
+	require.ErrorContains(t, err, `"no-reboot" mode`)
+
+	require.NoError(t, ValidateApplyMode(ApplyModeAuto, applyModeCurrent, desired))
+	require.NoError(t, ValidateApplyMode(ApplyModeStaged, applyModeCurrent, desired))
+}
+
+func TestApplyModeArgs(t *testing.T) {
+	args, err := applyModeArgs(ApplyModeTry, 90*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "--mode=try --timeout=1m30s", args)
+
+	args, err = applyModeArgs(ApplyModeReboot, 5*time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "--mode=reboot", args)
+
+	_, err = applyModeArgs("interactive", time.Minute)
+	require.ErrorContains(t, err, "unknown apply mode")
+}
+
+func TestParseTryTimeout(t *testing.T) {
+	d, err := ParseTryTimeout(DefaultTryTimeout)
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, d)
+
+	_, err = ParseTryTimeout("1m; reboot")
+	require.ErrorContains(t, err, "invalid tryTimeout")
+
+	_, err = ParseTryTimeout("-1m")
+	require.ErrorContains(t, err, "must be positive")
+}
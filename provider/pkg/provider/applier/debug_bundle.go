@@ -0,0 +1,80 @@
+package applier
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+const (
+	// DebugBundleNone disables diagnostics collection.
+	DebugBundleNone = "none"
+	// DebugBundleBasic collects dmesg, services, service logs and the machine status.
+	DebugBundleBasic = "basic"
+	// DebugBundleSupport collects a `talosctl support` archive in addition to basic diagnostics.
+	DebugBundleSupport = "support"
+
+	debugBundleCommandTimeout = time.Minute
+	debugBundleSupportTimeout = 5 * time.Minute
+)
+
+// DebugBundleModes are supported values of the debugBundle provider option.
+var DebugBundleModes = []string{DebugBundleNone, DebugBundleBasic, DebugBundleSupport}
+
+// WithDebugBundle sets what is collected from a node when a command fails and where it is written.
+// An empty dir means the debug directory inside the work dir.
+func (a *Applier) WithDebugBundle(mode, dir string) *Applier {
+	if mode != "" {
+		a.debugBundle = mode
+	}
+
+	a.debugBundleDir = dir
+
+	return a
+}
+
+// debugBundleHook returns a script collecting diagnostics of the machine after a failed stage.
+// Logs of services are collected in addition to machined.
+func (a *Applier) debugBundleHook(m *types.MachineInfo, stageName, talos string, services ...string) string {
+	if a.debugBundle == DebugBundleNone {
+		return ""
+	}
+
+	dir := a.workDirs.DebugDir(a.debugBundleDir, a.namespace(), a.name, fmt.Sprintf("%s-%s", stageName, m.MachineID))
+
+	return talosctlDebugBundleScript(talos, m.NodeIP, dir, a.debugBundle == DebugBundleSupport, append([]string{"machined"}, services...))
+}
+
+// talosctlDebugBundleScript collects diagnostics of a node into a new directory inside dir.
+// Every step is limited in time and its failure is ignored, since the node may be unreachable.
+// The path of the bundle is printed to stderr, so it becomes a part of the error of the command.
+func talosctlDebugBundleScript(talos, node, dir string, support bool, services []string) string {
+	timeout := int(debugBundleCommandTimeout.Seconds())
+
+	steps := []string{
+		fmt.Sprintf(`timeout %d %s dmesg > "$bundle/dmesg.log" 2>&1`, timeout, talos),
+		fmt.Sprintf(`timeout %d %s services > "$bundle/services.txt" 2>&1`, timeout, talos),
+		fmt.Sprintf(`timeout %d %s get machinestatus -o yaml > "$bundle/machinestatus.yaml" 2>&1`, timeout, talos),
+	}
+
+	for _, service := range services {
+		steps = append(steps, fmt.Sprintf(`timeout %d %s logs %s > "$bundle/%s.log" 2>&1`, timeout, talos, service, service))
+	}
+
+	if support {
+		steps = append(steps, fmt.Sprintf(`timeout %d %s support -O "$bundle/support.zip" > "$bundle/support.log" 2>&1`,
+			int(debugBundleSupportTimeout.Seconds()), talos))
+	}
+
+	return strings.Join([]string{
+		fmt.Sprintf(`bundle=%q/$(date -u +%%Y%%m%%dT%%H%%M%%SZ)`, filepath.Clean(dir)),
+		`if ( umask 077 && mkdir -p "$bundle" )`,
+		"then " + strings.Join(steps, " ; "),
+		fmt.Sprintf(`echo "talos-cluster: debug bundle of node %s is written to $bundle" >&2`, node),
+		fmt.Sprintf(`else echo "talos-cluster: failed to create debug bundle $bundle of node %s" >&2`, node),
+		"fi",
+	}, " ; ")
+}
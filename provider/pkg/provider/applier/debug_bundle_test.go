@@ -0,0 +1,37 @@
+package applier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTalosctlDebugBundleScript(t *testing.T) {
+	// The node is gone: talosctl fails, but every step is still tried.
+	bin, calls := fakeTalosctl(t, `echo 'connection refused' >&2 ; exit 1`)
+	dir := filepath.Join(t.TempDir(), "cli-upgrade-cp-1")
+
+	out, err := runInDir(t, talosctlDebugBundleScript(bin, "10.0.0.2", dir, true, []string{"machined", "etcd"}))
+	require.NoError(t, err)
+	require.Contains(t, out, "debug bundle of node 10.0.0.2 is written to "+dir+"/")
+
+	bundles, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+
+	bundle := filepath.Join(dir, bundles[0].Name())
+
+	got, err := os.ReadFile(calls)
+	require.NoError(t, err)
+	require.Equal(t, "dmesg\nservices\nget machinestatus -o yaml\nlogs machined\nlogs etcd\nsupport -O "+bundle+"/support.zip\n", string(got))
+
+	dmesg, err := os.ReadFile(filepath.Join(bundle, "dmesg.log"))
+	require.NoError(t, err)
+	require.Equal(t, "connection refused\n", string(dmesg))
+
+	info, err := os.Stat(bundle)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+}
@@ -0,0 +1,276 @@
+package applier
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+	"gopkg.in/yaml.v3"
+)
+
+const v1alpha1DocumentKey = "v1alpha1"
+
+// driftIgnoredPaths are managed by upgrade-k8s and kept by GuardUnmodifyK8sImages,
+// so differences there are not a drift.
+var driftIgnoredPaths = []string{
+	"machine.kubelet.image",
+	"cluster.apiServer.image",
+	"cluster.controllerManager.image",
+	"cluster.scheduler.image",
+	"cluster.proxy.image",
+}
+
+// drift compares the configuration running on the node after apply with the desired one.
+// The result is stored per machine and available via Drift().
+// If reapplyOnDrift is set, an additional apply-config is triggered every time the set of drifted fields changes.
+// The command does not contact the node when there is no drift, e.g. when it is created or the drift is gone.
+func (a *Applier) drift(m *types.MachineInfo, desired pulumi.StringOutput, deps []pulumi.Resource) ([]pulumi.Resource, error) {
+	current, err := a.currentConfig(m, "cli-get-machine-config-drift", deps)
+	if err != nil {
+		return nil, err
+	}
+
+	drifted := pulumi.All(desired, current).ApplyT(func(args []any) ([]string, error) {
+		diff, err := DiffMachineConfigs(args[0].(string), args[1].(string))
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect drift for %s: %w", m.MachineID, err)
+		}
+
+		if len(diff) > 0 {
+			a.ctx.Log.Warn(fmt.Sprintf("talos-cluster: configuration drift detected on %s: %s",
+				m.MachineID, strings.Join(diff, ", ")), nil)
+		}
+
+		return diff, nil
+	}).(pulumi.StringArrayOutput)
+
+	a.drifts[m.MachineID] = drifted
+
+	if !a.reapplyOnDrift {
+		return nil, nil
+	}
+
+	args, err := a.applyConfigArgs(m)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := drifted.ApplyT(driftFingerprint).(pulumi.StringOutput)
+
+	fixed, err := a.applyConfig(m, "cli-drift-fix", desired, fingerprint.ApplyT(func(fp string) string {
+		return driftFixArgs(fp, args, m.NodeIP)
+	}).(pulumi.StringOutput), pulumi.Array{fingerprint}, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	a.trackStage(m, stageDriftFixID, fixed)
+
+	return []pulumi.Resource{fixed}, nil
+}
+
+// DiffMachineConfigs returns the list of fields which differ between desired and actual multi-document configurations.
+// Both configurations are normalized before comparison: empty values are dropped and documents are matched by kind and name.
+func DiffMachineConfigs(desired, actual string) ([]string, error) {
+	d, err := splitConfigDocuments(desired)
+	if err != nil {
+		return nil, fmt.Errorf("desired config: %w", err)
+	}
+
+	c, err := splitConfigDocuments(actual)
+	if err != nil {
+		return nil, fmt.Errorf("actual config: %w", err)
+	}
+
+	keys := make([]string, 0, len(d)+len(c))
+	for k := range d {
+		keys = append(keys, k)
+	}
+	for k := range c {
+		if _, ok := d[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	diff := make([]string, 0)
+	for _, k := range keys {
+		dd, inDesired := d[k]
+		cd, inActual := c[k]
+
+		switch {
+		case !inActual:
+			diff = append(diff, fmt.Sprintf("%s (missing on node)", k))
+		case !inDesired:
+			diff = append(diff, fmt.Sprintf("%s (unexpected on node)", k))
+		default:
+			prefix := ""
+			if k != v1alpha1DocumentKey {
+				prefix = k + ":"
+			}
+			diff = append(diff, diffValues(prefix, dd, cd)...)
+		}
+	}
+
+	return diff, nil
+}
+
+func splitConfigDocuments(config string) (map[string]any, error) {
+	docs := make(map[string]any)
+
+	for i, doc := range splitYaml2All(config) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+
+		var h any
+		if err := yaml.Unmarshal([]byte(doc), &h); err != nil {
+			return nil, fmt.Errorf("doc %d parse: %w", i+1, err)
+		}
+
+		root, ok := normalize(h).(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("doc %d top-level is not a mapping", i+1)
+		}
+
+		key := documentKey(root)
+		if key == v1alpha1DocumentKey {
+			for _, p := range driftIgnoredPaths {
+				deletePath(root, strings.Split(p, "."))
+			}
+		}
+
+		docs[key] = dropEmpty(root)
+	}
+
+	return docs, nil
+}
+
+func documentKey(doc map[string]any) string {
+	kind, ok := doc["kind"].(string)
+	if !ok {
+		return v1alpha1DocumentKey
+	}
+
+	if name, ok := doc["name"].(string); ok {
+		return kind + "/" + name
+	}
+
+	if meta, ok := doc["metadata"].(map[string]any); ok {
+		if name, ok := meta["name"].(string); ok {
+			return kind + "/" + name
+		}
+	}
+
+	return kind
+}
+
+func diffValues(path string, desired, actual any) []string {
+	dm, dok := desired.(map[string]any)
+	am, aok := actual.(map[string]any)
+
+	if !dok || !aok {
+		if reflect.DeepEqual(desired, actual) {
+			return nil
+		}
+
+		return []string{strings.TrimSuffix(path, ".")}
+	}
+
+	keys := make([]string, 0, len(dm)+len(am))
+	for k := range dm {
+		keys = append(keys, k)
+	}
+	for k := range am {
+		if _, ok := dm[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	diff := make([]string, 0)
+	for _, k := range keys {
+		diff = append(diff, diffValues(path+k+".", dm[k], am[k])...)
+	}
+
+	return diff
+}
+
+// dropEmpty removes nil values, empty strings, empty maps and empty lists,
+// since Talos omits them when it encodes the running configuration.
+func dropEmpty(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, vv := range t {
+			vv = dropEmpty(vv)
+			if isEmpty(vv) {
+				delete(t, k)
+				continue
+			}
+			t[k] = vv
+		}
+		return t
+	case []any:
+		out := make([]any, 0, len(t))
+		for _, vv := range t {
+			out = append(out, dropEmpty(vv))
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func isEmpty(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case map[string]any:
+		return len(t) == 0
+	case []any:
+		return len(t) == 0
+	default:
+		return false
+	}
+}
+
+func deletePath(root map[string]any, path []string) {
+	m := root
+	for _, k := range path[:len(path)-1] {
+		nxt, ok := m[k].(map[string]any)
+		if !ok {
+			return
+		}
+		m = nxt
+	}
+	delete(m, path[len(path)-1])
+}
+
+func driftFingerprint(diff []string) string {
+	if len(diff) == 0 {
+		return ""
+	}
+
+	sorted := slices.Clone(diff)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// driftFixArgs returns talosctl arguments of the drift fix: apply when there is a drift, nothing otherwise.
+func driftFixArgs(fingerprint, apply, node string) string {
+	if fingerprint == "" {
+		return fmt.Sprintf("version --client > /dev/null && echo 'node %s: no configuration drift, nothing to apply'", node)
+	}
+
+	return apply
+}
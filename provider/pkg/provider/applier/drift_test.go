@@ -0,0 +1,102 @@
+package applier
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffMachineConfigs_NoDrift(t *testing.T) {
+	desired := `
+version: v1alpha1
+debug: false
+machine:
+  type: worker
+  kubelet:
+    image: ghcr.io/siderolabs/kubelet:v1.33.0
+    extraArgs: {}
+  network:
+    hostname: node-1
+---
+apiVersion: v1alpha1
+kind: ExtensionServiceConfig
+name: cloudflared
+`
+	actual := `
+version: v1alpha1
+debug: false
+machine:
+  type: worker
+  kubelet:
+    image: ghcr.io/siderolabs/kubelet:v1.34.0
+  network:
+    hostname: node-1
+---
+apiVersion: v1alpha1
+kind: ExtensionServiceConfig
+name: cloudflared
+`
+
+	diff, err := DiffMachineConfigs(desired, actual)
+	require.NoError(t, err)
+	require.Empty(t, diff)
+}
+
+func TestDiffMachineConfigs_ReportsChangedFields(t *testing.T) {
+	desired := `
+machine:
+  network:
+    hostname: node-1
+  sysctls:
+    net.core.somaxconn: "65535"
+---
+apiVersion: v1alpha1
+kind: ExtensionServiceConfig
+name: cloudflared
+`
+	actual := `
+machine:
+  network:
+    hostname: edited
+  sysctls:
+    net.core.somaxconn: "65535"
+  time:
+    disabled: true
+---
+apiVersion: v1alpha1
+kind: KmsgLogConfig
+name: remote
+`
+
+	diff, err := DiffMachineConfigs(desired, actual)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"ExtensionServiceConfig/cloudflared (missing on node)",
+		"KmsgLogConfig/remote (unexpected on node)",
+		"machine.network.hostname",
+		"machine.time",
+	}, diff)
+}
+
+func TestDiffMachineConfigs_MalformedActual_ShouldError(t *testing.T) {
+	_, err := DiffMachineConfigs(`machine: {}`, `- not a mapping`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "actual config")
+}
+
+func TestDriftFixArgs_NoDrift(t *testing.T) {
+	bin, calls := fakeTalosctl(t, `exit 0`)
+	apply := "apply-config -f machineconfig.yaml --mode=auto"
+
+	out, err := runInDir(t, bin+" "+driftFixArgs(driftFingerprint(nil), apply, "10.0.0.2"))
+	require.NoError(t, err)
+	require.Contains(t, out, "no configuration drift")
+
+	// Only the client version is printed, the node is not contacted.
+	got, err := os.ReadFile(calls)
+	require.NoError(t, err)
+	require.Equal(t, "version --client\n", string(got))
+
+	require.Equal(t, apply, driftFixArgs(driftFingerprint([]string{"machine.network.hostname"}), apply, "10.0.0.2"))
+}
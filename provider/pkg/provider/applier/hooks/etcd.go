@@ -1,25 +1,30 @@
 package hooks
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	machineapi "github.com/siderolabs/talos/pkg/machinery/api/machine"
+	"github.com/siderolabs/talos/pkg/machinery/client"
+	"github.com/siderolabs/talos/pkg/machinery/resources/etcd"
 	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"google.golang.org/grpc"
 )
 
 type PeerStatus struct {
-	ID      string `json:"id"`
-	Learner bool   `json:"learner"`
+	ID       string `json:"id"`
+	Hostname string `json:"hostname"`
+	Learner  bool   `json:"learner"`
 }
 
 // EtcdReadyHook returns a hook function that waits for the etcd cluster to become healthy.
+// The node is queried via the Talos API with the talosconfig prepared for the hooked command.
 func EtcdReadyHook(logger pulumi.Log) pulumi.ResourceHookFunction {
 	return func(args *pulumi.ResourceHookArgs) error {
 		const (
@@ -35,24 +40,30 @@ func EtcdReadyHook(logger pulumi.Log) pulumi.ResourceHookFunction {
 			return err
 		}
 
-		// 2) Build runner
-		cli := talosctl.New().WithNodeIP(ip)
-		run := makeTalosRunner(cli, workDir, logger)
+		// 2) Build client
+		ctx := context.Background()
+
+		c, err := NewEtcdClient(ctx, ip, filepath.Join(workDir, talosctl.ConfigName))
+		if err != nil {
+			return err
+		}
+		defer c.Close()
 
 		// 3) Wait loop with simple linear backoff
 		consecutiveOK := 0
 		for attempt := 1; attempt <= maxRetries; attempt++ {
 			backoff := time.Duration(attempt) * time.Second
 
-			// 3.1) health/status
-			if err := checkEtcdStatus(run, healthTimeout); err != nil {
+			// 3.1) health/status and alarms
+			if err := CheckEtcdHealth(ctx, c, healthTimeout); err != nil {
+				consecutiveOK = 0
 				logger.Debug(fmt.Sprintf("talos-cluster: etcd status attempt %d/%d failed: %v", attempt, maxRetries, err), nil)
 				time.Sleep(backoff)
 				continue
 			}
 
 			// 3.2) members
-			peers, err := listEtcdPeers(run, listTimeout)
+			peers, err := ListEtcdPeers(ctx, c, listTimeout)
 			if err != nil {
 				logger.Debug(fmt.Sprintf("talos-cluster: etcd members attempt %d/%d failed: %v", attempt, maxRetries, err), nil)
 				time.Sleep(backoff)
@@ -112,9 +123,11 @@ func readEtcdEnv(args *pulumi.ResourceHookArgs) (ip, workDir string, expected in
 
 // ---------- RUNNER ----------
 
-type runnerFn func(timeout time.Duration, args ...string) ([]byte, error)
+// Runner executes talosctl with the given arguments and returns its combined output.
+type Runner func(timeout time.Duration, args ...string) ([]byte, error)
 
-func makeTalosRunner(cli *talosctl.Talosctl, workDir string, logger pulumi.Log) runnerFn {
+// NewTalosRunner returns a Runner which executes the talosctl binary in workDir.
+func NewTalosRunner(cli *talosctl.Talosctl, workDir string, logger pulumi.Log) Runner {
 	return func(timeout time.Duration, args ...string) ([]byte, error) {
 		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
@@ -136,92 +149,98 @@ func makeTalosRunner(cli *talosctl.Talosctl, workDir string, logger pulumi.Log)
 	}
 }
 
-func checkEtcdStatus(run runnerFn, timeout time.Duration) error {
-	_, err := run(timeout, "etcd", "status")
-	return err
+// EtcdClient is the part of the Talos machine API used by etcd checks.
+// It is implemented by the machinery client and can be replaced in tests.
+type EtcdClient interface {
+	EtcdMemberList(ctx context.Context, req *machineapi.EtcdMemberListRequest, callOptions ...grpc.CallOption) (*machineapi.EtcdMemberListResponse, error)
+	EtcdStatus(ctx context.Context, callOptions ...grpc.CallOption) (*machineapi.EtcdStatusResponse, error)
+	EtcdAlarmList(ctx context.Context, callOptions ...grpc.CallOption) (*machineapi.EtcdAlarmListResponse, error)
 }
 
-func listEtcdPeers(run runnerFn, timeout time.Duration) ([]PeerStatus, error) {
-	out, err := run(timeout, "etcd", "members")
+var _ EtcdClient = (*client.Client)(nil)
+
+// NewEtcdClient returns a Talos API client for the node authenticated with the talosconfig file.
+func NewEtcdClient(ctx context.Context, node, talosconfig string) (*client.Client, error) {
+	c, err := client.New(ctx, client.WithConfigFromFile(talosconfig), client.WithEndpoints(node))
 	if err != nil {
-		return nil, err
-	}
-	peers, perr := parseEtcdPeersFromTable(out)
-	if perr != nil {
-		return nil, perr
+		return nil, fmt.Errorf("failed to create Talos API client for %s: %w", node, err)
 	}
-	return peers, nil
+
+	return c, nil
 }
 
-// peersReady checks count and that no peer is a learner.
-func peersReady(peers []PeerStatus, expected int) (bool, string) {
-	if len(peers) != expected {
-		return false, fmt.Sprintf("expected %d members, got %d", expected, len(peers))
-	}
-	for _, p := range peers {
-		if p.Learner {
-			return false, fmt.Sprintf("peer %s is learner", p.ID)
-		}
+// CheckEtcdHealth returns an error if the etcd member of the node reports errors or any member has an active alarm.
+func CheckEtcdHealth(ctx context.Context, c EtcdClient, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status, err := c.EtcdStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("etcd status: %w", err)
 	}
-	return true, "ok"
-}
 
-/* An example of talosctl etcd members
-NODE            ID                 HOSTNAME        PEER URLS                  CLIENT URLS                LEARNER
-91.98.138.169   97f365161a13b437   talos-8me-09g   https://10.10.10.2:2380    https://10.10.10.2:2379    false
-91.98.138.169   c22a6165837acd5b   talos-apx-beq   https://10.10.10.10:2380   https://10.10.10.10:2379   false
-91.98.138.169   d65010d57cf22d49   talos-q2p-yyy   https://10.10.10.5:2380    https://10.10.10.5:2379    false
-*/
-
-// parseEtcdPeersFromTable parses `talosctl etcd members` table output and returns peer statuses.
-// It skips the header (first non-empty line) and any accidental separator/garbage lines.
-func parseEtcdPeersFromTable(out []byte) ([]PeerStatus, error) {
-	sc := bufio.NewScanner(bytes.NewReader(out))
-	// increase max token size to handle long lines safely (up to ~1MB)
-	buf := make([]byte, 0, 64*1024)
-	sc.Buffer(buf, 1<<20)
-
-	lines := make([]string, 0, 8)
-	for sc.Scan() {
-		line := strings.TrimSpace(sc.Text())
-		if line != "" {
-			lines = append(lines, line)
+	for _, msg := range status.GetMessages() {
+		if errs := msg.GetMemberStatus().GetErrors(); len(errs) > 0 {
+			return fmt.Errorf("etcd member %s reports errors: %s",
+				etcd.FormatMemberID(msg.GetMemberStatus().GetMemberId()), strings.Join(errs, ", "))
 		}
 	}
-	if err := sc.Err(); err != nil {
-		return nil, fmt.Errorf("scan output: %w", err)
+
+	alarms, err := c.EtcdAlarmList(ctx)
+	if err != nil {
+		return fmt.Errorf("etcd alarms: %w", err)
 	}
-	if len(lines) == 0 {
-		return nil, fmt.Errorf("no output from talosctl etcd members")
+
+	for _, msg := range alarms.GetMessages() {
+		for _, alarm := range msg.GetMemberAlarms() {
+			if alarm.GetAlarm() != machineapi.EtcdMemberAlarm_NONE {
+				return fmt.Errorf("etcd member %s has alarm %s", etcd.FormatMemberID(alarm.GetMemberId()), alarm.GetAlarm())
+			}
+		}
 	}
 
-	// First non-empty line is the header. Everything after is a member row.
-	memberRows := lines[1:]
-	peers := make([]PeerStatus, 0, len(memberRows))
+	return nil
+}
 
-	for i, row := range memberRows {
-		// Expect at least: NODE ID HOSTNAME PEER_URLS CLIENT_URLS LEARNER
-		fields := strings.Fields(row)
-		if len(fields) < 6 {
-			// skip separator/garbage lines
-			continue
-		}
+// ListEtcdPeers returns members of the etcd cluster as seen by the node.
+func ListEtcdPeers(ctx context.Context, c EtcdClient, timeout time.Duration) ([]PeerStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-		id := fields[1]
-		learnerStr := fields[len(fields)-1] // last column is LEARNER
-		learner, err := strconv.ParseBool(strings.ToLower(learnerStr))
-		if err != nil {
-			return nil, fmt.Errorf("row %d: invalid LEARNER value %q: %w", i+2, learnerStr, err)
-		}
+	resp, err := c.EtcdMemberList(ctx, &machineapi.EtcdMemberListRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("etcd members: %w", err)
+	}
+
+	peers := make([]PeerStatus, 0)
 
-		peers = append(peers, PeerStatus{
-			ID:      id,
-			Learner: learner,
-		})
+	// A single node is queried, so there is one message at most.
+	for _, msg := range resp.GetMessages() {
+		for _, m := range msg.GetMembers() {
+			peers = append(peers, PeerStatus{
+				ID:       etcd.FormatMemberID(m.GetId()),
+				Hostname: m.GetHostname(),
+				Learner:  m.GetIsLearner(),
+			})
+		}
 	}
 
 	if len(peers) == 0 {
-		return nil, fmt.Errorf("no peer rows found in talosctl output")
+		return nil, fmt.Errorf("no etcd members returned")
 	}
+
 	return peers, nil
 }
+
+// peersReady checks count and that no peer is a learner.
+func peersReady(peers []PeerStatus, expected int) (bool, string) {
+	if len(peers) != expected {
+		return false, fmt.Sprintf("expected %d members, got %d", expected, len(peers))
+	}
+	for _, p := range peers {
+		if p.Learner {
+			return false, fmt.Sprintf("peer %s is learner", p.ID)
+		}
+	}
+	return true, "ok"
+}
@@ -0,0 +1,100 @@
+package hooks
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	machineapi "github.com/siderolabs/talos/pkg/machinery/api/machine"
+	"github.com/siderolabs/talos/pkg/machinery/client"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// fakeMachineService serves the etcd part of the Talos machine API.
+type fakeMachineService struct {
+	machineapi.UnimplementedMachineServiceServer
+
+	members []*machineapi.EtcdMember
+	errors  []string
+	alarms  []*machineapi.EtcdMemberAlarm
+}
+
+func (f *fakeMachineService) EtcdMemberList(context.Context, *machineapi.EtcdMemberListRequest) (*machineapi.EtcdMemberListResponse, error) {
+	return &machineapi.EtcdMemberListResponse{
+		Messages: []*machineapi.EtcdMembers{{Members: f.members}},
+	}, nil
+}
+
+func (f *fakeMachineService) EtcdStatus(context.Context, *emptypb.Empty) (*machineapi.EtcdStatusResponse, error) {
+	return &machineapi.EtcdStatusResponse{
+		Messages: []*machineapi.EtcdStatus{{
+			MemberStatus: &machineapi.EtcdMemberStatus{MemberId: 0x97f365161a13b437, Errors: f.errors},
+		}},
+	}, nil
+}
+
+func (f *fakeMachineService) EtcdAlarmList(context.Context, *emptypb.Empty) (*machineapi.EtcdAlarmListResponse, error) {
+	return &machineapi.EtcdAlarmListResponse{
+		Messages: []*machineapi.EtcdAlarm{{MemberAlarms: f.alarms}},
+	}, nil
+}
+
+// newFakeClient starts a local gRPC server with the fake service and returns the machinery client connected to it.
+func newFakeClient(t *testing.T, svc *fakeMachineService) *client.Client {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	machineapi.RegisterMachineServiceServer(srv, svc)
+
+	go srv.Serve(lis) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return &client.Client{MachineClient: machineapi.NewMachineServiceClient(conn)}
+}
+
+func TestListEtcdPeers(t *testing.T) {
+	c := newFakeClient(t, &fakeMachineService{
+		members: []*machineapi.EtcdMember{
+			{Id: 0x97f365161a13b437, Hostname: "talos-8me-09g"},
+			{Id: 0xc22a6165837acd5b, Hostname: "talos-apx-beq", IsLearner: true},
+		},
+	})
+
+	peers, err := ListEtcdPeers(context.Background(), c, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, []PeerStatus{
+		{ID: "97f365161a13b437", Hostname: "talos-8me-09g"},
+		{ID: "c22a6165837acd5b", Hostname: "talos-apx-beq", Learner: true},
+	}, peers)
+
+	ok, reason := peersReady(peers, 2)
+	require.False(t, ok)
+	require.Contains(t, reason, "c22a6165837acd5b is learner")
+}
+
+func TestCheckEtcdHealth(t *testing.T) {
+	require.NoError(t, CheckEtcdHealth(context.Background(), newFakeClient(t, &fakeMachineService{
+		alarms: []*machineapi.EtcdMemberAlarm{{MemberId: 1, Alarm: machineapi.EtcdMemberAlarm_NONE}},
+	}), time.Second))
+
+	err := CheckEtcdHealth(context.Background(), newFakeClient(t, &fakeMachineService{
+		errors: []string{"raft: stopped"},
+	}), time.Second)
+	require.ErrorContains(t, err, "97f365161a13b437 reports errors: raft: stopped")
+
+	err = CheckEtcdHealth(context.Background(), newFakeClient(t, &fakeMachineService{
+		alarms: []*machineapi.EtcdMemberAlarm{{MemberId: 0xc22a6165837acd5b, Alarm: machineapi.EtcdMemberAlarm_NOSPACE}},
+	}), time.Second)
+	require.ErrorContains(t, err, "c22a6165837acd5b has alarm NOSPACE")
+}
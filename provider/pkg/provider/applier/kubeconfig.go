@@ -0,0 +1,59 @@
+package applier
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/kubeconfig"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+// NewKubeconfig returns the admin kubeconfig generated from the configuration of a controlplane machine
+// with the kubeconfig options applied. It does not need the machine to be reachable.
+//
+// The generated kubeconfig is kept until certLifetime is changed or the certificate reaches the middle of its lifetime.
+// Other options only rewrite names and the server of the kept kubeconfig, so they do not issue a new certificate.
+// A missing certLifetime means the lifetime from the machine configuration.
+func (a *Applier) NewKubeconfig(m *types.MachineInfo, opts map[string]string) (pulumi.StringOutput, error) {
+	lifetime := time.Duration(0)
+
+	if l := opts[kubeconfig.OptionCertLifetime]; l != "" {
+		d, err := time.ParseDuration(l)
+		if err != nil {
+			return pulumi.StringOutput{}, fmt.Errorf("invalid %s: %w", kubeconfig.OptionCertLifetime, err)
+		}
+
+		if d <= 0 {
+			return pulumi.StringOutput{}, fmt.Errorf("invalid %s %q: must be positive", kubeconfig.OptionCertLifetime, l)
+		}
+
+		lifetime = d
+	}
+
+	now := time.Now()
+
+	raw, err := kubeconfig.GenerateAdmin(m.Configuration, lifetime, now)
+	if err != nil {
+		return pulumi.StringOutput{}, fmt.Errorf("failed to generate kubeconfig from %s: %w", m.MachineID, err)
+	}
+
+	expiry, err := kubeconfig.CertificateExpiry(raw)
+	if err != nil {
+		return pulumi.StringOutput{}, err
+	}
+
+	cluster, err := kubeconfig.ClusterFingerprint(raw)
+	if err != nil {
+		return pulumi.StringOutput{}, err
+	}
+
+	kept, err := a.keepSecret(fmt.Sprintf("%s:kubeconfig", a.name), raw, expiry.Sub(now), lifetime.String(), cluster)
+	if err != nil {
+		return pulumi.StringOutput{}, err
+	}
+
+	return kept.ApplyT(func(raw string) (string, error) {
+		return kubeconfig.Rewrite(raw, kubeconfig.NewOptions(opts))
+	}).(pulumi.StringOutput), nil
+}
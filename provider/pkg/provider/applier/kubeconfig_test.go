@@ -0,0 +1,54 @@
+package applier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/siderolabs/talos/pkg/machinery/config/generate"
+	"github.com/siderolabs/talos/pkg/machinery/config/machine"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/kubeconfig"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+func TestNewKubeconfig_ChangedOptionsAreServed(t *testing.T) {
+	in, err := generate.NewInput("dev", "https://10.0.0.2:6443", "1.33.0")
+	require.NoError(t, err)
+
+	m := &types.MachineInfo{MachineID: "cp-1", Configuration: generatedConfig(t, in, machine.TypeControlPlane)}
+
+	e := newCommandEngine()
+	admin := func(opts map[string]string) string {
+		return e.run(t, func(ctx *pulumi.Context) (pulumi.StringOutput, error) {
+			a := &Applier{ctx: ctx, name: "dev", parent: pulumi.Parent(nil)}
+
+			return a.NewKubeconfig(m, opts)
+		})
+	}
+
+	first := admin(map[string]string{kubeconfig.OptionServer: "https://k8s.example.com:6443"})
+	require.Contains(t, first, "server: https://k8s.example.com:6443")
+
+	// The kept kubeconfig is rewritten with the new options, the certificate is the same.
+	renamed := admin(map[string]string{kubeconfig.OptionServer: "https://api.example.com:6443"})
+	require.Contains(t, renamed, "server: https://api.example.com:6443")
+	require.Equal(t, certExpiry(t, first), certExpiry(t, renamed))
+
+	// A new lifetime issues a new certificate.
+	short := admin(map[string]string{kubeconfig.OptionCertLifetime: "24h"})
+	require.WithinDuration(t, time.Now().Add(24*time.Hour), certExpiry(t, short), time.Minute)
+
+	_, err = (&Applier{}).NewKubeconfig(m, map[string]string{kubeconfig.OptionCertLifetime: "0s"})
+	require.EqualError(t, err, `invalid certLifetime "0s": must be positive`)
+}
+
+func certExpiry(t *testing.T, raw string) time.Time {
+	t.Helper()
+
+	expiry, err := kubeconfig.CertificateExpiry(raw)
+	require.NoError(t, err)
+
+	return expiry
+}
@@ -0,0 +1,197 @@
+package applier
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi-command/sdk/go/command/local"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+const (
+	// Operations recorded as the last operation on a machine.
+	OperationUnknown      = "unknown"
+	OperationInitialApply = "initial-apply"
+	OperationUpgrade      = "upgrade"
+	OperationUpgradeK8S   = "upgrade-k8s"
+	OperationApplyConfig  = "apply-config"
+
+	MachineStatusConfigHash           = "configHash"
+	MachineStatusTalosVersion         = "talosVersion"
+	MachineStatusObservedTalosVersion = "observedTalosVersion"
+	MachineStatusKubernetesVersion    = "kubernetesVersion"
+	MachineStatusLastOperation        = "lastOperation"
+	MachineStatusLastOperationTime    = "lastOperationTime"
+
+	// Environment variables of machineStatusScript with IDs of stage commands.
+	stageInitialApplyID = "INITIAL_APPLY_ID"
+	stageUpgradeID      = "UPGRADE_ID"
+	stageUpgradeK8SID   = "UPGRADE_K8S_ID"
+	stageApplyConfigID  = "APPLY_CONFIG_ID"
+	stageDriftFixID     = "DRIFT_FIX_ID"
+
+	// machineStatusTalosImage is kept in the state to detect upgrades, it is not a part of the status.
+	machineStatusTalosImage = "talosImage"
+)
+
+// Operations lists all operations recorded as the last operation.
+var Operations = []string{OperationUnknown, OperationInitialApply, OperationUpgrade, OperationUpgradeK8S, OperationApplyConfig}
+
+// machineStatusStages are stage commands with the status field their ID is kept in.
+// Only a replace runs a stage command and it gets a new ID then, so a changed ID means the stage ran.
+// The order is the priority of the operation if several stages ran.
+var machineStatusStages = []struct {
+	env, field, operation string
+}{
+	{stageInitialApplyID, "initialApplyId", OperationInitialApply},
+	{stageUpgradeID, "upgradeId", OperationUpgrade},
+	{stageUpgradeK8SID, "upgradeK8sId", OperationUpgradeK8S},
+	{stageApplyConfigID, "applyConfigId", OperationApplyConfig},
+	{stageDriftFixID, "driftFixId", OperationApplyConfig},
+}
+
+// machineStatusScript prints the desired state of a machine with the operation which changed it.
+// pulumi-command passes the previous stdout in PULUMI_COMMAND_STDOUT on update,
+// so the operation is the one of the stage whose command ID differs from the previous one.
+// Without a previous state the machine is created in this run if it has the initial apply,
+// otherwise, e.g. in the import migration mode, the operation is unknown.
+// If no stage ran, the previous operation is kept.
+var machineStatusScript = func() string {
+	detect := []string{fmt.Sprintf(`if [ -z "$prev" ] && [ -n "$%s" ] ; then op=%s ; at=$now ; `+
+		`elif [ -z "$prev" ] ; then op=%s ; at=''`, stageInitialApplyID, OperationInitialApply, OperationUnknown)}
+	format := []string{MachineStatusConfigHash, machineStatusTalosImage, MachineStatusKubernetesVersion,
+		MachineStatusLastOperation, MachineStatusLastOperationTime}
+	values := []string{`"$CONFIG_HASH"`, `"$TALOS_IMAGE"`, `"$KUBERNETES_VERSION"`, `"$op"`, `"$at"`}
+
+	for _, s := range machineStatusStages {
+		detect = append(detect, fmt.Sprintf(`elif ran %s "$%s" ; then op=%s ; at=$now`, s.field, s.env, s.operation))
+		format = append(format, s.field)
+		values = append(values, fmt.Sprintf(`"$%s"`, s.env))
+	}
+
+	return strings.Join([]string{
+		`prev="${PULUMI_COMMAND_STDOUT:-}"`,
+		`get() { printf '%s\n' "$prev" | sed -n "s/^$1=//p" ; }`,
+		`ran() { [ -n "$2" ] && [ "$(get "$1")" != "$2" ] ; }`,
+		fmt.Sprintf(`op=$(get %s) ; at=$(get %s) ; now=$(date -u +%%Y-%%m-%%dT%%H:%%M:%%SZ)`,
+			MachineStatusLastOperation, MachineStatusLastOperationTime),
+		strings.Join(detect, " ; ") + " ; fi",
+		fmt.Sprintf(`printf '%s=%%s\n' %s`, strings.Join(format, `=%s\n`), strings.Join(values, " ")),
+	}, " ; ")
+}()
+
+// pendingStatus is a machine whose status is recorded after all stages of the cluster.
+type pendingStatus struct {
+	m       *types.MachineInfo
+	desired pulumi.StringOutput
+	deps    []pulumi.Resource
+}
+
+// trackStage keeps the ID of a stage command of a machine for its status.
+func (a *Applier) trackStage(m *types.MachineInfo, env string, stage pulumi.Resource) {
+	if a.stages == nil {
+		a.stages = make(map[string]pulumi.StringMap)
+	}
+
+	if a.stages[m.MachineID] == nil {
+		a.stages[m.MachineID] = make(pulumi.StringMap)
+	}
+
+	a.stages[m.MachineID][env] = stage.(pulumi.CustomResource).ID().ToStringOutput()
+}
+
+// RecordStatuses records the status of every applied machine. It is called after all stages,
+// since upgrade-k8s runs once for the whole cluster after machines are applied.
+func (a *Applier) RecordStatuses(deps []pulumi.Resource) error {
+	for _, s := range a.statuses {
+		if err := a.recordStatus(s.m, s.desired, append(s.deps, deps...)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordStatus keeps the applied state of a machine in a local command resource and queries the running Talos version.
+// The resource is updated only if the applied configuration, the image, the Kubernetes version or a stage command is changed,
+// so its stdout holds the last operation on the machine and its time. The result is available via Machines().
+func (a *Applier) recordStatus(m *types.MachineInfo, desired pulumi.StringOutput, deps []pulumi.Resource) error {
+	env := pulumi.StringMap{
+		"CONFIG_HASH":        desired.ApplyT(ConfigHash).(pulumi.StringOutput),
+		"TALOS_IMAGE":        pulumi.String(m.TalosImage),
+		"KUBERNETES_VERSION": pulumi.String(m.KubernetesVersion),
+	}
+
+	for _, s := range machineStatusStages {
+		env[s.env] = pulumi.String("")
+	}
+
+	for k, v := range a.stages[m.MachineID] {
+		env[k] = v
+	}
+
+	record, err := local.NewCommand(a.ctx, fmt.Sprintf("%s:status:%s", a.name, m.MachineID), &local.CommandArgs{
+		Create:      pulumi.String(machineStatusScript),
+		Interpreter: a.commnanInterpreter,
+		Environment: env,
+	}, a.parent, pulumi.DependsOn(deps))
+	if err != nil {
+		return err
+	}
+
+	version, err := a.cli(m).RunGetCommand(a.ctx, &talosctl.Args{
+		TalosConfig: a.basicClient().TalosConfig(),
+		Dir:         a.runDir("cli-version", m.MachineID),
+		CommandArgs: pulumi.String("version"),
+		Retry:       talosctl.GetRetryPolicy(),
+	}, append(deps, record))
+	if err != nil {
+		return fmt.Errorf("failed to get talos version of %s: %w", m.MachineID, err)
+	}
+
+	a.machines[m.MachineID] = pulumi.All(record.Stdout, version).ApplyT(func(v []any) map[string]string {
+		status := ParseMachineStatus(v[0].(string))
+		status[MachineStatusTalosVersion] = talosctl.VersionFromImage(m.TalosImage)
+		status[MachineStatusObservedTalosVersion] = talosctl.ServerVersion(v[1].(string))
+
+		if status[MachineStatusObservedTalosVersion] != "" && status[MachineStatusTalosVersion] != "" &&
+			status[MachineStatusObservedTalosVersion] != status[MachineStatusTalosVersion] {
+			a.ctx.Log.Warn(fmt.Sprintf("talos-cluster: machine %s runs Talos %s, but %s is expected", m.MachineID,
+				status[MachineStatusObservedTalosVersion], status[MachineStatusTalosVersion]), nil)
+		}
+
+		return status
+	}).(pulumi.StringMapOutput)
+
+	return nil
+}
+
+// ParseMachineStatus parses the output of machineStatusScript.
+func ParseMachineStatus(out string) map[string]string {
+	status := map[string]string{
+		MachineStatusConfigHash:        "",
+		MachineStatusKubernetesVersion: "",
+		MachineStatusLastOperation:     "",
+		MachineStatusLastOperationTime: "",
+	}
+
+	for _, line := range strings.Split(out, "\n") {
+		k, v, ok := strings.Cut(line, "=")
+		if _, known := status[k]; ok && known {
+			status[k] = v
+		}
+	}
+
+	return status
+}
+
+// ConfigHash returns the SHA-256 hash of a machine configuration.
+func ConfigHash(config string) string {
+	sum := sha256.Sum256([]byte(config))
+
+	return hex.EncodeToString(sum[:])
+}
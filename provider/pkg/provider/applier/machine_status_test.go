@@ -0,0 +1,77 @@
+package applier
+
+import (
+	"maps"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runMachineStatusScript(t *testing.T, prev, hash string, stages map[string]string) map[string]string {
+	t.Helper()
+
+	cmd := exec.Command("/bin/bash", "-c", machineStatusScript)
+	cmd.Env = append(os.Environ(),
+		"PULUMI_COMMAND_STDOUT="+prev,
+		"CONFIG_HASH="+hash,
+		"TALOS_IMAGE=ghcr.io/siderolabs/installer:v1.11.0",
+		"KUBERNETES_VERSION=v1.33.0",
+	)
+
+	for _, s := range machineStatusStages {
+		cmd.Env = append(cmd.Env, s.env+"="+stages[s.env])
+	}
+
+	out, err := cmd.Output()
+	require.NoError(t, err)
+
+	return ParseMachineStatus(string(out))
+}
+
+func TestMachineStatusScript_Operations(t *testing.T) {
+	stages := map[string]string{stageInitialApplyID: "i1", stageUpgradeID: "u1", stageUpgradeK8SID: "k1", stageApplyConfigID: "a1"}
+
+	// The machine is created in this run.
+	created := runMachineStatusScript(t, "", "h1", stages)
+	require.Equal(t, OperationInitialApply, created[MachineStatusLastOperation])
+	require.Equal(t, "h1", created[MachineStatusConfigHash])
+	require.Regexp(t, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`, created[MachineStatusLastOperationTime])
+
+	// The initial apply is imported, so the last operation is not known.
+	imported := runMachineStatusScript(t, "", "h1", map[string]string{stageUpgradeID: "u1", stageApplyConfigID: "a1"})
+	require.Equal(t, OperationUnknown, imported[MachineStatusLastOperation])
+	require.Empty(t, imported[MachineStatusLastOperationTime])
+
+	prev := "configHash=h1\nlastOperation=initial-apply\nlastOperationTime=2026-01-02T03:04:05Z\n" +
+		"initialApplyId=i1\nupgradeId=u1\nupgradeK8sId=k1\napplyConfigId=a1\ndriftFixId=\n"
+	ran := func(env, id string) map[string]string {
+		changed := maps.Clone(stages)
+		changed[env] = id
+
+		return runMachineStatusScript(t, prev, "h2", changed)
+	}
+
+	require.Equal(t, OperationApplyConfig, ran(stageApplyConfigID, "a2")[MachineStatusLastOperation])
+	require.Equal(t, OperationApplyConfig, ran(stageDriftFixID, "d1")[MachineStatusLastOperation])
+	require.Equal(t, OperationUpgrade, ran(stageUpgradeID, "u2")[MachineStatusLastOperation])
+	require.Equal(t, OperationUpgradeK8S, ran(stageUpgradeK8SID, "k2")[MachineStatusLastOperation])
+
+	// The configuration is regenerated, but apply-config did not run: the previous operation is kept.
+	kept := runMachineStatusScript(t, prev, "h2", stages)
+	require.Equal(t, OperationInitialApply, kept[MachineStatusLastOperation])
+	require.Equal(t, "2026-01-02T03:04:05Z", kept[MachineStatusLastOperationTime])
+	require.Equal(t, "h2", kept[MachineStatusConfigHash])
+}
+
+func TestParseMachineStatus_IgnoresUnknownKeys(t *testing.T) {
+	status := ParseMachineStatus("configHash=abc\ntalosImage=img\nlastOperation=upgrade\n")
+
+	require.Equal(t, map[string]string{
+		MachineStatusConfigHash:        "abc",
+		MachineStatusKubernetesVersion: "",
+		MachineStatusLastOperation:     OperationUpgrade,
+		MachineStatusLastOperationTime: "",
+	}, status)
+}
@@ -0,0 +1,138 @@
+package applier
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/siderolabs/talos/pkg/machinery/config/configloader"
+	tmachine "github.com/siderolabs/talos/pkg/machinery/config/machine"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+const (
+	// MigrationModeNone registers resources as is. It is used for new stacks.
+	MigrationModeNone = ""
+	// MigrationModeAliases registers the initial apply and bootstrap with aliases to the former pulumiverse resources.
+	MigrationModeAliases = "aliases"
+	// MigrationModeImport takes the initial apply and bootstrap as done, like they were imported from the old state.
+	// Nodes are not contacted by these steps. Cluster secrets are taken from the pulumiverse Secrets resource.
+	MigrationModeImport = "import"
+)
+
+// MigrationModes are supported values of the migrationMode provider option.
+var MigrationModes = []string{MigrationModeAliases, MigrationModeImport}
+
+// etcdRunningRe matches the state line of `talosctl service etcd`.
+var etcdRunningRe = regexp.MustCompile(`(?m)^STATE\s+Running\s*$`)
+
+// WithMigrationMode sets how resources of stacks created with pulumiverse resources are migrated.
+func (a *Applier) WithMigrationMode(mode string) *Applier {
+	a.migrationMode = mode
+	return a
+}
+
+// legacyAliases returns aliases to the pulumiverse resource type in the aliases migration mode.
+func (a *Applier) legacyAliases(typ string) []pulumi.ResourceOption {
+	if a.migrationMode != MigrationModeAliases {
+		return nil
+	}
+
+	return []pulumi.ResourceOption{pulumi.Aliases([]pulumi.Alias{{Type: pulumi.String(typ)}})}
+}
+
+// gateMigration returns args which resolve only if the migration check of the machine passes,
+// so a command using them never runs against the node if the migration would touch it.
+// args are returned as is if there is no migration.
+func (a *Applier) gateMigration(m *types.MachineInfo, role tmachine.Type, args pulumi.StringOutput, deps []pulumi.Resource) (pulumi.StringOutput, error) {
+	if a.migrationMode == MigrationModeNone {
+		return args, nil
+	}
+
+	check, err := a.verifyMigration(m, role, deps)
+	if err != nil {
+		return pulumi.StringOutput{}, err
+	}
+
+	return pulumi.All(check, args).ApplyT(func(v []any) string {
+		return v[1].(string)
+	}).(pulumi.StringOutput), nil
+}
+
+// verifyMigration fails if the migration would run anything against the node:
+// the initial apply of a node which is not configured, a bootstrap of the init node whose etcd is not running
+// or an apply of a configuration with other cluster secrets.
+// The check runs once per machine before its first step, in the update as well as in the preview,
+// since an update can run without a preview.
+func (a *Applier) verifyMigration(m *types.MachineInfo, role tmachine.Type, deps []pulumi.Resource) (pulumi.StringOutput, error) {
+	if check, ok := a.migrationChecks[m.MachineID]; ok {
+		return check, nil
+	}
+
+	current, err := a.currentConfig(m, "migration-machine-config", deps)
+	if err != nil {
+		return pulumi.StringOutput{}, err
+	}
+
+	etcd := pulumi.String("").ToStringOutput()
+
+	if role == tmachine.TypeInit {
+		stageName := "migration-etcd-status"
+
+		out, err := a.cli(m).RunGetCommand(a.ctx, &talosctl.Args{
+			TalosConfig: a.basicClient().TalosConfig(),
+			Dir:         a.runDir(stageName, m.MachineID),
+			CommandArgs: pulumi.String("service etcd"),
+			Retry:       talosctl.GetRetryPolicy(),
+		}, deps)
+		if err != nil {
+			return pulumi.StringOutput{}, err
+		}
+
+		etcd = out
+	}
+
+	check := pulumi.All(current, etcd).ApplyT(func(v []any) (string, error) {
+		if err := VerifyMigration(role, v[0].(string), m.Configuration, v[1].(string)); err != nil {
+			return "", fmt.Errorf("migration (%s mode) of machine %s: %w", a.migrationMode, m.MachineID, err)
+		}
+
+		return "", nil
+	}).(pulumi.StringOutput)
+
+	a.migrationChecks[m.MachineID] = check
+
+	return check, nil
+}
+
+// VerifyMigration checks that the node runs a configuration with the same cluster secrets as desired
+// and that etcd of the init node is running.
+func VerifyMigration(role tmachine.Type, current, desired, etcdStatus string) error {
+	if current == "" {
+		return fmt.Errorf("the node is not configured, the initial apply would run against it")
+	}
+
+	running, err := configloader.NewFromBytes([]byte(current))
+	if err != nil {
+		return fmt.Errorf("failed to parse the running configuration: %w", err)
+	}
+
+	wanted, err := configloader.NewFromBytes([]byte(desired))
+	if err != nil {
+		return fmt.Errorf("failed to parse the desired configuration: %w", err)
+	}
+
+	if running.Cluster().ID() != wanted.Cluster().ID() ||
+		!slices.Equal(running.Machine().Security().IssuingCA().Crt, wanted.Machine().Security().IssuingCA().Crt) {
+		return fmt.Errorf("the cluster secrets differ from the running ones, the node would be reconfigured with new secrets. " +
+			"Pass the secrets of the existing cluster as existingSecrets of the Cluster")
+	}
+
+	if role == tmachine.TypeInit && !etcdRunningRe.MatchString(etcdStatus) {
+		return fmt.Errorf("etcd is not running, the bootstrap would run against the node")
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package applier
+
+import (
+	"testing"
+
+	"github.com/siderolabs/talos/pkg/machinery/config/generate"
+	"github.com/siderolabs/talos/pkg/machinery/config/machine"
+	"github.com/stretchr/testify/require"
+)
+
+func generatedConfig(t *testing.T, in *generate.Input, typ machine.Type) string {
+	t.Helper()
+
+	cfg, err := in.Config(typ)
+	require.NoError(t, err)
+
+	out, err := cfg.EncodeString()
+	require.NoError(t, err)
+
+	return out
+}
+
+func TestVerifyMigration(t *testing.T) {
+	in, err := generate.NewInput("dev", "https://10.0.0.2:6443", "1.33.0")
+	require.NoError(t, err)
+
+	other, err := generate.NewInput("dev", "https://10.0.0.2:6443", "1.33.0")
+	require.NoError(t, err)
+
+	running := generatedConfig(t, in, machine.TypeInit)
+	etcd := "NODE     10.0.0.2\nID       etcd\nSTATE    Running\nHEALTH   OK\n"
+
+	require.NoError(t, VerifyMigration(machine.TypeInit, running, generatedConfig(t, in, machine.TypeControlPlane), etcd))
+	require.NoError(t, VerifyMigration(machine.TypeWorker, generatedConfig(t, in, machine.TypeWorker), generatedConfig(t, in, machine.TypeWorker), ""))
+
+	err = VerifyMigration(machine.TypeWorker, "", generatedConfig(t, in, machine.TypeWorker), "")
+	require.ErrorContains(t, err, "not configured")
+
+	err = VerifyMigration(machine.TypeInit, running, generatedConfig(t, other, machine.TypeControlPlane), etcd)
+	require.ErrorContains(t, err, "existingSecrets")
+
+	err = VerifyMigration(machine.TypeInit, running, running, "STATE    Preparing\n")
+	require.ErrorContains(t, err, "bootstrap")
+}
@@ -0,0 +1,155 @@
+package applier
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/internals"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+const redactedValue = "<redacted>"
+
+// secretFieldRe matches the content of diff lines with sensitive machine configuration fields,
+// e.g. `        token: abc.def`, `    - key: LS0t...`, `secretboxEncryptionSecret: ...` or `crt: |-`.
+// The first group is the indent of the key including a list item dash, the third is the value.
+var secretFieldRe = regexp.MustCompile(`(?i)^(\s*(?:-\s+)?)([\w.-]*(?:token|secret|key|crt|password)[\w.-]*):(?:[ \t]+(\S.*))?$`)
+
+// RedactConfigDiff hides values of certificates, keys, tokens and other secrets in a configuration diff.
+// Values spanning several lines, like block scalars and lists, are hidden entirely:
+// every line indented deeper than the key is redacted, as well as list items at the indent of a key without an inline value.
+func RedactConfigDiff(diff string) string {
+	lines := strings.Split(diff, "\n")
+
+	// The indent of the key whose value is being redacted, -1 outside of such a value.
+	block := -1
+	list := false
+
+	for i, line := range lines {
+		marker, content, ok := diffContent(line)
+		if !ok {
+			block = -1
+			continue
+		}
+
+		trimmed := strings.TrimLeft(content, " \t")
+		indent := len(content) - len(trimmed)
+
+		if block >= 0 {
+			if trimmed == "" {
+				continue
+			}
+
+			if indent > block || (list && indent == block && strings.HasPrefix(trimmed, "-")) {
+				lines[i] = marker + content[:indent] + redactedValue
+				continue
+			}
+
+			block = -1
+		}
+
+		m := secretFieldRe.FindStringSubmatch(content)
+		if m == nil {
+			continue
+		}
+
+		block, list = len(m[1]), m[3] == ""
+
+		if m[3] != "" {
+			lines[i] = marker + m[1] + m[2] + ": " + redactedValue
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// diffContent splits a line of a unified diff into the change marker and the content.
+// Headers and other lines which are not a part of the configuration are not content.
+func diffContent(line string) (marker, content string, ok bool) {
+	if line == "" || strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---") || strings.HasPrefix(line, "@@") {
+		return "", "", false
+	}
+
+	if !strings.ContainsAny(line[:1], "+- ") {
+		return "", "", false
+	}
+
+	return line[:1], line[1:], true
+}
+
+// plan runs apply-config in dry-run mode for a machine whose desired configuration differs from the running one.
+// The redacted result is logged and stored per machine and available via PlannedConfigDiffs().
+// A difference is shown as planned only if the apply command reruns, i.e. the rendered configuration or the endpoint is changed.
+// Otherwise the node drifted from a configuration which is already applied, and the apply does not touch it.
+func (a *Applier) plan(m *types.MachineInfo, current, desired pulumi.StringOutput, apply pulumi.Resource, deps []pulumi.Resource) error {
+	stageName := "cli-apply-config-dry-run"
+
+	modeArgs, err := a.applyModeArgsFor(m)
+	if err != nil {
+		return err
+	}
+
+	planned := pulumi.All(current, desired, applyReruns(a.ctx, apply)).ApplyT(func(args []any) (pulumi.StringOutput, error) {
+		diff, err := DiffMachineConfigs(args[1].(string), args[0].(string))
+		if err != nil {
+			return pulumi.StringOutput{}, fmt.Errorf("failed to compare configuration of %s: %w", m.MachineID, err)
+		}
+
+		// Nothing to apply, so nothing to ask the node about.
+		if len(diff) == 0 {
+			return pulumi.String("").ToStringOutput(), nil
+		}
+
+		if !args[2].(bool) {
+			a.ctx.Log.Warn(fmt.Sprintf("talos-cluster: configuration of %s drifted from the applied one and will not be applied, "+
+				"since the rendered configuration is not changed: %s", m.MachineID, strings.Join(diff, ", ")), nil)
+
+			return pulumi.String("").ToStringOutput(), nil
+		}
+
+		t := a.cli(m)
+
+		out, err := t.RunGetCommand(a.ctx, &talosctl.Args{
+			TalosConfig: a.basicClient().TalosConfig(),
+			AdditionalFiles: []talosctl.ExtraFile{
+				{Name: machineConfigName, Content: pulumi.String(args[1].(string))},
+			},
+			Dir:         a.runDir(stageName, m.MachineID),
+			CommandArgs: pulumi.Sprintf("apply-config --dry-run -f %s %s", machineConfigName, modeArgs),
+		}, deps)
+		if err != nil {
+			return pulumi.StringOutput{}, err
+		}
+
+		return out.ApplyT(func(out string) string {
+			redacted := RedactConfigDiff(out)
+			a.ctx.Log.Info(fmt.Sprintf("talos-cluster: planned configuration changes for %s:\n%s", m.MachineID, redacted), nil)
+
+			return redacted
+		}).(pulumi.StringOutput), nil
+	}).(pulumi.StringOutput)
+
+	// The redacted diff still shows the layout of the configuration, keep it out of plain text outputs.
+	a.plannedDiffs[m.MachineID] = pulumi.ToSecret(planned).(pulumi.StringOutput)
+
+	return nil
+}
+
+// applyReruns reports whether a trigger-gated command runs in this preview.
+// Only a changed trigger replaces the command and the ID of a replaced or created command is unknown during preview.
+func applyReruns(ctx *pulumi.Context, apply pulumi.Resource) pulumi.BoolOutput {
+	id := apply.(pulumi.CustomResource).ID()
+
+	return pulumi.Bool(true).ToBoolOutput().ApplyTWithContext(ctx.Context(), func(c context.Context, _ bool) (bool, error) {
+		res, err := internals.UnsafeAwaitOutput(c, id)
+		if err != nil {
+			return false, err
+		}
+
+		return !res.Known, nil
+	}).(pulumi.BoolOutput)
+}
@@ -0,0 +1,118 @@
+package applier
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+func TestRedactConfigDiff(t *testing.T) {
+	diff := `Dry run summary:
+Applied configuration without a reboot (dry run)
+
+Config diff:
+
+--- a
++++ b
+@@ -1,9 +1,9 @@
+ machine:
+-    token: abcdef.0123456789abcdef
++    token: fedcba.fedcba9876543210
+     ca:
+-        crt: LS0tLS1CRUdJTi==
++        crt: LS0tLS1CRUdJTj==
+         key: LS0tLS1CRUdJTk==
+     nodeLabels:
+-        role: app
++        role: db
+ cluster:
++    secretboxEncryptionSecret: c2VjcmV0
+`
+
+	redacted := RedactConfigDiff(diff)
+
+	require.NotContains(t, redacted, "abcdef.0123456789abcdef")
+	require.NotContains(t, redacted, "LS0t")
+	require.NotContains(t, redacted, "c2VjcmV0")
+	require.Contains(t, redacted, "-    token: <redacted>")
+	require.Contains(t, redacted, "+        crt: <redacted>")
+	require.Contains(t, redacted, "         key: <redacted>")
+	require.Contains(t, redacted, "+    secretboxEncryptionSecret: <redacted>")
+	require.Contains(t, redacted, "+        role: db")
+	require.Contains(t, redacted, "Applied configuration without a reboot (dry run)")
+}
+
+func TestRedactConfigDiff_MultiLineValues(t *testing.T) {
+	diff := `--- a
++++ b
+@@ -1,14 +1,16 @@
+ machine:
+-    token: abcdef.
+-        0123456789abcdef
++    token: fedcba.fedcba9876543210
+     ca:
+-        crt: |-
+-            LS0tLS1CRUdJTi==
+-            QkVHSU4gQ0VSVElG==
++        crt: |
++            LS0tLS1CRUdJTj==
+         key: >-
+             LS0tLS1CRUdJTk==
++    certSANs:
++        - 10.0.0.2
+ cluster:
+     secretboxEncryptionSecret:
+-    - c2VjcmV0MQ==
++    - c2VjcmV0Mg==
+     apiServer:
+         image: registry.k8s.io/kube-apiserver:v1.33.0
+`
+
+	redacted := RedactConfigDiff(diff)
+
+	for _, secret := range []string{"abcdef", "0123456789abcdef", "LS0t", "QkVHSU4", "c2VjcmV0"} {
+		require.NotContains(t, redacted, secret)
+	}
+
+	require.Contains(t, redacted, "-    token: <redacted>\n-        <redacted>\n")
+	require.Contains(t, redacted, "-        crt: <redacted>\n-            <redacted>\n-            <redacted>\n")
+	require.Contains(t, redacted, "         key: <redacted>\n             <redacted>\n")
+	require.Contains(t, redacted, "     secretboxEncryptionSecret:\n-    <redacted>\n+    <redacted>\n")
+
+	// Values of other fields are kept.
+	require.Contains(t, redacted, "+        - 10.0.0.2")
+	require.Contains(t, redacted, "         image: registry.k8s.io/kube-apiserver:v1.33.0")
+	require.Contains(t, redacted, "--- a\n+++ b\n@@ -1,14 +1,16 @@\n")
+}
+
+func TestApplyReruns_OnlyIfApplyIsReplaced(t *testing.T) {
+	workDirs, err := talosctl.NewWorkDirs(t.TempDir())
+	require.NoError(t, err)
+
+	e := newCommandEngine()
+	reruns := func(preview bool, configuration string) bool {
+		e.preview = preview
+
+		return e.run(t, func(ctx *pulumi.Context) (pulumi.StringOutput, error) {
+			m := &types.MachineInfo{MachineID: "cp-1", NodeIP: "10.0.0.2", Configuration: configuration}
+
+			apply, err := newTestApplier(ctx, workDirs).apply(m, pulumi.String(configuration).ToStringOutput(), nil)
+			if err != nil {
+				return pulumi.StringOutput{}, err
+			}
+
+			return applyReruns(ctx, apply).ApplyT(strconv.FormatBool).(pulumi.StringOutput), nil
+		}) == "true"
+	}
+
+	require.True(t, reruns(true, "machine:\n  type: controlplane\n"))
+	require.False(t, reruns(false, "machine:\n  type: controlplane\n"))
+	// A drift of the node does not change the rendered configuration, so the apply does not rerun.
+	require.False(t, reruns(true, "machine:\n  type: controlplane\n"))
+	require.True(t, reruns(true, "machine:\n  type: controlplane\n  install:\n    disk: /dev/vda\n"))
+}
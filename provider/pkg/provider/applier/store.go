@@ -0,0 +1,52 @@
+package applier
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi-command/sdk/go/command/local"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+)
+
+// KeepSecret stores content in a local command and returns the stored content.
+// The content is stored on create and on replace, which happens only if triggers are changed.
+// A change of the content alone is an update which keeps the stored content, so the result
+// does not change on every run even if the content is generated again.
+// The content is not ignored: the engine would fill it from the old inputs on replace as well.
+// The command only echoes its stdin, nothing runs against nodes.
+func KeepSecret(ctx *pulumi.Context, name string, content pulumi.StringInput, triggers pulumi.Array, opts ...pulumi.ResourceOption) (pulumi.StringOutput, error) {
+	stored, err := local.NewCommand(ctx, name, &local.CommandArgs{
+		Create:      pulumi.String("cat"),
+		Update:      pulumi.String(talosctl.KeepOutputCommand),
+		Stdin:       pulumi.ToSecret(content).(pulumi.StringOutput),
+		Interpreter: pulumi.ToStringArray([]string{"/bin/bash", "-c"}),
+		Logging:     local.LoggingNone,
+		Triggers:    triggers,
+	}, append(opts,
+		pulumi.AdditionalSecretOutputs([]string{"stdin", "stdout"}),
+	)...)
+	if err != nil {
+		return pulumi.StringOutput{}, err
+	}
+
+	return pulumi.ToSecret(stored.Stdout).(pulumi.StringOutput), nil
+}
+
+// keepSecret stores content with a certificate. Certificates are issued on every run, but the stored one
+// is replaced only if the spec is changed or the certificate reaches the middle of its lifetime.
+// spec is everything the content is generated from, except the time.
+func (a *Applier) keepSecret(name, content string, lifetime time.Duration, spec ...string) (pulumi.StringOutput, error) {
+	return KeepSecret(a.ctx, name, pulumi.String(content), keepSecretTriggers(time.Now(), lifetime, spec), a.parent)
+}
+
+// keepSecretTriggers returns the hash of the spec and the number of the current renewal period,
+// which changes in the middle of the lifetime.
+func keepSecretTriggers(now time.Time, lifetime time.Duration, spec []string) pulumi.Array {
+	sum := sha256.Sum256([]byte(strings.Join(spec, "\x00")))
+	period := now.UnixNano() / max(int64(lifetime/2), 1)
+
+	return pulumi.Array{pulumi.String(hex.EncodeToString(sum[:])), pulumi.Int(int(period))}
+}
@@ -0,0 +1,180 @@
+package applier
+
+import (
+	stdx509 "crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/siderolabs/crypto/x509"
+	clientconfig "github.com/siderolabs/talos/pkg/machinery/client/config"
+	"github.com/siderolabs/talos/pkg/machinery/config/generate/secrets"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+// commandEngine mocks the engine for local commands which echo stdin, keeping the state between runs.
+// A change of triggers replaces the command and runs create, other changes run update.
+// Ignored properties are taken from the old inputs, on replace too, like the engine does.
+type commandEngine struct {
+	mu    sync.Mutex
+	state map[string]resource.PropertyMap
+	types []string
+	// created are names of commands created or replaced in the last run.
+	created []string
+	// preview runs programs as a preview: created and replaced commands get no ID and the state is kept.
+	preview bool
+}
+
+func newCommandEngine() *commandEngine {
+	return &commandEngine{state: make(map[string]resource.PropertyMap)}
+}
+
+func (e *commandEngine) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.types = append(e.types, args.TypeToken)
+
+	if args.TypeToken != "command:local:Command" {
+		return args.Name + "-id", args.Inputs, nil
+	}
+
+	inputs := args.Inputs.Copy()
+	prev, exists := e.state[args.Name]
+
+	if exists {
+		for _, k := range args.RegisterRPC.GetIgnoreChanges() {
+			if v, ok := prev[resource.PropertyKey(k)]; ok {
+				inputs[resource.PropertyKey(k)] = v
+			}
+		}
+	}
+
+	outputs := inputs.Copy()
+
+	switch {
+	case !exists || !prev["triggers"].DeepEquals(inputs["triggers"]):
+		e.created = append(e.created, args.Name)
+		outputs["stdout"] = inputs["stdin"]
+	case inputs["update"].IsString() && inputs["update"].StringValue() == talosctl.KeepOutputCommand:
+		outputs["stdout"] = prev["stdout"]
+	default:
+		outputs["stdout"] = inputs["stdin"]
+	}
+
+	// A preview does not change the state.
+	if e.preview {
+		if slices.Contains(e.created, args.Name) {
+			return "", outputs, nil
+		}
+
+		return args.Name + "-id", outputs, nil
+	}
+
+	e.state[args.Name] = outputs
+
+	return args.Name + "-id", outputs, nil
+}
+
+func (e *commandEngine) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	return args.Args, nil
+}
+
+// run runs a program against the engine and returns the resolved output.
+func (e *commandEngine) run(t *testing.T, program func(ctx *pulumi.Context) (pulumi.StringOutput, error)) string {
+	t.Helper()
+
+	var got string
+
+	e.created = nil
+
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		out, err := program(ctx)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+
+		wg.Add(1)
+		out.ApplyT(func(s string) string {
+			got = s
+			wg.Done()
+
+			return s
+		})
+		wg.Wait()
+
+		return nil
+	}, pulumi.WithMocks("project", "stack", e), func(info *pulumi.RunInfo) { info.DryRun = e.preview })
+	require.NoError(t, err)
+
+	return got
+}
+
+func TestKeepSecret_KeepsContentUntilTriggersChange(t *testing.T) {
+	e := newCommandEngine()
+	keep := func(content, trigger string) string {
+		return e.run(t, func(ctx *pulumi.Context) (pulumi.StringOutput, error) {
+			return KeepSecret(ctx, "secret", pulumi.String(content), pulumi.Array{pulumi.String(trigger)})
+		})
+	}
+
+	require.Equal(t, "first", keep("first", "a"))
+	// The content is generated again, but it is kept.
+	require.Equal(t, "first", keep("second", "a"))
+	// The replacement serves the new content.
+	require.Equal(t, "third", keep("third", "b"))
+}
+
+func TestKeepSecretTriggers(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	spec := []string{"os:reader", "8760h0m0s"}
+
+	same := keepSecretTriggers(now.Add(time.Hour), 48*time.Hour, spec)
+	require.Equal(t, keepSecretTriggers(now, 48*time.Hour, spec), same)
+	require.NotEqual(t, same, keepSecretTriggers(now, 48*time.Hour, []string{"os:operator", "8760h0m0s"}))
+	// The renewal period changes in the middle of the lifetime.
+	require.NotEqual(t, same, keepSecretTriggers(now.Add(25*time.Hour), 48*time.Hour, spec))
+}
+
+func TestIssueTalosconfig_NewRolesAreServed(t *testing.T) {
+	talosCA, err := secrets.NewTalosCA(time.Now())
+	require.NoError(t, err)
+
+	ca := x509.NewCertificateAndKeyFromCertificateAuthority(talosCA)
+
+	e := newCommandEngine()
+	issue := func(roles ...string) []string {
+		raw := e.run(t, func(ctx *pulumi.Context) (pulumi.StringOutput, error) {
+			a := &Applier{ctx: ctx, name: "dev", parent: pulumi.Parent(nil)}
+
+			return a.IssueTalosconfig(&types.TalosconfigSpec{Name: "ci", Roles: roles}, ca, []string{"10.0.0.2"}, nil)
+		})
+
+		cfg, err := clientconfig.FromString(raw)
+		require.NoError(t, err)
+
+		crt, err := base64.StdEncoding.DecodeString(cfg.Contexts["ci"].Crt)
+		require.NoError(t, err)
+
+		block, _ := pem.Decode(crt)
+		require.NotNil(t, block)
+
+		cert, err := stdx509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+
+		return cert.Subject.Organization
+	}
+
+	require.Equal(t, []string{"os:reader"}, issue("os:reader"))
+	require.Equal(t, []string{"os:operator"}, issue("os:operator"))
+}
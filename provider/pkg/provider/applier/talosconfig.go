@@ -0,0 +1,73 @@
+package applier
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/siderolabs/crypto/x509"
+	"github.com/siderolabs/talos/pkg/machinery/config/configloader"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+// DefaultTalosconfigTTL is the lifetime of client certificates of additional talosconfigs.
+const DefaultTalosconfigTTL = 365 * 24 * time.Hour
+
+// TalosCA returns the Talos API CA from a controlplane machine configuration.
+func TalosCA(configuration string) (*x509.PEMEncodedCertificateAndKey, error) {
+	cfg, err := configloader.NewFromBytes([]byte(configuration))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load machine configuration: %w", err)
+	}
+
+	if cfg.Machine() == nil {
+		return nil, fmt.Errorf("machine configuration has no machine section")
+	}
+
+	ca := cfg.Machine().Security().IssuingCA()
+	if ca == nil || len(ca.Crt) == 0 || len(ca.Key) == 0 {
+		return nil, fmt.Errorf("machine configuration has no Talos CA with a key, it must be a controlplane configuration")
+	}
+
+	return ca, nil
+}
+
+// IssueTalosconfig returns a talosconfig with a client certificate signed by ca.
+// Endpoints and nodes default to the given ones if the spec does not have its own.
+//
+// The talosconfig is renewed if the spec is changed or the certificate reaches the middle of its lifetime.
+func (a *Applier) IssueTalosconfig(spec *types.TalosconfigSpec, ca *x509.PEMEncodedCertificateAndKey, endpoints, nodes []string) (pulumi.StringOutput, error) {
+	ttl := DefaultTalosconfigTTL
+
+	if spec.TTL != "" {
+		d, err := time.ParseDuration(spec.TTL)
+		if err != nil {
+			return pulumi.StringOutput{}, fmt.Errorf("talosconfig %s: invalid ttl: %w", spec.Name, err)
+		}
+
+		// The certificate would be expired and the renewal period is a half of the ttl.
+		if d <= 0 {
+			return pulumi.StringOutput{}, fmt.Errorf("talosconfig %s: invalid ttl %q: must be positive", spec.Name, spec.TTL)
+		}
+
+		ttl = d
+	}
+
+	if len(spec.Endpoints) > 0 {
+		endpoints = spec.Endpoints
+	}
+
+	if len(spec.Nodes) > 0 {
+		nodes = spec.Nodes
+	}
+
+	talosconfig, err := talosctl.IssueTalosconfig(spec.Name, endpoints, nodes, spec.Roles, ttl, ca, time.Now())
+	if err != nil {
+		return pulumi.StringOutput{}, err
+	}
+
+	return a.keepSecret(fmt.Sprintf("%s:talosconfig:%s", a.name, spec.Name), talosconfig, ttl,
+		strings.Join(spec.Roles, ","), ttl.String(), strings.Join(endpoints, ","), strings.Join(nodes, ","))
+}
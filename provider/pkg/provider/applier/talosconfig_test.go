@@ -0,0 +1,21 @@
+package applier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+func TestIssueTalosconfig_InvalidTTL(t *testing.T) {
+	a := &Applier{}
+
+	for _, ttl := range []string{"0s", "-1h"} {
+		_, err := a.IssueTalosconfig(&types.TalosconfigSpec{Name: "ci", TTL: ttl}, nil, nil, nil)
+		require.EqualError(t, err, `talosconfig ci: invalid ttl "`+ttl+`": must be positive`)
+	}
+
+	_, err := a.IssueTalosconfig(&types.TalosconfigSpec{Name: "ci", TTL: "1 year"}, nil, nil, nil)
+	require.ErrorContains(t, err, "talosconfig ci: invalid ttl")
+}
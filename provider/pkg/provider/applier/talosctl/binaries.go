@@ -0,0 +1,196 @@
+package talosctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// VersionCheckWarn logs a warning if talosctl does not match the Talos version of a node.
+	VersionCheckWarn = "warn"
+	// VersionCheckFail fails the deployment if talosctl does not match the Talos version of a node.
+	VersionCheckFail = "fail"
+	// VersionCheckSkip disables the version check.
+	VersionCheckSkip = "skip"
+
+	versionTimeout = 10 * time.Second
+)
+
+// VersionChecks lists all supported version check modes.
+var VersionChecks = []string{VersionCheckWarn, VersionCheckFail, VersionCheckSkip}
+
+var versionRe = regexp.MustCompile(`v(\d+)\.(\d+)\.(\d+)[\w.+-]*`)
+
+// Binary is a talosctl executable with its client version.
+type Binary struct {
+	Path    string
+	Version string
+}
+
+// Binaries is a set of talosctl binaries. A binary is picked by the Talos version of a node.
+type Binaries struct {
+	Default  *Binary
+	Versions []*Binary
+	// Skipped lists errors of executables in the directory which could not report their version.
+	Skipped []error
+}
+
+// DiscoverBinaries returns talosctl binaries found by path.
+// An empty path means talosctl from PATH. A file path is used as the only binary.
+// If path is a directory, every executable inside it is a candidate and the newest one is the default.
+// The version of every binary is detected via `talosctl version --client`.
+// Executables of a directory which fail to run are skipped and listed in Skipped.
+func DiscoverBinaries(path string) (*Binaries, error) {
+	if path == "" {
+		path = talosctlBinary
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		b, err := newBinary(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Binaries{Default: b, Versions: []*Binary{b}}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read talosctl directory: %w", err)
+	}
+
+	bins := &Binaries{}
+
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || info.IsDir() || info.Mode()&0o111 == 0 {
+			continue
+		}
+
+		b, err := newBinary(filepath.Join(path, e.Name()))
+		if err != nil {
+			bins.Skipped = append(bins.Skipped, err)
+			continue
+		}
+
+		bins.Versions = append(bins.Versions, b)
+	}
+
+	if len(bins.Versions) == 0 {
+		return nil, errors.Join(append([]error{fmt.Errorf("no talosctl binaries found in %s", path)}, bins.Skipped...)...)
+	}
+
+	slices.SortFunc(bins.Versions, func(a, b *Binary) int {
+		return compareVersions(b.Version, a.Version)
+	})
+
+	bins.Default = bins.Versions[0]
+
+	return bins, nil
+}
+
+func newBinary(path string) (*Binary, error) {
+	version, err := ClientVersion(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Binary{Path: path, Version: version}, nil
+}
+
+// ClientVersion returns the version of talosctl reported by `talosctl version --client`.
+func ClientVersion(path string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), versionTimeout)
+	defer cancel()
+
+	// #nosec G204 — path is set by the provider configuration
+	out, err := exec.CommandContext(ctx, path, "version", "--client").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s version --client: %w: %s", path, err, strings.TrimSpace(string(out)))
+	}
+
+	version := versionRe.FindString(string(out))
+	if version == "" {
+		return "", fmt.Errorf("failed to find version in output of %s version --client: %s", path, strings.TrimSpace(string(out)))
+	}
+
+	return version, nil
+}
+
+// ServerVersion returns the Talos version of a node from the output of `talosctl version`.
+// It is the tag of the `Server:` section. An empty string means the version is not found.
+func ServerVersion(out string) string {
+	_, server, ok := strings.Cut(out, "Server:")
+	if !ok {
+		return ""
+	}
+
+	for _, line := range strings.Split(server, "\n") {
+		if tag, ok := strings.CutPrefix(strings.TrimSpace(line), "Tag:"); ok {
+			return versionRe.FindString(tag)
+		}
+	}
+
+	return ""
+}
+
+// For returns the binary for the Talos version.
+// Binaries match if their major and minor versions are equal.
+// If no binary matches, the default one is returned with false.
+func (b *Binaries) For(version string) (*Binary, bool) {
+	for _, bin := range b.Versions {
+		if sameMinor(bin.Version, version) {
+			return bin, true
+		}
+	}
+
+	return b.Default, false
+}
+
+// VersionFromImage returns the tag of an installer image if it is a Talos version.
+// For example, v1.12.0 for ghcr.io/siderolabs/installer:v1.12.0.
+func VersionFromImage(image string) string {
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return ""
+	}
+
+	return versionRe.FindString(image[i+1:])
+}
+
+func sameMinor(a, b string) bool {
+	ma, mb := versionRe.FindStringSubmatch(a), versionRe.FindStringSubmatch(b)
+	if ma == nil || mb == nil {
+		return false
+	}
+
+	return ma[1] == mb[1] && ma[2] == mb[2]
+}
+
+func compareVersions(a, b string) int {
+	ma, mb := versionRe.FindStringSubmatch(a), versionRe.FindStringSubmatch(b)
+	if ma == nil || mb == nil {
+		return strings.Compare(a, b)
+	}
+
+	for i := 1; i <= 3; i++ {
+		x, _ := strconv.Atoi(ma[i])
+		y, _ := strconv.Atoi(mb[i])
+
+		if x != y {
+			return x - y
+		}
+	}
+
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,74 @@
+package talosctl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func fakeTalosctl(t *testing.T, dir, name, version string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	script := "#!/bin/sh\nprintf 'Client:\\n\\tTag:         " + version + "\\n\\tSHA:         abcdef\\n'\n"
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+
+	return path
+}
+
+func TestDiscoverBinaries_Directory(t *testing.T) {
+	dir := t.TempDir()
+	old := fakeTalosctl(t, dir, "talosctl-1.11", "v1.11.5")
+	latest := fakeTalosctl(t, dir, "talosctl-1.12", "v1.12.1")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("not a binary"), 0o644))
+	// A binary for another platform can not be run.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "talosctl-darwin"), []byte("\xcf\xfa\xed\xfe"), 0o755))
+
+	bins, err := DiscoverBinaries(dir)
+	require.NoError(t, err)
+	require.Equal(t, latest, bins.Default.Path)
+	require.Len(t, bins.Versions, 2)
+	require.Len(t, bins.Skipped, 1)
+	require.ErrorContains(t, bins.Skipped[0], "talosctl-darwin version --client")
+
+	bin, ok := bins.For(VersionFromImage("factory.talos.dev/installer/376567988ad3:v1.11.2"))
+	require.True(t, ok)
+	require.Equal(t, old, bin.Path)
+
+	bin, ok = bins.For(VersionFromImage("ghcr.io/siderolabs/installer:v1.10.0"))
+	require.False(t, ok)
+	require.Equal(t, latest, bin.Path)
+}
+
+func TestDiscoverBinaries_File(t *testing.T) {
+	path := fakeTalosctl(t, t.TempDir(), "talosctl", "v1.12.0-beta.1")
+
+	bins, err := DiscoverBinaries(path)
+	require.NoError(t, err)
+	require.Equal(t, &Binary{Path: path, Version: "v1.12.0-beta.1"}, bins.Default)
+
+	_, err = DiscoverBinaries(filepath.Join(t.TempDir(), "missing"))
+	require.ErrorContains(t, err, "version --client")
+}
+
+func TestWithBinary(t *testing.T) {
+	cli := New().WithBinary("/opt/talos/talosctl-1.12").WithNodeIP("10.0.0.2")
+	require.Equal(t, "/opt/talos/talosctl-1.12", cli.Binary)
+	require.Equal(t, "/opt/talos/talosctl-1.12 --talosconfig talosctl.yaml -n 10.0.0.2 -e 10.0.0.2", cli.BasicCommand)
+}
+
+func TestServerVersion(t *testing.T) {
+	out := `Client:
+	Tag:         v1.12.0
+	SHA:         1234abcd
+Server:
+	NODE:        10.5.0.2
+	Tag:         v1.11.3
+	SHA:         abcd1234
+`
+
+	require.Equal(t, "v1.11.3", ServerVersion(out))
+	require.Equal(t, "", ServerVersion("Client:\n\tTag:         v1.12.0\n"))
+}
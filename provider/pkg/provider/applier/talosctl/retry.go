@@ -0,0 +1,261 @@
+package talosctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"os/exec"
+	"os/signal"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// RetryCommand is the argument which makes the provider binary run a command according to a retry policy
+// instead of serving the provider. See RetryMain.
+const RetryCommand = "talosctl-retry"
+
+// permanentErrors match talosctl errors which never go away by themselves:
+// rejected credentials, TLS failures and invalid requests.
+// They are checked before retryableErrors on the stderr of talosctl.
+var permanentErrors = regexp.MustCompile(strings.Join([]string{
+	`code = (Unauthenticated|PermissionDenied|InvalidArgument|Unimplemented)`,
+	`x509: `,
+	`tls: `,
+	`unknown (flag|command|shorthand flag)`,
+}, "|"))
+
+// retryableErrors match errors of a node which is not reachable yet or is busy.
+var retryableErrors = regexp.MustCompile(strings.Join([]string{
+	`code = (Unavailable|DeadlineExceeded|ResourceExhausted|Aborted)`,
+	`connection refused`,
+	`connection reset`,
+	`no route to host`,
+	`i/o timeout`,
+	`context deadline exceeded`,
+	`transport is closing`,
+	`EOF`,
+}, "|"))
+
+// errorClass is the kind of a talosctl failure found by its stderr.
+type errorClass int
+
+const (
+	errorUnknown errorClass = iota
+	errorPermanent
+	errorRetryable
+)
+
+func classifyError(stderr string) errorClass {
+	switch {
+	case permanentErrors.MatchString(stderr):
+		return errorPermanent
+	case retryableErrors.MatchString(stderr):
+		return errorRetryable
+	default:
+		return errorUnknown
+	}
+}
+
+// RetryPolicy describes how a failed talosctl command is retried.
+// Intervals grow exponentially from InitialInterval up to MaxInterval.
+// Retrying stops when any of MaxAttempts and MaxElapsedTime is reached or the error is permanent.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of executions. 0 means no limit.
+	MaxAttempts     int
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	// MaxElapsedTime limits the total time of all attempts. 0 means no limit.
+	MaxElapsedTime time.Duration
+	// Jitter randomizes every interval between a half and the full value.
+	Jitter bool
+	// RetryUnknown enables retries of errors matching neither permanent nor retryable patterns.
+	RetryUnknown bool
+}
+
+// NoRetry runs a command exactly once.
+func NoRetry() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 1}
+}
+
+// DefaultRetryPolicy is suited for commands which wait for a node to become available, like upgrade.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     10,
+		InitialInterval: 5 * time.Second,
+		MaxInterval:     time.Minute,
+		Multiplier:      2,
+		MaxElapsedTime:  10 * time.Minute,
+		Jitter:          true,
+		RetryUnknown:    true,
+	}
+}
+
+// GetRetryPolicy is suited for read-only commands whose output is used in a program.
+// Only errors known as temporary are retried.
+func GetRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     5,
+		InitialInterval: 2 * time.Second,
+		MaxInterval:     20 * time.Second,
+		Multiplier:      2,
+		MaxElapsedTime:  2 * time.Minute,
+		Jitter:          true,
+	}
+}
+
+// executable is the path of the running binary, which runs retries by RetryMain.
+var executable = sync.OnceValue(func() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return os.Args[0]
+	}
+
+	return exe
+})
+
+// Wrap returns a shell command running cmd according to the policy.
+// Commands are run by local commands of pulumi-command, so the policy is passed to the provider binary
+// which runs cmd with RetryPolicy.Run.
+func (p *RetryPolicy) Wrap(cmd string) string {
+	if p == nil {
+		p = NoRetry()
+	}
+
+	// A policy of plain fields is always encoded.
+	policy, _ := json.Marshal(p)
+
+	return fmt.Sprintf("%s %s %s %s", shellQuote(executable()), RetryCommand, shellQuote(string(policy)), shellQuote(cmd))
+}
+
+// RetryMain runs a shell command according to a policy, args are the policy in JSON and the command.
+// Retries stop on SIGINT and SIGTERM. It returns the exit code of the last attempt.
+func RetryMain(args []string) int {
+	if len(args) != 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s <policy> <command>\n", RetryCommand)
+
+		return 2
+	}
+
+	var p RetryPolicy
+	if err := json.Unmarshal([]byte(args[0]), &p); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: invalid policy: %s\n", RetryCommand, err)
+
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return p.Run(ctx, args[1], os.Stdout, os.Stderr)
+}
+
+// Run runs cmd with bash until it succeeds or the policy stops retrying and returns the exit code of the last attempt.
+// Stderr of every attempt is written to stderr. Stdout is written only on success,
+// so failed attempts do not pollute the output of get commands and do not leak secrets to logs.
+func (p *RetryPolicy) Run(ctx context.Context, cmd string, stdout, stderr io.Writer) int {
+	start := time.Now()
+	initial := max(p.InitialInterval, time.Millisecond)
+	delay := initial
+
+	for n := 1; ; n++ {
+		out, errOut, rc := runAttempt(ctx, cmd)
+
+		_, _ = stderr.Write(errOut)
+
+		if rc == 0 {
+			_, _ = stdout.Write(out)
+
+			return 0
+		}
+
+		switch classifyError(string(errOut)) {
+		case errorPermanent:
+			fmt.Fprintf(stderr, "talosctl: attempt %d failed with a permanent error\n", n)
+
+			return rc
+		case errorUnknown:
+			if !p.RetryUnknown {
+				fmt.Fprintf(stderr, "talosctl: attempt %d failed with a non-retryable error\n", n)
+
+				return rc
+			}
+		case errorRetryable:
+		}
+
+		if p.MaxAttempts > 0 && n >= p.MaxAttempts {
+			if n > 1 {
+				fmt.Fprintf(stderr, "talosctl: giving up after %d attempts\n", n)
+			}
+
+			return rc
+		}
+
+		wait := delay
+		if p.Jitter {
+			wait = delay/2 + rand.N(delay/2+1)
+		}
+
+		if elapsed := time.Since(start); p.MaxElapsedTime > 0 && elapsed+wait >= p.MaxElapsedTime {
+			fmt.Fprintf(stderr, "talosctl: giving up after %d attempts and %s\n", n, elapsed.Round(time.Second))
+
+			return rc
+		}
+
+		fmt.Fprintf(stderr, "talosctl: attempt %d failed with exit code %d, retrying in %s\n", n, rc, wait.Round(time.Millisecond))
+
+		select {
+		case <-ctx.Done():
+			return rc
+		case <-time.After(wait):
+		}
+
+		delay = min(time.Duration(float64(delay)*max(p.Multiplier, 1)), max(p.MaxInterval, initial))
+	}
+}
+
+// runAttempt runs cmd once in its own process group, so talosctl is stopped together with bash on cancellation.
+func runAttempt(ctx context.Context, cmd string) (stdout, stderr []byte, rc int) {
+	var out, errOut bytes.Buffer
+
+	c := exec.CommandContext(ctx, interpreter[0], interpreter[1], cmd)
+	c.Stdout = &out
+	c.Stderr = &errOut
+	c.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	c.Cancel = func() error {
+		return syscall.Kill(-c.Process.Pid, syscall.SIGTERM)
+	}
+
+	err := c.Run()
+
+	var exitErr *exec.ExitError
+
+	switch {
+	case err == nil:
+		return out.Bytes(), errOut.Bytes(), 0
+	case errors.As(err, &exitErr):
+		// Like bash, a command killed by a signal exits with 128 plus the signal number.
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return out.Bytes(), errOut.Bytes(), 128 + int(status.Signal())
+		}
+
+		return out.Bytes(), errOut.Bytes(), exitErr.ExitCode()
+	default:
+		fmt.Fprintf(&errOut, "talosctl: failed to run the command: %s\n", err)
+
+		return nil, errOut.Bytes(), 1
+	}
+}
+
+// shellQuote quotes s as a single word for bash.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
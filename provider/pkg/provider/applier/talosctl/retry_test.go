@@ -0,0 +1,119 @@
+package talosctl
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain lets the test binary run wrapped commands like the provider binary does.
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == RetryCommand {
+		os.Exit(RetryMain(os.Args[2:]))
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestClassifyError(t *testing.T) {
+	require.Equal(t, errorPermanent, classifyError("rpc error: code = PermissionDenied desc = not authorized"))
+	// TLS failures are permanent even if the connection is closed because of them.
+	require.Equal(t, errorPermanent, classifyError("tls: failed to verify certificate\nEOF"))
+	require.Equal(t, errorRetryable, classifyError("rpc error: code = Unavailable desc = connection error: dial tcp 10.0.0.2:50000: connect: connection refused"))
+	require.Equal(t, errorUnknown, classifyError("etcd is not healthy"))
+}
+
+// runWrapped runs cmd wrapped by the policy in a temporary directory and returns stdout and the exit code.
+func runWrapped(t *testing.T, p *RetryPolicy, cmd string) (string, int) {
+	t.Helper()
+
+	c := exec.Command("/bin/bash", "-c", p.Wrap(cmd))
+	c.Dir = t.TempDir()
+
+	out, err := c.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return string(out), exitErr.ExitCode()
+	}
+	require.NoError(t, err)
+
+	return string(out), 0
+}
+
+func TestRetryPolicy_Wrap(t *testing.T) {
+	fast := &RetryPolicy{
+		MaxAttempts:     3,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     5 * time.Millisecond,
+		Multiplier:      2,
+		Jitter:          true,
+	}
+
+	// Succeeds on the third attempt, output of failed attempts is dropped.
+	flaky := `echo attempt >> attempts ; [ $(wc -l < attempts) -ge 3 ] && echo done || { echo partial ; echo 'connection refused' >&2 ; exit 1 ; }`
+	out, code := runWrapped(t, fast, flaky)
+	require.Equal(t, 0, code)
+	require.Equal(t, "done\n", out)
+
+	// Permanent errors are not retried.
+	for _, stderr := range []string{
+		`rpc error: code = Unauthenticated desc = authentication failed`,
+		`tls: failed to verify certificate: x509: certificate signed by unknown authority`,
+	} {
+		out, code = runWrapped(t, fast, `echo attempt >> attempts ; echo '`+stderr+`' >&2 ; wc -l < attempts ; exit 3`)
+		require.Equal(t, 3, code)
+		require.Empty(t, out)
+	}
+
+	// Unknown errors are not retried unless enabled.
+	_, code = runWrapped(t, fast, `echo 'etcd is not healthy' >&2 ; exit 4`)
+	require.Equal(t, 4, code)
+
+	fast.RetryUnknown = true
+	out, code = runWrapped(t, fast, `echo attempt >> attempts ; [ $(wc -l < attempts) -ge 2 ] && cat attempts || exit 4`)
+	require.Equal(t, 0, code)
+	require.Equal(t, 2, strings.Count(out, "attempt"))
+
+	// Stdout of a failed attempt is not printed at all.
+	c := exec.Command("/bin/bash", "-c", NoRetry().Wrap(`echo 'key: very-secret' ; exit 1`))
+	c.Dir = t.TempDir()
+	combined, err := c.CombinedOutput()
+	require.Error(t, err)
+	require.NotContains(t, string(combined), "very-secret")
+
+	// The exit code of the last attempt is returned.
+	_, code = runWrapped(t, NoRetry(), `exit 5`)
+	require.Equal(t, 5, code)
+
+	// Quotes and variables of the command are kept.
+	out, code = runWrapped(t, NoRetry(), `x='it'"'"'s' ; echo "$x"`)
+	require.Equal(t, 0, code)
+	require.Equal(t, "it's\n", out)
+}
+
+func TestRetryPolicy_MaxElapsedTime(t *testing.T) {
+	p := &RetryPolicy{InitialInterval: 20 * time.Millisecond, Multiplier: 2, MaxElapsedTime: 50 * time.Millisecond, RetryUnknown: true}
+
+	var stderr strings.Builder
+
+	start := time.Now()
+	code := p.Run(context.Background(), `exit 6`, io.Discard, &stderr)
+	require.Equal(t, 6, code)
+	require.Less(t, time.Since(start), time.Second)
+	require.Contains(t, stderr.String(), "talosctl: giving up after")
+}
+
+func TestRetryPolicy_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	code := DefaultRetryPolicy().Run(ctx, `sleep 10`, io.Discard, io.Discard)
+	require.Equal(t, 143, code)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,103 @@
+package talosctl
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/siderolabs/crypto/x509"
+	clientconfig "github.com/siderolabs/talos/pkg/machinery/client/config"
+	"github.com/siderolabs/talos/pkg/machinery/config/generate/secrets"
+	"github.com/siderolabs/talos/pkg/machinery/role"
+)
+
+// ClientCredentials holds base64 encoded PEM credentials for the Talos API.
+// This is the same format as the cluster clientConfiguration output.
+type ClientCredentials struct {
+	CACertificate     string `pulumi:"caCertificate"`
+	ClientCertificate string `pulumi:"clientCertificate"`
+	ClientKey         string `pulumi:"clientKey"`
+}
+
+// NewTalosconfig renders a talosconfig with a single context from the client credentials.
+func NewTalosconfig(name string, endpoints, nodes []string, creds *ClientCredentials) (string, error) {
+	cfg := &clientconfig.Config{
+		Context: name,
+		Contexts: map[string]*clientconfig.Context{
+			name: {
+				Endpoints: endpoints,
+				Nodes:     nodes,
+				CA:        creds.CACertificate,
+				Crt:       creds.ClientCertificate,
+				Key:       creds.ClientKey,
+			},
+		},
+	}
+
+	b, err := cfg.Bytes()
+	if err != nil {
+		return "", fmt.Errorf("failed to render talosconfig: %w", err)
+	}
+
+	return string(b), nil
+}
+
+// ParseTalosconfig returns the client credentials of the current context of a talosconfig.
+func ParseTalosconfig(raw string) (*ClientCredentials, error) {
+	cfg, err := clientconfig.FromString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse talosconfig: %w", err)
+	}
+
+	c, ok := cfg.Contexts[cfg.Context]
+	if !ok {
+		return nil, fmt.Errorf("talosconfig has no current context %q", cfg.Context)
+	}
+
+	if c.CA == "" || c.Crt == "" || c.Key == "" {
+		return nil, fmt.Errorf("context %s of talosconfig has no CA or client certificate", cfg.Context)
+	}
+
+	return &ClientCredentials{
+		CACertificate:     c.CA,
+		ClientCertificate: c.Crt,
+		ClientKey:         c.Key,
+	}, nil
+}
+
+// PrepareDir creates a private directory with talosctl.yaml inside.
+// It is used for talosctl executions made directly by the provider process.
+func PrepareDir(dir, talosconfig string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create talosctl directory: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ConfigName), []byte(talosconfig), 0o600); err != nil {
+		return fmt.Errorf("failed to write talosconfig: %w", err)
+	}
+
+	return nil
+}
+
+// IssueTalosconfig signs a new client certificate with the Talos CA and renders a talosconfig with it.
+// Roles are Talos API roles, e.g. os:reader.
+func IssueTalosconfig(name string, endpoints, nodes, roles []string, ttl time.Duration, ca *x509.PEMEncodedCertificateAndKey, now time.Time) (string, error) {
+	set, unknown := role.Parse(roles)
+	if len(unknown) > 0 {
+		return "", fmt.Errorf("talosconfig %s: unknown roles %s", name, strings.Join(unknown, ", "))
+	}
+
+	cert, err := secrets.NewAdminCertificateAndKey(now, ca, set, ttl)
+	if err != nil {
+		return "", fmt.Errorf("talosconfig %s: failed to issue client certificate: %w", name, err)
+	}
+
+	return NewTalosconfig(name, endpoints, nodes, &ClientCredentials{
+		CACertificate:     base64.StdEncoding.EncodeToString(ca.Crt),
+		ClientCertificate: base64.StdEncoding.EncodeToString(cert.Crt),
+		ClientKey:         base64.StdEncoding.EncodeToString(cert.Key),
+	})
+}
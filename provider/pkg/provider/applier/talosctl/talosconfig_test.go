@@ -0,0 +1,73 @@
+package talosctl
+
+import (
+	stdx509 "crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/siderolabs/crypto/x509"
+	clientconfig "github.com/siderolabs/talos/pkg/machinery/client/config"
+	"github.com/siderolabs/talos/pkg/machinery/config/generate/secrets"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueTalosconfig_Roles(t *testing.T) {
+	now := time.Now()
+
+	talosCA, err := secrets.NewTalosCA(now)
+	require.NoError(t, err)
+
+	ca := x509.NewCertificateAndKeyFromCertificateAuthority(talosCA)
+
+	raw, err := IssueTalosconfig("oncall", []string{"10.0.0.2"}, []string{"10.0.0.3"}, []string{"os:reader"}, 24*time.Hour, ca, now)
+	require.NoError(t, err)
+
+	cfg, err := clientconfig.FromString(raw)
+	require.NoError(t, err)
+	require.Equal(t, "oncall", cfg.Context)
+
+	ctx := cfg.Contexts["oncall"]
+	require.Equal(t, []string{"10.0.0.2"}, ctx.Endpoints)
+	require.Equal(t, []string{"10.0.0.3"}, ctx.Nodes)
+
+	crt, err := base64.StdEncoding.DecodeString(ctx.Crt)
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(crt)
+	require.NotNil(t, block)
+
+	cert, err := stdx509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	require.Equal(t, []string{"os:reader"}, cert.Subject.Organization)
+	require.WithinDuration(t, now.Add(24*time.Hour), cert.NotAfter, time.Minute)
+
+	pool := stdx509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(ca.Crt))
+
+	_, err = cert.Verify(stdx509.VerifyOptions{Roots: pool, KeyUsages: []stdx509.ExtKeyUsage{stdx509.ExtKeyUsageClientAuth}})
+	require.NoError(t, err)
+}
+
+func TestIssueTalosconfig_UnknownRole(t *testing.T) {
+	talosCA, err := secrets.NewTalosCA(time.Now())
+	require.NoError(t, err)
+
+	_, err = IssueTalosconfig("ci", nil, nil, []string{"os:superuser"}, time.Hour, x509.NewCertificateAndKeyFromCertificateAuthority(talosCA), time.Now())
+	require.ErrorContains(t, err, "unknown roles os:superuser")
+}
+
+func TestParseTalosconfig_CurrentContext(t *testing.T) {
+	creds := &ClientCredentials{CACertificate: "Y2E=", ClientCertificate: "Y3J0", ClientKey: "a2V5"}
+
+	raw, err := NewTalosconfig("prod", []string{"10.0.0.2"}, nil, creds)
+	require.NoError(t, err)
+
+	parsed, err := ParseTalosconfig(raw)
+	require.NoError(t, err)
+	require.Equal(t, creds, parsed)
+
+	_, err = ParseTalosconfig("context: prod\ncontexts:\n  prod:\n    endpoints: [10.0.0.2]\n")
+	require.EqualError(t, err, "context prod of talosconfig has no CA or client certificate")
+}
@@ -11,10 +11,16 @@ import (
 )
 
 const (
-	talosctlBinary     = "talosctl"
-	talosctlConfigName = "talosctl.yaml"
+	talosctlBinary = "talosctl"
+	// ConfigName is the name of the talosconfig file inside a talosctl work dir.
+	ConfigName = "talosctl.yaml"
+	// failureHookName is the name of the OnFailure script inside a talosctl work dir.
+	failureHookName = "on-failure.sh"
 )
 
+// KeepOutputCommand is the update command of resource commands. It only keeps the output of the previous run.
+const KeepOutputCommand = `printf %s "${PULUMI_COMMAND_STDOUT:-}"`
+
 var interpreter = []string{
 	"/bin/bash",
 	"-c",
@@ -28,14 +34,18 @@ type Talosctl struct {
 
 // Args groups arguments used to execute a talosctl command.
 type Args struct {
-	TalosConfig     pulumi.StringInput
-	PrepareDeps     []pulumi.Resource
-	Dir             string
-	CommandArgs     pulumi.StringInput
-	RetryCount      int
+	TalosConfig pulumi.StringInput
+	PrepareDeps []pulumi.Resource
+	Dir         string
+	CommandArgs pulumi.StringInput
+	// Retry is the retry policy of the command. The command runs once if it is nil.
+	Retry           *RetryPolicy
 	Environment     pulumi.StringMap
 	Triggers        pulumi.Array
 	AdditionalFiles []ExtraFile
+	// OnFailure is a shell script which runs in Dir if the command fails. The exit code of the command is kept.
+	// It is written to Dir like AdditionalFiles, so the command does not change with it.
+	OnFailure string
 }
 
 // ExtraFile describes an additional file to place alongside talosctl.yaml.
@@ -48,10 +58,22 @@ type ExtraFile struct {
 func New() *Talosctl {
 	return &Talosctl{
 		Binary:       talosctlBinary,
-		BasicCommand: fmt.Sprintf("%s --talosconfig %s", talosctlBinary, talosctlConfigName),
+		BasicCommand: fmt.Sprintf("%s --talosconfig %s", talosctlBinary, ConfigName),
 	}
 }
 
+// WithBinary sets the path of the talosctl binary.
+func (t *Talosctl) WithBinary(path string) *Talosctl {
+	if path == "" {
+		return t
+	}
+
+	t.BasicCommand = path + strings.TrimPrefix(t.BasicCommand, t.Binary)
+	t.Binary = path
+
+	return t
+}
+
 // WithNodeIP adds `-n` and `-e` flags for the provided node IP address.
 func (t *Talosctl) WithNodeIP(ip string) *Talosctl {
 	t.BasicCommand = fmt.Sprintf("%s -n %s -e %s", t.BasicCommand, ip, ip)
@@ -60,6 +82,9 @@ func (t *Talosctl) WithNodeIP(ip string) *Talosctl {
 }
 
 // RunCommand executes a talosctl command as a Pulumi resource.
+// The command runs on create and on replace, so only Triggers rerun it. An update, e.g. after a change of the retry policy,
+// the work dir or the environment by a provider upgrade, keeps the previous result and does not contact the node.
+// Real changes of the command must be reflected in Triggers.
 func (t *Talosctl) RunCommand(
 	ctx *pulumi.Context,
 	name string,
@@ -73,10 +98,11 @@ func (t *Talosctl) RunCommand(
 
 	main, err := local.NewCommand(ctx, name, &local.CommandArgs{
 		Create: createGated.ApplyT(func(args string) string {
-			return withBashRetry(
-				fmt.Sprintf("%s %s", t.BasicCommand, args),
-				fmt.Sprint(a.RetryCount+1),
-			)
+			return withCleanup(a.Dir, withFailureHook(a.Retry.Wrap(fmt.Sprintf("%s %s", t.BasicCommand, args))))
+		}).(pulumi.StringOutput),
+		// The update waits for the prepare step like the create, since it runs in the same dir.
+		Update: createGated.ApplyT(func(string) string {
+			return KeepOutputCommand
 		}).(pulumi.StringOutput),
 		Dir:         pulumi.String(a.Dir),
 		Interpreter: pulumi.ToStringArray(interpreter),
@@ -88,6 +114,7 @@ func (t *Talosctl) RunCommand(
 	}
 
 	// Hidden cleanup after the resource completes.
+	// It is needed if the command is not changed and does not run, since the prepare step runs every time.
 	_ = local.RunOutput(ctx, local.RunOutputArgs{
 		Command:     pulumi.Sprintf(`rm -rf %q`, a.Dir),
 		Interpreter: pulumi.ToStringArray(interpreter),
@@ -96,6 +123,13 @@ func (t *Talosctl) RunCommand(
 	return main, nil
 }
 
+// OneShot is the resource option of commands which run once and have no triggers.
+// The command, its dir and the update are kept as they were created, so the resource is never updated.
+// The dir is ignored as well: an update would run in the previous dir which does not exist anymore.
+func OneShot() pulumi.ResourceOption {
+	return pulumi.IgnoreChanges([]string{"create", "dir", "update"})
+}
+
 // RunGetCommand executes a talosctl command and returns its standard output.
 func (t *Talosctl) RunGetCommand(
 	ctx *pulumi.Context,
@@ -110,12 +144,7 @@ func (t *Talosctl) RunGetCommand(
 
 	// Compose main + inline cleanup (no resource to depend on)
 	cmd := createGated.ApplyT(func(args string) string {
-		main := withBashRetry(
-			fmt.Sprintf("%s %s", t.BasicCommand, args),
-			fmt.Sprint(a.RetryCount+1),
-		)
-		cleanup := fmt.Sprintf("rm -rf %s", a.Dir)
-		return main + " && " + cleanup
+		return withCleanup(a.Dir, a.Retry.Wrap(fmt.Sprintf("%s %s", t.BasicCommand, args)))
 	}).(pulumi.StringOutput)
 
 	out := local.RunOutput(ctx, local.RunOutputArgs{
@@ -160,36 +189,27 @@ func (t *Talosctl) prepareAndGate(ctx *pulumi.Context, args *Args) (createGated
 
 // prepareAll builds one shell that writes talosctl.yaml and any AdditionalFiles.
 // It uses `local.RunOutput` as the prepare step, returning a BoolOutput.
+// The content of the files is delivered via stdin, one base64 line per file, and never becomes a part of the command.
 func (t *Talosctl) prepareAll(ctx *pulumi.Context, args *Args) pulumi.BoolOutput {
 	// Gather inputs: main config + each extra file content
 	inputs := []any{args.TalosConfig}
+	names := []string{ConfigName}
+
 	for _, f := range args.AdditionalFiles {
 		inputs = append(inputs, f.Content)
+		names = append(names, f.Name)
 	}
 
-	// Build the full shell command as a StringOutput from resolved inputs
-	cmd := pulumi.All(inputs...).ApplyT(func(resolved []any) string {
-		// resolved[0] = main talosctl.yaml content
-		main := resolved[0].(string)
+	if args.OnFailure != "" {
+		inputs = append(inputs, pulumi.String(args.OnFailure))
+		names = append(names, failureHookName)
+	}
 
-		// 1) talosctl.yaml
-		talosConfigPath := filepath.Join(args.Dir, talosctlConfigName)
-		mainB64 := base64.StdEncoding.EncodeToString([]byte(main))
+	stdin := pulumi.All(inputs...).ApplyT(func(resolved []any) string {
 		var b strings.Builder
-		// Ensure TALOS_HOME exists, private perms
-		fmt.Fprintf(&b, `mkdir -p %s && umask 077; `, args.Dir)
-		// Write talosctl.yaml
-		fmt.Fprintf(&b, `printf %%s %q | base64 -d > %s && chmod 600 %s`,
-			mainB64, talosConfigPath, talosConfigPath)
-
-		// 2) Additional files
-		for i, ef := range args.AdditionalFiles {
-			content := resolved[1+i].(string)
-			contentB64 := base64.StdEncoding.EncodeToString([]byte(content))
-
-			finalPath := filepath.Join(args.Dir, ef.Name)
-			fmt.Fprintf(&b, ` && printf %%s %q | base64 -d > %s && chmod 600 %s`,
-				contentB64, finalPath, finalPath)
+		for _, content := range resolved {
+			b.WriteString(base64.StdEncoding.EncodeToString([]byte(content.(string))))
+			b.WriteString("\n")
 		}
 
 		return b.String()
@@ -197,30 +217,47 @@ func (t *Talosctl) prepareAll(ctx *pulumi.Context, args *Args) pulumi.BoolOutput
 
 	// Execute prepare (single invoke). Error propagates via rejected output.
 	run := local.RunOutput(ctx, local.RunOutputArgs{
-		Command:     cmd,
+		Command:     pulumi.String(prepareScript(args.Dir, names)),
+		Stdin:       pulumi.ToSecret(stdin).(pulumi.StringOutput),
 		Environment: args.Environment,
 		Interpreter: pulumi.ToStringArray(interpreter),
 	}, pulumi.DependsOn(args.PrepareDeps))
 
 	// Map stderr to success/failure, preserving errors.
-	return run.Stderr().ApplyT(func(s string) (bool, error) {
+	// The result is secret because of stdin, but a bool does not carry anything sensitive.
+	return pulumi.Unsecret(run.Stderr().ApplyT(func(s string) (bool, error) {
 		if s != "" {
 			return false, fmt.Errorf("prepare error (stderr is not empty): %s", s)
 		}
 		return true, nil
-	}).(pulumi.BoolOutput)
+	})).(pulumi.BoolOutput)
+}
+
+// prepareScript returns a shell script which reads a base64 line from stdin for every file and writes it to dir.
+func prepareScript(dir string, names []string) string {
+	var b strings.Builder
+
+	// Ensure the dir exists, private perms
+	fmt.Fprintf(&b, `umask 077 && mkdir -p %q && chmod 700 %q && { true`, dir, dir)
+
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		fmt.Fprintf(&b, ` && IFS= read -r line && printf %%s "$line" | base64 -d > %q && chmod 600 %q`, path, path)
+	}
+
+	// Do not leave partially written secrets behind.
+	fmt.Fprintf(&b, ` ; } || { rm -rf %q ; echo 'failed to write talosctl files to %s' >&2 ; exit 1 ; }`, dir, dir)
+
+	return b.String()
+}
+
+// withFailureHook runs the OnFailure script of the work dir if cmd fails and exits with the exit code of cmd.
+// The script is optional, so the command is the same whether it is set or not.
+func withFailureHook(cmd string) string {
+	return fmt.Sprintf("%s || { rc=$? ; if [ -f %s ] ; then bash %s ; fi ; exit $rc ; }", cmd, failureHookName, failureHookName)
 }
 
-func withBashRetry(cmd, maxTries string) string {
-	return fmt.Sprintf(strings.Join([]string{
-		"n=0",
-		"until [ $n -ge %[1]s ]",
-		"do %s && break",
-		"sleep 10",
-		"n=$((n+1))",
-		"done",
-		// Exiting with 0 if command succeeded.
-		// Otherwise exit with 10 exit code.
-		"[ $n -ge %[1]s ] && exit 10 || true",
-	}, " ; "), maxTries, cmd)
+// withCleanup removes dir when cmd exits, fails or is interrupted.
+func withCleanup(dir, cmd string) string {
+	return fmt.Sprintf(`trap 'rm -rf %q' EXIT ; trap 'exit 143' INT TERM ; %s`, dir, cmd)
 }
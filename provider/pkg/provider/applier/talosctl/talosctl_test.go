@@ -0,0 +1,81 @@
+package talosctl
+
+import (
+	"encoding/base64"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareScript_ReadsStdin(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "work")
+	secret := "context: test\ncontexts:\n  test:\n    key: very-secret\n"
+
+	script := prepareScript(dir, []string{ConfigName, "machineconfig.yaml"})
+	require.NotContains(t, script, "very-secret")
+
+	cmd := exec.Command("/bin/bash", "-c", script)
+	cmd.Stdin = strings.NewReader(base64.StdEncoding.EncodeToString([]byte(secret)) + "\n" +
+		base64.StdEncoding.EncodeToString([]byte("version: v1alpha1")) + "\n")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	cfg, err := os.ReadFile(filepath.Join(dir, ConfigName))
+	require.NoError(t, err)
+	require.Equal(t, secret, string(cfg))
+
+	info, err := os.Stat(filepath.Join(dir, "machineconfig.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestPrepareScript_RemovesDirOnFailure(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "work")
+
+	cmd := exec.Command("/bin/bash", "-c", prepareScript(dir, []string{ConfigName, "machineconfig.yaml"}))
+	cmd.Stdin = strings.NewReader(base64.StdEncoding.EncodeToString([]byte("only one file")) + "\n")
+
+	require.Error(t, cmd.Run())
+	require.NoDirExists(t, dir)
+}
+
+func TestWithFailureHook_KeepsExitCode(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, failureHookName), []byte("touch hook.ran ; false"), 0o600))
+
+	cmd := exec.Command("/bin/bash", "-c", withFailureHook(NoRetry().Wrap("exit 3")))
+	cmd.Dir = dir
+
+	err := cmd.Run()
+	require.Error(t, err)
+	require.Equal(t, 3, cmd.ProcessState.ExitCode())
+	require.FileExists(t, filepath.Join(dir, "hook.ran"))
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "hook.ran")))
+
+	cmd = exec.Command("/bin/bash", "-c", withFailureHook(NoRetry().Wrap("true")))
+	cmd.Dir = dir
+
+	require.NoError(t, cmd.Run())
+	require.NoFileExists(t, filepath.Join(dir, "hook.ran"))
+
+	// Without the script the exit code is kept as well.
+	cmd = exec.Command("/bin/bash", "-c", withFailureHook(NoRetry().Wrap("exit 4")))
+	cmd.Dir = t.TempDir()
+
+	require.Error(t, cmd.Run())
+	require.Equal(t, 4, cmd.ProcessState.ExitCode())
+}
+
+func TestKeepOutputCommand(t *testing.T) {
+	cmd := exec.Command("/bin/bash", "-c", KeepOutputCommand)
+	cmd.Env = append(os.Environ(), "PULUMI_COMMAND_STDOUT=Upgraded node 10.0.0.2\nnext line")
+
+	out, err := cmd.Output()
+	require.NoError(t, err)
+	require.Equal(t, "Upgraded node 10.0.0.2\nnext line", string(out))
+}
@@ -0,0 +1,139 @@
+package talosctl
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const maxPathComponentLength = 64
+
+var unsafePathCharsRe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// WorkDirs creates private working directories for talosctl.
+// All directories are placed in `<root>/talos-cluster-<uid>`, which is accessible only by the current user.
+//
+// Directories of resource commands are stable between runs, because the directory is an input of the command.
+// A per-run directory would update every command on every run, so no run would be free of changes.
+// They do not collide all the same: the directory is unique for the stack (see Namespace), the stage and the machine,
+// and Pulumi does not run two operations on a stack at once. They are removed when the command exits.
+// A change of it, e.g. from the directory of a previous provider version, only updates the command without running it.
+// Directories of get commands are unique for every run.
+type WorkDirs struct {
+	base  string
+	runID string
+}
+
+// NewWorkDirs prepares the base directory under root. An empty root means the system temporary directory.
+func NewWorkDirs(root string) (*WorkDirs, error) {
+	if root == "" {
+		root = os.TempDir()
+	}
+
+	base := filepath.Join(root, fmt.Sprintf("talos-cluster-%d", os.Getuid()))
+
+	if err := os.MkdirAll(base, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create work dir %s: %w", base, err)
+	}
+
+	info, err := os.Lstat(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check work dir %s: %w", base, err)
+	}
+
+	// The base can be created in advance by another user of a shared runner.
+	if !info.IsDir() || info.Mode()&os.ModeSymlink != 0 {
+		return nil, fmt.Errorf("work dir %s is not a directory", base)
+	}
+
+	if info.Mode().Perm() != 0o700 {
+		if err := os.Chmod(base, 0o700); err != nil {
+			return nil, fmt.Errorf("work dir %s has insecure permissions %s: %w", base, info.Mode().Perm(), err)
+		}
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate run id: %w", err)
+	}
+
+	return &WorkDirs{
+		base:  base,
+		runID: hex.EncodeToString(id),
+	}, nil
+}
+
+// Dir returns a directory which is the same for the same parts in every run.
+// It is meant for resource commands, see WorkDirs.
+func (w *WorkDirs) Dir(parts ...string) string {
+	return filepath.Join(append([]string{w.base}, sanitizePathComponents(parts)...)...)
+}
+
+// RunDir returns a directory unique for the current run.
+func (w *WorkDirs) RunDir(parts ...string) string {
+	return filepath.Join(append([]string{w.base, "run-" + w.runID}, sanitizePathComponents(parts)...)...)
+}
+
+// DebugDir returns a directory for debug bundles. Unlike working directories, it is kept after the run.
+// An empty root means the debug directory inside the base.
+func (w *WorkDirs) DebugDir(root string, parts ...string) string {
+	if root == "" {
+		root = filepath.Join(w.base, "debug")
+	}
+
+	return filepath.Join(append([]string{root}, sanitizePathComponents(parts)...)...)
+}
+
+// TempDir creates a new directory unique for the current run, e.g. for a single invoke.
+func (w *WorkDirs) TempDir(pattern string) (string, error) {
+	root := filepath.Join(w.base, "run-"+w.runID)
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create work dir %s: %w", root, err)
+	}
+
+	return os.MkdirTemp(root, SanitizePathComponent(pattern))
+}
+
+// Cleanup removes all directories of the current run.
+func (w *WorkDirs) Cleanup() error {
+	return os.RemoveAll(filepath.Join(w.base, "run-"+w.runID))
+}
+
+// Namespace returns a path component unique for a stack, so stacks with the same name in different
+// projects or organizations do not share directories.
+func Namespace(organization, project, stack string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{organization, project, stack}, "/")))
+
+	return fmt.Sprintf("%s-%s", SanitizePathComponent(stack), hex.EncodeToString(sum[:6]))
+}
+
+// SanitizePathComponent makes s safe to use as a single path component.
+// Separators and other unsafe characters are replaced, so the result never escapes its parent directory.
+func SanitizePathComponent(s string) string {
+	safe := strings.Trim(unsafePathCharsRe.ReplaceAllString(s, "_"), ".")
+	if safe == "" {
+		safe = "_"
+	}
+
+	// Different long values must not collide after truncation.
+	if len(safe) > maxPathComponentLength || safe != s {
+		sum := sha256.Sum256([]byte(s))
+		safe = fmt.Sprintf("%s-%s", safe[:min(len(safe), maxPathComponentLength-13)], hex.EncodeToString(sum[:6]))
+	}
+
+	return safe
+}
+
+func sanitizePathComponents(parts []string) []string {
+	res := make([]string, 0, len(parts))
+	for _, p := range parts {
+		res = append(res, SanitizePathComponent(p))
+	}
+
+	return res
+}
@@ -0,0 +1,45 @@
+package talosctl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizePathComponent(t *testing.T) {
+	require.Equal(t, "cli-apply-config-m1", SanitizePathComponent("cli-apply-config-m1"))
+
+	for _, s := range []string{"..", "../etc", "a/b", "dev/stack", strings.Repeat("x", 100)} {
+		got := SanitizePathComponent(s)
+		require.NotContains(t, got, "/", s)
+		require.NotEqual(t, "..", got, s)
+		require.LessOrEqual(t, len(got), maxPathComponentLength, s)
+	}
+
+	require.NotEqual(t, SanitizePathComponent(strings.Repeat("x", 100)+"a"), SanitizePathComponent(strings.Repeat("x", 100)+"b"))
+	require.NotEqual(t, SanitizePathComponent("a/b"), SanitizePathComponent("a_b"))
+}
+
+func TestWorkDirs_Private(t *testing.T) {
+	root := t.TempDir()
+
+	w, err := NewWorkDirs(root)
+	require.NoError(t, err)
+
+	info, err := os.Stat(w.base)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+
+	require.Equal(t, w.Dir("dev", "cluster", "m1"), w.Dir("dev", "cluster", "m1"))
+	require.True(t, strings.HasPrefix(w.Dir("..", "../..", "m1"), w.base+string(filepath.Separator)))
+
+	dir, err := w.TempDir("status-")
+	require.NoError(t, err)
+	require.DirExists(t, dir)
+
+	require.NoError(t, w.Cleanup())
+	require.NoDirExists(t, dir)
+}
@@ -11,6 +11,9 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// machineConfigName is the name of the machine configuration file in a talosctl work dir.
+const machineConfigName = "machineconfig.yaml"
+
 // K8SImages holds Kubernetes component images extracted from a Talos configuration.
 type K8SImages struct {
 	Kubelet           string
@@ -42,70 +45,138 @@ func NewK8SImages(config *v1alpha1.Config) *K8SImages {
 	return images
 }
 
-// apply prepares and returns a Talos CLI command to apply a machine configuration.
+// desiredConfig returns the machine configuration that should be applied to a node.
 // This function merges base machine configuration with user-provided patches and ensures
 // that Kubernetes image versions in the configuration align with the currently running
 // versions to prevent accidental downgrades (Talos does not support downgrades via specifying images in the config).
-func (a *Applier) apply(m *types.MachineInfo, deps []pulumi.Resource) (pulumi.Resource, error) {
-	machineFile := pulumi.All(m.UserConfigPatches, m.NodeIP, m.Configuration).ApplyT(func(args []any) (pulumi.StringOutput, error) {
-		// Extract current images to use instead of any potential downgraded images
-		userPatches := args[0].(string)
-		ip := args[1].(string)
-		machineConfig := args[2].(string)
-
-		t2 := talosctl.New().WithNodeIP(ip)
-		stageName := "cli-get-machine-config"
-
-		current, err := t2.RunGetCommand(a.ctx, &talosctl.Args{
-			TalosConfig: a.basicClient().TalosConfig(),
-			Dir:         generateWorkDirNameForTalosctl(a.name, stageName, m.MachineID),
-			CommandArgs: pulumi.String("get machineconfig v1alpha1 -oyaml"),
-			// No retry. Need to implement another way to retry for get functions.
-			RetryCount: 0,
-		}, deps)
+func (a *Applier) desiredConfig(m *types.MachineInfo, current pulumi.StringOutput) pulumi.StringOutput {
+	// An invalid try timeout is reported by the apply itself.
+	mode, _, _ := a.applyModeFor(m)
+
+	return current.ApplyT(func(spec string) (string, error) {
+		rendered, err := RenderConfig(m.Configuration, m.UserConfigPatches, spec)
 		if err != nil {
-			return pulumi.StringOutput{}, fmt.Errorf("failed to get current machine info: %w", err)
+			return "", err
 		}
 
-		return current.ApplyT(func(output string) (string, error) {
-			var config MachineConfig
-			if err := yaml.Unmarshal([]byte(output), &config); err != nil {
-				return "", fmt.Errorf("error parsing YAML output: %w", err)
-			}
+		// Fail early with a clear message instead of a talosctl failure.
+		if err := ValidateApplyMode(mode, spec, rendered); err != nil {
+			return "", fmt.Errorf("machine %s: %w", m.MachineID, err)
+		}
+
+		return rendered, nil
+	}).(pulumi.StringOutput)
+}
 
-			var spec v1alpha1.Config
-			if err := yaml.Unmarshal([]byte(config.Spec), &spec); err != nil {
-				return "", fmt.Errorf("error parsing YAML spec string: %w", err)
-			}
+// RenderConfig returns the exact configuration which is sent to a node by apply-config.
+// If current is not empty, images of Kubernetes components are taken from it.
+func RenderConfig(configuration, userPatches, current string) (string, error) {
+	merger := MergeYAML(configuration, userPatches)
 
-			oldK8SImages := NewK8SImages(&spec)
+	if current != "" {
+		var config v1alpha1.Config
+		if err := yaml.Unmarshal([]byte(current), &config); err != nil {
+			return "", fmt.Errorf("error parsing YAML spec string: %w", err)
+		}
 
-			// Merge the base machine configuration with user-provided patches.
-			// This combines the configs into a single YAML representation.
-			merged, err := MergeYAML(machineConfig, userPatches).WithGuard(GuardUnmodifyK8sImages(oldK8SImages)).Build()
-			if err != nil {
-				return "", fmt.Errorf("failed merge yaml strings: %w", err)
-			}
+		if err := validateImagesPresent(&config); err != nil {
+			return "", fmt.Errorf("invalid current configuration: %w", err)
+		}
 
-			return merged, nil
-		}).(pulumi.StringOutput), nil
-	}).(pulumi.StringOutput)
+		// Extract current images to use instead of any potential downgraded images
+		merger = merger.WithGuard(GuardUnmodifyK8sImages(NewK8SImages(&config)))
+	}
+
+	// Merge the base machine configuration with user-provided patches.
+	// This combines the configs into a single YAML representation.
+	merged, err := merger.Build()
+	if err != nil {
+		return "", fmt.Errorf("failed merge yaml strings: %w", err)
+	}
+
+	return merged, nil
+}
+
+// validateImagesPresent checks that all sections read by NewK8SImages exist.
+func validateImagesPresent(config *v1alpha1.Config) error {
+	if config.MachineConfig == nil || config.MachineConfig.MachineKubelet == nil {
+		return fmt.Errorf("machine.kubelet section is missing")
+	}
+
+	if config.MachineConfig.MachineType != machine.TypeControlPlane.String() && config.MachineConfig.MachineType != machine.TypeInit.String() {
+		return nil
+	}
+
+	c := config.ClusterConfig
+	if c == nil || c.APIServerConfig == nil || c.ProxyConfig == nil || c.SchedulerConfig == nil || c.ControllerManagerConfig == nil {
+		return fmt.Errorf("cluster section of a controlplane must contain apiServer, controllerManager, proxy and scheduler")
+	}
 
-	stageName := "cli-apply-config"
-	t := talosctl.New().WithNodeIP(m.NodeIP)
-	machineConfigName := "machineconfig.yaml"
+	return nil
+}
+
+// currentConfig fetches the machine configuration running on the node.
+// It returns the spec of the machineconfig resource as is.
+func (a *Applier) currentConfig(m *types.MachineInfo, stageName string, deps []pulumi.Resource) (pulumi.StringOutput, error) {
+	t := a.cli(m)
+
+	current, err := t.RunGetCommand(a.ctx, &talosctl.Args{
+		TalosConfig: a.basicClient().TalosConfig(),
+		Dir:         a.runDir(stageName, m.MachineID),
+		CommandArgs: pulumi.String("get machineconfig v1alpha1 -oyaml"),
+		Retry:       talosctl.GetRetryPolicy(),
+	}, deps)
+	if err != nil {
+		return pulumi.StringOutput{}, fmt.Errorf("failed to get current machine info: %w", err)
+	}
+
+	return current.ApplyT(func(output string) (string, error) {
+		var config MachineConfig
+		if err := yaml.Unmarshal([]byte(output), &config); err != nil {
+			return "", fmt.Errorf("error parsing YAML output: %w", err)
+		}
+
+		return config.Spec, nil
+	}).(pulumi.StringOutput), nil
+}
+
+// apply returns a Talos CLI command to apply a machine configuration.
+// It reruns when the rendered configuration changes, whatever it is changed by: the configuration,
+// user patches or images of Kubernetes components taken from the node.
+func (a *Applier) apply(m *types.MachineInfo, machineFile pulumi.StringOutput, deps []pulumi.Resource) (pulumi.Resource, error) {
+	args, err := a.applyConfigArgs(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.applyConfig(m, "cli-apply-config", machineFile, pulumi.String(args), pulumi.Array{
+		machineFile.ApplyT(ConfigHash).(pulumi.StringOutput),
+		pulumi.String(m.ClusterEnpoint),
+	}, deps)
+}
+
+// applyConfigArgs returns talosctl arguments applying the machine configuration file with the mode of the machine.
+func (a *Applier) applyConfigArgs(m *types.MachineInfo) (string, error) {
+	modeArgs, err := a.applyModeArgsFor(m)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("apply-config -f %s %s", machineConfigName, modeArgs), nil
+}
+
+func (a *Applier) applyConfig(m *types.MachineInfo, stageName string, machineFile pulumi.StringOutput, args pulumi.StringInput, triggers pulumi.Array, deps []pulumi.Resource) (pulumi.Resource, error) {
+	t := a.cli(m)
 
 	apply, err := t.RunCommand(a.ctx, fmt.Sprintf("%s:%s:%s", a.name, stageName, m.MachineID), &talosctl.Args{
 		TalosConfig: a.basicClient().TalosConfig(),
 		AdditionalFiles: []talosctl.ExtraFile{
 			{Name: machineConfigName, Content: machineFile},
 		},
-		CommandArgs: pulumi.Sprintf("apply-config -f %s", machineConfigName),
-		Dir:         generateWorkDirNameForTalosctl(a.name, stageName, m.MachineID),
-		Triggers: pulumi.Array{
-			pulumi.String(m.UserConfigPatches),
-			pulumi.String(m.ClusterEnpoint),
-		},
+		CommandArgs: args,
+		Dir:         a.workDir(stageName, m.MachineID),
+		Triggers:    triggers,
+		OnFailure:   a.debugBundleHook(m, stageName, t.BasicCommand),
 	}, []pulumi.ResourceOption{
 		a.parent,
 		pulumi.Timeouts(&pulumi.CustomTimeouts{Create: "90s", Update: "90s"}),
@@ -0,0 +1,100 @@
+package applier
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumiverse/pulumi-talos/sdk/go/talos/machine"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+func TestRenderConfig_WithoutCurrent(t *testing.T) {
+	configuration := `
+machine:
+  type: worker
+  kubelet:
+    image: ghcr.io/siderolabs/kubelet:v1.33.0
+`
+	patches := `
+machine:
+  network:
+    hostname: worker-1
+`
+
+	result, err := RenderConfig(configuration, patches, "")
+	require.NoError(t, err)
+	require.Contains(t, result, "image: ghcr.io/siderolabs/kubelet:v1.33.0")
+	require.Contains(t, result, "hostname: worker-1")
+}
+
+func TestRenderConfig_KeepsRunningImages(t *testing.T) {
+	configuration := `
+machine:
+  type: worker
+  kubelet:
+    image: ghcr.io/siderolabs/kubelet:v1.33.0
+`
+	current := `
+machine:
+  type: worker
+  kubelet:
+    image: ghcr.io/siderolabs/kubelet:v1.34.1
+`
+
+	result, err := RenderConfig(configuration, "", current)
+	require.NoError(t, err)
+	require.Contains(t, result, "image: ghcr.io/siderolabs/kubelet:v1.34.1")
+	require.NotContains(t, result, "v1.33.0")
+}
+
+func TestRenderConfig_IncompleteCurrent_ShouldError(t *testing.T) {
+	_, err := RenderConfig(`machine: {}`, "", `machine:
+  type: controlplane
+  kubelet: {}
+`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cluster section")
+}
+
+// newTestApplier returns an Applier registering commands without a real cluster.
+func newTestApplier(ctx *pulumi.Context, workDirs *talosctl.WorkDirs) *Applier {
+	return &Applier{
+		ctx:       ctx,
+		name:      "dev",
+		parent:    pulumi.Parent(nil),
+		workDirs:  workDirs,
+		applyMode: ApplyModeAuto,
+		clientConfiguration: &machine.ClientConfigurationArgs{
+			CaCertificate:     pulumi.String("ca"),
+			ClientCertificate: pulumi.String("crt"),
+			ClientKey:         pulumi.String("key"),
+		},
+	}
+}
+
+func TestApply_RerunsOnRenderedConfigChange(t *testing.T) {
+	workDirs, err := talosctl.NewWorkDirs(t.TempDir())
+	require.NoError(t, err)
+
+	e := newCommandEngine()
+	apply := func(configuration string) bool {
+		e.run(t, func(ctx *pulumi.Context) (pulumi.StringOutput, error) {
+			m := &types.MachineInfo{MachineID: "cp-1", NodeIP: "10.0.0.2", Configuration: configuration}
+
+			_, err := newTestApplier(ctx, workDirs).apply(m, pulumi.String(configuration).ToStringOutput(), nil)
+
+			return pulumi.String("").ToStringOutput(), err
+		})
+
+		return slices.Contains(e.created, "dev:cli-apply-config:cp-1")
+	}
+
+	require.True(t, apply("machine:\n  type: controlplane\n"))
+	require.False(t, apply("machine:\n  type: controlplane\n"))
+	// Only the raw configuration is changed, there are no user patches.
+	require.True(t, apply("machine:\n  type: controlplane\n  install:\n    disk: /dev/vda\n"))
+}
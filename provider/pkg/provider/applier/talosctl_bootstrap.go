@@ -0,0 +1,124 @@
+package applier
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	tmachine "github.com/siderolabs/talos/pkg/machinery/config/machine"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+const (
+	// Types of pulumiverse resources which were used for the initial apply and bootstrap.
+	// Resources are registered with aliases to them in the aliases migration mode.
+	LegacyInitialApplyType = "talos:machine/configurationApply:ConfigurationApply"
+	LegacyBootstrapType    = "talos:machine/bootstrap:Bootstrap"
+
+	initialApplyStage = OperationInitialApply
+	bootstrapStage    = "bootstrap"
+
+	bootstrapOutputFile = "bootstrap.out"
+)
+
+// initApply applies the configuration to a node in maintenance mode and waits for the reboot.
+func (a *Applier) initApply(m *types.MachineInfo, role tmachine.Type, deps []pulumi.Resource) (pulumi.Resource, error) {
+	t := a.cli(m)
+
+	args := talosctlInitialApplyArgs(t.BasicCommand, m.NodeIP, machineConfigName)
+	if a.migrationMode == MigrationModeImport {
+		args = talosctlImportedArgs(initialApplyStage, m.NodeIP)
+	}
+
+	gated, err := a.gateMigration(m, role, pulumi.String(args).ToStringOutput(), deps)
+	if err != nil {
+		return nil, err
+	}
+
+	apply, err := t.RunCommand(a.ctx, fmt.Sprintf("%s:%s:%s", a.name, initialApplyStage, m.MachineID), &talosctl.Args{
+		TalosConfig: a.basicClient().TalosConfig(),
+		PrepareDeps: deps,
+		// The configuration is delivered as a file, so changes of it do not rerun the command.
+		// There will be an additional apply via cli.
+		// Generated configuration has a contract with immutable talos version and sometimes new options can be skipped.
+		AdditionalFiles: []talosctl.ExtraFile{
+			{Name: machineConfigName, Content: pulumi.String(m.Configuration)},
+		},
+		Dir:         a.workDir(initialApplyStage, m.MachineID),
+		CommandArgs: gated,
+		Retry:       talosctl.DefaultRetryPolicy(),
+	}, append([]pulumi.ResourceOption{
+		a.parent,
+		pulumi.Timeouts(&pulumi.CustomTimeouts{Create: "10m", Update: "10m"}),
+		pulumi.DependsOn(deps),
+		// The initial apply is a one-shot step, a change of the migration mode must not rerun it.
+		talosctl.OneShot(),
+	}, a.legacyAliases(LegacyInitialApplyType)...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The imported initial apply did not run.
+	if a.migrationMode != MigrationModeImport {
+		a.trackStage(m, stageInitialApplyID, apply)
+	}
+
+	deps = append(deps, apply)
+
+	return a.reboot(m, deps)
+}
+
+// bootstrap bootstraps etcd on the init node.
+func (a *Applier) bootstrap(m *types.MachineInfo, deps []pulumi.Resource) (pulumi.Resource, error) {
+	args := talosctlBootstrapArgs(m.NodeIP)
+	if a.migrationMode == MigrationModeImport {
+		args = talosctlImportedArgs(bootstrapStage, m.NodeIP)
+	}
+
+	gated, err := a.gateMigration(m, tmachine.TypeInit, pulumi.String(args).ToStringOutput(), deps)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.cli(m).RunCommand(a.ctx, fmt.Sprintf("%s:%s:%s", a.name, bootstrapStage, m.MachineID), &talosctl.Args{
+		TalosConfig: a.basicClient().TalosConfig(),
+		PrepareDeps: deps,
+		Dir:         a.workDir(bootstrapStage, m.MachineID),
+		CommandArgs: gated,
+		Retry:       talosctl.DefaultRetryPolicy(),
+	}, append([]pulumi.ResourceOption{
+		a.parent,
+		pulumi.Timeouts(&pulumi.CustomTimeouts{Create: "10m", Update: "10m"}),
+		pulumi.DependsOn(deps),
+		talosctl.OneShot(),
+	}, a.legacyAliases(LegacyBootstrapType)...)...)
+}
+
+// talosctlInitialApplyArgs applies the configuration via the maintenance API.
+// A node which already accepts authenticated requests is configured and is skipped,
+// so the command is safe to rerun.
+// Staged mode is not supported in maintenance and no-reboot can lead to failures.
+func talosctlInitialApplyArgs(talos, node, file string) string {
+	return strings.Join([]string{
+		"version > /dev/null 2>&1 && { echo 'node " + node + " is already configured, skipping the initial apply' ; exit 0 ; }",
+		fmt.Sprintf("%s apply-config --insecure -f %s --mode reboot", talos, file),
+	}, " ; ")
+}
+
+// talosctlImportedArgs only records that the stage was done before the migration.
+// The client version is printed to keep the command a talosctl one, it does not contact the node.
+func talosctlImportedArgs(stage, node string) string {
+	return fmt.Sprintf("version --client > /dev/null && echo 'node %s: %s is imported from the previous state'", node, stage)
+}
+
+// talosctlBootstrapArgs bootstraps etcd.
+// An already bootstrapped etcd is not an error, so the command is safe to rerun.
+func talosctlBootstrapArgs(node string) string {
+	return strings.Join([]string{
+		fmt.Sprintf("bootstrap > %s 2>&1 && exit 0", bootstrapOutputFile),
+		fmt.Sprintf("grep -q 'AlreadyExists' %s && { echo 'etcd is already bootstrapped on node %s' ; exit 0 ; }", bootstrapOutputFile, node),
+		fmt.Sprintf("cat %s >&2", bootstrapOutputFile),
+		"exit 1",
+	}, " ; ")
+}
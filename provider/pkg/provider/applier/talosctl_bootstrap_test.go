@@ -0,0 +1,70 @@
+package applier
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTalosctl writes a talosctl stub which records calls and fails the given subcommands.
+func fakeTalosctl(t *testing.T, script string) (bin, calls string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	bin = filepath.Join(dir, "talosctl")
+	calls = filepath.Join(dir, "calls")
+
+	require.NoError(t, os.WriteFile(bin, []byte("#!/bin/bash\necho \"$@\" >> "+calls+"\n"+script), 0o700))
+
+	return bin, calls
+}
+
+func runInDir(t *testing.T, cmd string) (string, error) {
+	t.Helper()
+
+	c := exec.Command("/bin/bash", "-c", cmd)
+	c.Dir = t.TempDir()
+	out, err := c.CombinedOutput()
+
+	return string(out), err
+}
+
+func TestTalosctlInitialApplyArgs(t *testing.T) {
+	// The node is in maintenance: authenticated requests fail.
+	bin, calls := fakeTalosctl(t, `[ "$1" = version ] && exit 1 ; exit 0`)
+
+	_, err := runInDir(t, bin+" "+talosctlInitialApplyArgs(bin, "10.0.0.2", "machineconfig.yaml"))
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(calls)
+	require.NoError(t, err)
+	require.Equal(t, "version\napply-config --insecure -f machineconfig.yaml --mode reboot\n", string(got))
+
+	// The node is configured.
+	bin, calls = fakeTalosctl(t, `exit 0`)
+
+	out, err := runInDir(t, bin+" "+talosctlInitialApplyArgs(bin, "10.0.0.2", "machineconfig.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, out, "already configured")
+
+	got, err = os.ReadFile(calls)
+	require.NoError(t, err)
+	require.Equal(t, "version\n", string(got))
+}
+
+func TestTalosctlBootstrapArgs(t *testing.T) {
+	bin, _ := fakeTalosctl(t, `echo 'rpc error: code = AlreadyExists desc = etcd data directory is not empty' >&2 ; exit 1`)
+
+	out, err := runInDir(t, bin+" "+talosctlBootstrapArgs("10.0.0.2"))
+	require.NoError(t, err)
+	require.Contains(t, out, "already bootstrapped")
+
+	bin, _ = fakeTalosctl(t, `echo 'rpc error: code = Unavailable desc = connection refused' >&2 ; exit 1`)
+
+	out, err = runInDir(t, bin+" "+talosctlBootstrapArgs("10.0.0.2"))
+	require.Error(t, err)
+	require.Contains(t, out, "connection refused")
+}
@@ -2,6 +2,7 @@ package applier
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
@@ -10,15 +11,19 @@ import (
 
 func (a *Applier) upgradeK8S(m *types.MachineInfo, deps []pulumi.Resource) (pulumi.Resource, error) {
 	stageName := "cli-upgrade-k8s"
-	home := generateWorkDirNameForTalosctl(a.name, stageName, m.MachineID)
-	t := talosctl.New().WithNodeIP(m.NodeIP)
+	home := a.workDir(stageName, m.MachineID)
+	t := a.cli(m)
 
 	return t.RunCommand(a.ctx, fmt.Sprintf("%s:%s:%s", a.name, stageName, m.MachineID), &talosctl.Args{
 		TalosConfig: a.basicClient().TalosConfig(),
 		PrepareDeps: deps,
 		Dir:         home,
 		CommandArgs: pulumi.Sprintf("upgrade-k8s --with-docs=false --with-examples=false --to %s", m.KubernetesVersion),
-		RetryCount:  1,
+		Retry: &talosctl.RetryPolicy{
+			MaxAttempts:     2,
+			InitialInterval: 10 * time.Second,
+			RetryUnknown:    true,
+		},
 		Triggers: pulumi.Array{
 			pulumi.String(m.KubernetesVersion),
 		},
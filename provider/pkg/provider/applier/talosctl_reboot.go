@@ -3,39 +3,86 @@ package applier
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
 	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
 )
 
+const (
+	DefaultRebootTimeout = 10 * time.Minute
+
+	// Phases of a reboot reported when a node does not come back in time.
+	RebootPhaseShutdown = "shutdown"
+	RebootPhaseBoot     = "boot"
+	RebootPhaseStartup  = "startup"
+)
+
+// rebootPollInterval is how often a rebooting node is checked. It is whole seconds.
+var rebootPollInterval = 5 * time.Second
+
+// rebootExpectedStages are machine stages of a node which is ready for the next step after a reboot.
+var rebootExpectedStages = []string{"running", "maintenance"}
+
 func (a *Applier) reboot(m *types.MachineInfo, deps []pulumi.Resource) (pulumi.Resource, error) {
 	stageName := "cli-reboot"
-	home := generateWorkDirNameForTalosctl(a.name, stageName, m.MachineID)
-	t := talosctl.New().WithNodeIP(m.NodeIP)
+	home := a.workDir(stageName, m.MachineID)
+	t := a.cli(m)
+	timeout := (a.rebootTimeout + 2*time.Minute).String()
+
+	args := talosctlRebootAndWaitArgs(t.BasicCommand, m.NodeIP, a.rebootTimeout)
+	if a.migrationMode == MigrationModeImport {
+		args = talosctlImportedArgs(stageName, m.NodeIP)
+	}
 
 	return t.RunCommand(a.ctx, fmt.Sprintf("%s:%s:%s", a.name, stageName, m.MachineID), &talosctl.Args{
 		TalosConfig: a.basicClient().TalosConfig(),
 		PrepareDeps: deps,
 		Dir:         home,
-		CommandArgs: pulumi.String(talosctlFastRebootArgs()),
-		// Do not retry since we do not wait for success.
-		RetryCount: 0,
+		CommandArgs: pulumi.String(args),
+		// Do not retry since the command waits by itself.
+		Retry: talosctl.NoRetry(),
 	}, []pulumi.ResourceOption{
 		a.parent,
-		pulumi.Timeouts(&pulumi.CustomTimeouts{Create: "5m", Update: "5m"}),
+		pulumi.Timeouts(&pulumi.CustomTimeouts{Create: timeout, Update: timeout}),
 		pulumi.DependsOn(deps),
+		// The reboot follows the initial apply only, changes of the timeout, the migration mode or the work dir must not reboot the node again.
+		talosctl.OneShot(),
 	}...,
 	)
 }
 
-func talosctlFastRebootArgs() string {
-	// Do not wait for succesfull reboot.
+// talosctlRebootAndWaitArgs reboots the node and polls apid until the node is back.
+// The node is back when it reports a new boot ID and one of rebootExpectedStages.
+// A node in maintenance does not serve authenticated requests, so it is checked via an insecure connection.
+// If the timeout is exceeded, the command fails with the phase the node got stuck in:
+//   - shutdown: the node still runs the previous boot.
+//   - boot: apid of the node is not reachable.
+//   - startup: the node is up, but has not reached an expected stage.
+func talosctlRebootAndWaitArgs(talos, node string, timeout time.Duration) string {
+	bootID := "read /proc/sys/kernel/random/boot_id"
+	stage := "get machinestatus -o jsonpath='{.spec.stage}'"
+	expected := strings.Join(rebootExpectedStages, "|")
+
 	return strings.Join([]string{
-		"reboot --wait --debug --timeout=20s",
-		// Talosctl exit with code 1 if timeout exceeded.
-		// This code is allowed.
-		"[ $? == 1 ] && true",
-		// Do not retry this command.
+		fmt.Sprintf("%s > boot_id.before || { echo 'node %s: phase reboot-request: failed to read boot id' >&2 ; exit 1 ; }", bootID, node),
+		fmt.Sprintf("%s reboot --wait=false || { echo 'node %s: phase reboot-request: reboot is not accepted' >&2 ; exit 1 ; }", talos, node),
+		fmt.Sprintf("phase=%s ; stage=unknown", RebootPhaseShutdown),
+		fmt.Sprintf("deadline=$(( $(date +%%s) + %d ))", int(timeout.Seconds())),
+		"while [ $(date +%s) -lt $deadline ]",
+		fmt.Sprintf("do sleep %d", int(rebootPollInterval.Seconds())),
+		fmt.Sprintf("current='' ; boot=$(%s %s 2>/dev/null)", talos, bootID),
+		fmt.Sprintf("if [ -z \"$boot\" ] ; then current=$(%s %s --insecure 2>/dev/null) ; [ -z \"$current\" ] && phase=%s || phase=%s ; "+
+			"elif [ \"$boot\" = \"$(cat boot_id.before)\" ] ; then phase=%s ; "+
+			"else phase=%s ; current=$(%s %s 2>/dev/null) ; fi",
+			talos, stage, RebootPhaseBoot, RebootPhaseStartup,
+			RebootPhaseShutdown,
+			RebootPhaseStartup, talos, stage),
+		"[ -n \"$current\" ] && stage=$current",
+		fmt.Sprintf("case \"$current\" in %s) exit 0 ;; esac", expected),
+		"done",
+		fmt.Sprintf("echo \"node %s did not come back after reboot within %s: stuck in phase $phase (last observed stage: $stage)\" >&2", node, timeout),
+		"exit 1",
 	}, " ; ")
 }
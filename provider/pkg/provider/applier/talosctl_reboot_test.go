@@ -0,0 +1,56 @@
+package applier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRebootingTalosctl writes a talosctl stub of a node whose boot ID is kept in a file.
+// The boot ID changes on reboot only if the node comes back.
+func fakeRebootingTalosctl(t *testing.T, comesBack bool) (bin, calls string) {
+	t.Helper()
+
+	boot := filepath.Join(t.TempDir(), "boot_id")
+	require.NoError(t, os.WriteFile(boot, []byte("boot-1\n"), 0o600))
+
+	reboot := "true"
+	if comesBack {
+		reboot = "echo boot-2 > " + boot
+	}
+
+	return fakeTalosctl(t, `case "$*" in
+*"read /proc/sys/kernel/random/boot_id") cat `+boot+` ;;
+*"reboot --wait=false") `+reboot+` ;;
+*"get machinestatus"*) printf running ;;
+esac`)
+}
+
+func TestTalosctlRebootAndWaitArgs_NewBoot(t *testing.T) {
+	rebootPollInterval = time.Second
+	t.Cleanup(func() { rebootPollInterval = 5 * time.Second })
+
+	bin, calls := fakeRebootingTalosctl(t, true)
+
+	_, err := runInDir(t, bin+" "+talosctlRebootAndWaitArgs(bin, "10.0.0.2", DefaultRebootTimeout))
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(calls)
+	require.NoError(t, err)
+	require.Equal(t, "read /proc/sys/kernel/random/boot_id\nreboot --wait=false\n"+
+		"read /proc/sys/kernel/random/boot_id\nget machinestatus -o jsonpath={.spec.stage}\n", string(got))
+}
+
+func TestTalosctlRebootAndWaitArgs_StuckInShutdown(t *testing.T) {
+	rebootPollInterval = time.Second
+	t.Cleanup(func() { rebootPollInterval = 5 * time.Second })
+
+	bin, _ := fakeRebootingTalosctl(t, false)
+
+	out, err := runInDir(t, bin+" "+talosctlRebootAndWaitArgs(bin, "10.0.0.2", time.Second))
+	require.Error(t, err)
+	require.Contains(t, out, "node 10.0.0.2 did not come back after reboot within 1s: stuck in phase shutdown")
+}
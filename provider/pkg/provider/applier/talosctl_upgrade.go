@@ -19,54 +19,63 @@ func (a *Applier) upgrade(m *types.MachineInfo, role tmachine.Type, deps []pulum
 	}
 
 	etcdMemberTarget := a.etcdMembers
+	var debugServices []string
 
 	if role == tmachine.TypeInit {
 		etcdMemberTarget = 1
 	}
 
 	if role == tmachine.TypeInit || role == tmachine.TypeControlPlane {
-		hooks := []*pulumi.ResourceHook{a.etcdReadyHook}
+		// etcd is stopped and started again during the upgrade of a controlplane.
+		debugServices = append(debugServices, "etcd")
+		// An update of the command does not contact the node, only the create upgrades it.
 		opts = append(opts, pulumi.ResourceHooks(&pulumi.ResourceHookBinding{
-			BeforeCreate: hooks,
-			BeforeUpdate: hooks,
+			BeforeCreate: []*pulumi.ResourceHook{a.etcdReadyHook},
 		}))
 	}
 
-	args, err := talosctlUpgradeArgs(m)
+	image, err := installImage(m)
+	if err != nil {
+		return nil, err
+	}
+
+	gated, err := a.gateMigration(m, role, pulumi.String(talosctlUpgradeArgs(image)).ToStringOutput(), deps)
 	if err != nil {
 		return nil, err
 	}
 
 	stageName := "cli-upgrade"
-	home := generateWorkDirNameForTalosctl(a.name, stageName, m.MachineID)
-	t := talosctl.New().WithNodeIP(m.NodeIP)
+	home := a.workDir(stageName, m.MachineID)
+	t := a.cli(m)
 
 	return t.RunCommand(a.ctx, fmt.Sprintf("%s:%s:%s", a.name, stageName, m.MachineID), &talosctl.Args{
 		TalosConfig: a.basicClient().TalosConfig(),
 		PrepareDeps: deps,
 		Dir:         home,
-		CommandArgs: pulumi.String(args),
-		RetryCount:  10,
+		CommandArgs: gated,
+		Retry:       talosctl.DefaultRetryPolicy(),
 		Environment: pulumi.StringMap{
 			"NODE_IP":            pulumi.String(m.NodeIP),
 			"TALOSCTL_HOME":      pulumi.String(home),
 			"ETCD_MEMBER_TARGET": pulumi.String(fmt.Sprint(etcdMemberTarget)),
 		},
-		Triggers: pulumi.Array{pulumi.String(m.TalosImage)},
+		// The node is upgraded to the install image of the configuration. It is the talosImage of the machine
+		// unless the configuration sets another one.
+		Triggers:  pulumi.Array{pulumi.String(image)},
+		OnFailure: a.debugBundleHook(m, stageName, t.BasicCommand, debugServices...),
 	}, opts...)
 }
 
-func talosctlUpgradeArgs(m *types.MachineInfo) (string, error) {
-	machineConfig := m.Configuration
-
+// installImage returns the install image of the machine configuration.
+func installImage(m *types.MachineInfo) (string, error) {
 	var cfg v1alpha1.Config
-	if err := yaml.Unmarshal([]byte(machineConfig), &cfg); err != nil {
+	if err := yaml.Unmarshal([]byte(m.Configuration), &cfg); err != nil {
 		return "", fmt.Errorf("failed to unmarshal machine config: %w", err)
 	}
 
-	img := cfg.MachineConfig.Install().Image()
-
-	base := fmt.Sprintf("upgrade --debug --image %s", img)
+	return cfg.MachineConfig.Install().Image(), nil
+}
 
-	return base, nil
+func talosctlUpgradeArgs(image string) string {
+	return fmt.Sprintf("upgrade --debug --image %s", image)
 }
@@ -0,0 +1,107 @@
+package applier
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	tmachine "github.com/siderolabs/talos/pkg/machinery/config/machine"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+// TestNodeCommands_Triggers checks that every command changing a node reruns on all inputs which change what it does,
+// since an update of a command only keeps its previous output. One-shot steps never rerun.
+func TestNodeCommands_Triggers(t *testing.T) {
+	workDirs, err := talosctl.NewWorkDirs(t.TempDir())
+	require.NoError(t, err)
+
+	config := func(image string) string {
+		return "version: v1alpha1\nmachine:\n  type: worker\n  install:\n    image: " + image + "\n"
+	}
+
+	stages := func(a *Applier, m *types.MachineInfo) error {
+		if _, err := a.initApply(m, tmachine.TypeWorker, nil); err != nil {
+			return err
+		}
+
+		if _, err := a.bootstrap(m, nil); err != nil {
+			return err
+		}
+
+		if _, err := a.upgrade(m, tmachine.TypeWorker, nil); err != nil {
+			return err
+		}
+
+		desired, err := RenderConfig(m.Configuration, m.UserConfigPatches, "")
+		if err != nil {
+			return err
+		}
+
+		if _, err := a.apply(m, pulumi.String(desired).ToStringOutput(), nil); err != nil {
+			return err
+		}
+
+		_, err = a.upgradeK8S(m, nil)
+
+		return err
+	}
+
+	for _, tc := range []struct {
+		name   string
+		change func(m *types.MachineInfo)
+		rerun  []string
+	}{
+		{"nothing", func(*types.MachineInfo) {}, nil},
+		{"install image", func(m *types.MachineInfo) {
+			m.TalosImage = "ghcr.io/siderolabs/installer:v1.11.1"
+			m.Configuration = config(m.TalosImage)
+		}, []string{"cli-upgrade", "cli-apply-config"}},
+		{"install image of the configuration", func(m *types.MachineInfo) {
+			m.Configuration = config("registry.example.com/installer:v1.11.0")
+		}, []string{"cli-upgrade", "cli-apply-config"}},
+		{"user patches", func(m *types.MachineInfo) {
+			m.UserConfigPatches = "machine:\n  network:\n    hostname: worker-1\n"
+		}, []string{"cli-apply-config"}},
+		{"cluster endpoint", func(m *types.MachineInfo) {
+			m.ClusterEnpoint = "https://10.0.0.10:6443"
+		}, []string{"cli-apply-config"}},
+		{"kubernetes version", func(m *types.MachineInfo) {
+			m.KubernetesVersion = "v1.34.0"
+		}, []string{"cli-upgrade-k8s"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e := newCommandEngine()
+			m := types.MachineInfo{
+				MachineID:         "worker-1",
+				NodeIP:            "10.0.0.3",
+				TalosImage:        "ghcr.io/siderolabs/installer:v1.11.0",
+				KubernetesVersion: "v1.33.0",
+				Configuration:     config("ghcr.io/siderolabs/installer:v1.11.0"),
+			}
+			run := func(m types.MachineInfo) []string {
+				e.run(t, func(ctx *pulumi.Context) (pulumi.StringOutput, error) {
+					return pulumi.String("").ToStringOutput(), stages(newTestApplier(ctx, workDirs), &m)
+				})
+
+				var rerun []string
+				for _, name := range e.created {
+					rerun = append(rerun, strings.TrimSuffix(strings.TrimPrefix(name, "dev:"), ":"+m.MachineID))
+				}
+
+				return rerun
+			}
+
+			require.NotEmpty(t, run(m))
+
+			tc.change(&m)
+			rerun := run(m)
+			slices.Sort(rerun)
+			slices.Sort(tc.rerun)
+			require.Equal(t, tc.rerun, rerun)
+		})
+	}
+}
@@ -2,24 +2,41 @@ package provider
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
-	tmachine "github.com/siderolabs/talos/pkg/machinery/config/machine"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
-	pulumi_cluster "github.com/pulumiverse/pulumi-talos/sdk/go/talos/cluster"
 	"github.com/pulumiverse/pulumi-talos/sdk/go/talos/machine"
 
 	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/kubeconfig"
 	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
 )
 
+const (
+	ApplyResourceOutputsCredentials  = "credentials"
+	ApplyResourceOutputsDrift        = "drift"
+	ApplyResourceOutputsPlannedDiff  = "plannedConfigDiff"
+	ApplyResourceOutputsMachines     = "machines"
+	ApplyResourceOutputsCertExpiry   = "adminCertificateExpiry"
+	ApplyResourceOutputsTalosconfigs = "talosconfigs"
+)
+
 type Apply struct {
 	pulumi.ResourceState
 	ApplyArgs
 
-	Credentials pulumi.StringMapOutput `pulumi:"credentials"`
+	Credentials pulumi.StringMapOutput      `pulumi:"credentials"`
+	Drift       pulumi.StringArrayMapOutput `pulumi:"drift"`
+
+	PlannedConfigDiff pulumi.StringMapOutput    `pulumi:"plannedConfigDiff"`
+	Machines          pulumi.StringMapMapOutput `pulumi:"machines"`
+
+	AdminCertificateExpiry pulumi.StringOutput    `pulumi:"adminCertificateExpiry"`
+	Talosconfigs           pulumi.StringMapOutput `pulumi:"talosconfigs"`
 }
 
 func ApplyType() string {
@@ -27,19 +44,37 @@ func ApplyType() string {
 }
 
 type ApplyArgs struct {
-	ClientConfiguration pulumi.StringMapOutput `pulumi:"clientConfiguration"`
-	ApplyMachines       pulumi.ArrayMapOutput  `pulumi:"applyMachines"`
-	SkipInitApply       pulumi.BoolOutput      `pulumi:"skipInitApply"`
+	// ClientConfiguration is optional if ClientTalosconfig is set, so it is nil if not set.
+	ClientConfiguration pulumi.StringMapInput `pulumi:"clientConfiguration"`
+	// ClientTalosconfig is optional, so it is nil if not set.
+	ClientTalosconfig pulumi.StringInput `pulumi:"clientTalosconfig"`
+	// ApplyMachines is optional if MachineConfigurations is set, so it is nil if not set.
+	ApplyMachines pulumi.ArrayMapInput `pulumi:"applyMachines"`
+	// MachineConfigurations is optional, so it is nil if not set.
+	MachineConfigurations pulumi.ArrayInput   `pulumi:"machineConfigurations"`
+	SkipInitApply         pulumi.BoolOutput   `pulumi:"skipInitApply"`
+	DetectDrift           pulumi.BoolOutput   `pulumi:"detectDrift"`
+	ReapplyOnDrift        pulumi.BoolOutput   `pulumi:"reapplyOnDrift"`
+	ApplyMode             pulumi.StringOutput `pulumi:"applyMode"`
+	TryTimeout            pulumi.StringOutput `pulumi:"tryTimeout"`
+	RebootTimeout         pulumi.StringOutput `pulumi:"rebootTimeout"`
+	// Kubeconfig is optional, so it is nil if not set.
+	Kubeconfig pulumi.StringMapInput `pulumi:"kubeconfig"`
+	// AdditionalTalosconfigs is optional, so it is nil if not set.
+	AdditionalTalosconfigs pulumi.ArrayInput `pulumi:"additionalTalosconfigs"`
 }
 
 type ApplyMachines struct {
 	InitMachineConfiguration          *types.MachineInfo   `pulumi:"init"`
 	ControlplaneMachineConfigurations []*types.MachineInfo `pulumi:"controlplane"`
 	WorkerMachineConfigurations       []*types.MachineInfo `pulumi:"worker"`
+	// Unresolved are machines of machineConfigurations which can not be grouped during preview
+	// since their configuration is not known yet.
+	Unresolved []*types.MachineInfo
 }
 
 //nolint:gocognit // apply is complex but mirrors provider logic
-func apply(ctx *pulumi.Context, a *Apply, name string,
+func apply(ctx *pulumi.Context, config *Config, a *Apply, name string,
 	args *ApplyArgs, inputs provider.ConstructInputs, opts ...pulumi.ResourceOption,
 ) (*provider.ConstructResult, error) {
 	// Blit the inputs onto the arguments struct.
@@ -52,32 +87,100 @@ func apply(ctx *pulumi.Context, a *Apply, name string,
 		return nil, err
 	}
 
-	a.Credentials = pulumi.All(args.ApplyMachines, args.SkipInitApply).ApplyT(func(v []any) (pulumi.StringMapOutput, error) {
+	kubeconfigOptions := pulumi.StringMap{}.ToStringMapOutput()
+	if args.Kubeconfig != nil {
+		kubeconfigOptions = args.Kubeconfig.ToStringMapOutput()
+	}
+
+	additionalTalosconfigs := pulumi.Array{}.ToArrayOutput()
+	if args.AdditionalTalosconfigs != nil {
+		additionalTalosconfigs = args.AdditionalTalosconfigs.ToArrayOutput()
+	}
+
+	if (args.ApplyMachines == nil) == (args.MachineConfigurations == nil) {
+		return nil, fmt.Errorf("exactly one of %s and %s must be set", ApplyInputsApplyMachines, ApplyInputsMachineConfigurations)
+	}
+
+	applyMachines := pulumi.ArrayMap{}.ToArrayMapOutput()
+	if args.ApplyMachines != nil {
+		applyMachines = args.ApplyMachines.ToArrayMapOutput()
+	}
+
+	machineConfigurations := pulumi.Array{}.ToArrayOutput()
+	if args.MachineConfigurations != nil {
+		machineConfigurations = args.MachineConfigurations.ToArrayOutput()
+	}
+
+	clientConfiguration, err := applyClientConfiguration(args)
+	if err != nil {
+		return nil, err
+	}
+
+	result := pulumi.All(applyMachines, args.SkipInitApply, args.DetectDrift, args.ReapplyOnDrift,
+		args.ApplyMode, args.TryTimeout, args.RebootTimeout, kubeconfigOptions, additionalTalosconfigs, machineConfigurations,
+	).ApplyT(func(v []any) (pulumi.MapOutput, error) {
+		outputs := make(pulumi.Map)
 		creds := make(pulumi.StringMap, 0)
 		endpoints := make([]string, 0)
 		nodes := make([]string, 0)
 
-		ma := v[0].(map[string][]any)
+		machines, err := parseApplyMachines(v[0].(map[string][]any), v[9].([]any), ctx.DryRun())
+		if err != nil {
+			return outputs.ToMapOutput(), err
+		}
+
+		if len(machines.Unresolved) > 0 {
+			for _, m := range machines.Unresolved {
+				skipUnknownMachine(ctx, a, m)
+			}
+
+			return unknownMap(), nil
+		}
 
-		init := ma[tmachine.TypeInit.String()]
-		if len(init) == 0 {
-			return creds.ToStringMapOutput(), fmt.Errorf("a init node must exist")
+		i := machines.InitMachineConfiguration
+		// Everything depends on the init node, so nothing can be planned without it.
+		if skipUnknownMachine(ctx, a, i) {
+			return unknownMap(), nil
 		}
-		cp := ma[tmachine.TypeControlPlane.String()]
-		workers := ma[tmachine.TypeWorker.String()]
+
+		cp := machines.ControlplaneMachineConfigurations
+		workers := machines.WorkerMachineConfigurations
 
 		app, err := applier.New(ctx, name,
-			buildClientConfigurationFromMap(args.ClientConfiguration),
+			buildClientConfigurationFromMap(clientConfiguration),
 			pulumi.Parent(a),
 		)
 		if err != nil {
-			return creds.ToStringMapOutput(), err
+			return outputs.ToMapOutput(), err
 		}
 
 		app.WithSkipedInitApply(v[1].(bool))
 		app.WithEtcdMembersCount(len(cp) + 1)
+		app.WithDriftDetection(v[2].(bool), v[3].(bool))
 
-		i := types.ParseMachineInfo(init[0].(map[string]any))
+		tryTimeout := time.Duration(0)
+
+		if timeout := v[5].(string); timeout != "" {
+			tryTimeout, err = applier.ParseTryTimeout(timeout)
+			if err != nil {
+				return outputs.ToMapOutput(), err
+			}
+		}
+
+		app.WithApplyMode(v[4].(string), tryTimeout)
+		app.WithTalosctl(config.Talosctl, config.TalosctlVersionCheck)
+		app.WithWorkDirs(config.WorkDirs)
+		app.WithMigrationMode(config.MigrationMode)
+		app.WithDebugBundle(config.DebugBundle, config.DebugBundleDir)
+
+		if timeout := v[6].(string); timeout != "" {
+			d, err := time.ParseDuration(timeout)
+			if err != nil {
+				return outputs.ToMapOutput(), fmt.Errorf("invalid rebootTimeout: %w", err)
+			}
+
+			app.WithRebootTimeout(d)
+		}
 
 		endpoints = append(endpoints, i.NodeIP)
 
@@ -88,31 +191,28 @@ func apply(ctx *pulumi.Context, a *Apply, name string,
 
 		inited, err := app.BootstrapInitNode(i)
 		if err != nil {
-			return creds.ToStringMapOutput(), err
+			return outputs.ToMapOutput(), err
 		}
 
 		controlplanesReady := inited
 
-		for _, m := range cp {
-			ma, ok := m.(map[string]any)
-			if !ok {
-				return creds.ToStringMapOutput(), fmt.Errorf("expected map[string]any, got: %T", m)
+		for _, node := range cp {
+			if skipUnknownMachine(ctx, a, node) {
+				continue
 			}
 
-			node := types.ParseMachineInfo(ma)
-
 			endpoints = append(endpoints, node.NodeIP)
 
 			i, err := app.InitControlplane(node, inited)
 			if err != nil {
-				return creds.ToStringMapOutput(), err
+				return outputs.ToMapOutput(), err
 			}
 
 			controlplanesReady = append(controlplanesReady, i...)
 
 			applied, err := app.ApplyToControlplane(node, controlplanesReady)
 			if err != nil {
-				return creds.ToStringMapOutput(), err
+				return outputs.ToMapOutput(), err
 			}
 
 			controlplanesReady = append(controlplanesReady, applied...)
@@ -121,49 +221,102 @@ func apply(ctx *pulumi.Context, a *Apply, name string,
 		// Nodes contains all nodes, including endpoints
 		nodes = append(nodes, endpoints...)
 
-		for _, m := range workers {
-			ma, ok := m.(map[string]any)
-			if !ok {
-				return creds.ToStringMapOutput(), fmt.Errorf("expected map[string]any, got: %T", m)
+		for _, node := range workers {
+			if skipUnknownMachine(ctx, a, node) {
+				continue
 			}
-			node := types.ParseMachineInfo(ma)
 
 			nodes = append(nodes, node.NodeIP)
 
-			_, err := app.ApplyToWorker(node, inited)
-			if err != nil {
-				return creds.ToStringMapOutput(), err
+			if _, err := app.ApplyToWorker(node, inited); err != nil {
+				return outputs.ToMapOutput(), err
 			}
 		}
 
 		upgraded, err := app.UpgradeK8S(i, controlplanesReady)
 		if err != nil {
-			return creds.ToStringMapOutput(), err
+			return outputs.ToMapOutput(), err
 		}
 
-		kubeconfig, err := pulumi_cluster.NewKubeconfig(ctx, types.KubeconfigKey, &pulumi_cluster.KubeconfigArgs{
-			Node: pulumi.String(i.NodeIP),
-			ClientConfiguration: &pulumi_cluster.KubeconfigClientConfigurationArgs{
-				CaCertificate:     args.ClientConfiguration.MapIndex(pulumi.String(ClusterResourceOutputsClientConfigurationCAKey)),
-				ClientKey:         args.ClientConfiguration.MapIndex(pulumi.String(ClusterResourceOutputsClientConfigurationClientKey)),
-				ClientCertificate: args.ClientConfiguration.MapIndex(pulumi.String(ClusterResourceOutputsClientConfigurationClientCertificateKey)),
-			},
-		}, pulumi.Parent(a),
-			pulumi.DependsOn(upgraded),
-		)
+		if err := app.RecordStatuses(upgraded); err != nil {
+			return outputs.ToMapOutput(), err
+		}
+
+		// The kubeconfig is generated locally, so it does not depend on the init node being up.
+		admin, err := app.NewKubeconfig(i, v[7].(map[string]string))
 		if err != nil {
-			return creds.ToStringMapOutput(), err
+			return outputs.ToMapOutput(), err
 		}
 
 		creds[types.TalosconfigKey] = app.NewTalosconfig(endpoints, nodes).TalosConfig()
-		creds[types.KubeconfigKey] = kubeconfig.KubeconfigRaw
 
-		return creds.ToStringMapOutput(), nil
-	}).(pulumi.StringMapOutput)
+		talosconfigs := make(pulumi.StringMap)
+
+		if specs := v[8].([]any); len(specs) > 0 {
+			ca, err := applier.TalosCA(i.Configuration)
+			if err != nil {
+				return outputs.ToMapOutput(), fmt.Errorf("failed to get Talos CA from %s: %w", i.MachineID, err)
+			}
+
+			for _, s := range specs {
+				sm, ok := s.(map[string]any)
+				if !ok {
+					return outputs.ToMapOutput(), fmt.Errorf("expected map[string]any, got: %T", s)
+				}
+
+				spec, err := types.ParseTalosconfigSpec(sm)
+				if err != nil {
+					return outputs.ToMapOutput(), err
+				}
+
+				if _, ok := talosconfigs[spec.Name]; ok {
+					return outputs.ToMapOutput(), fmt.Errorf("duplicate additional talosconfig %s", spec.Name)
+				}
+
+				talosconfigs[spec.Name], err = app.IssueTalosconfig(spec, ca, endpoints, nodes)
+				if err != nil {
+					return outputs.ToMapOutput(), err
+				}
+			}
+		}
+		creds[types.KubeconfigKey] = admin
+
+		outputs[ApplyResourceOutputsCredentials] = creds
+		outputs[ApplyResourceOutputsDrift] = app.Drift()
+		outputs[ApplyResourceOutputsPlannedDiff] = app.PlannedConfigDiffs()
+		outputs[ApplyResourceOutputsMachines] = app.Machines()
+		outputs[ApplyResourceOutputsTalosconfigs] = talosconfigs
+		outputs[ApplyResourceOutputsCertExpiry] = admin.ApplyT(func(raw string) (string, error) {
+			expiry, err := kubeconfig.CertificateExpiry(raw)
+			if err != nil {
+				return "", err
+			}
+
+			return expiry.UTC().Format(time.RFC3339), nil
+		}).(pulumi.StringOutput)
+
+		return outputs.ToMapOutput(), nil
+	}).(pulumi.MapOutput)
+
+	a.Credentials = pulumi.ToSecret(
+		result.MapIndex(pulumi.String(ApplyResourceOutputsCredentials)).(pulumi.AnyOutput).AsStringMapOutput(),
+	).(pulumi.StringMapOutput)
+	a.Drift = result.MapIndex(pulumi.String(ApplyResourceOutputsDrift)).(pulumi.AnyOutput).AsStringArrayMapOutput()
+	a.PlannedConfigDiff = result.MapIndex(pulumi.String(ApplyResourceOutputsPlannedDiff)).(pulumi.AnyOutput).AsStringMapOutput()
+	a.Machines = result.MapIndex(pulumi.String(ApplyResourceOutputsMachines)).(pulumi.AnyOutput).AsStringMapMapOutput()
+	a.Talosconfigs = pulumi.ToSecret(
+		result.MapIndex(pulumi.String(ApplyResourceOutputsTalosconfigs)).(pulumi.AnyOutput).AsStringMapOutput(),
+	).(pulumi.StringMapOutput)
+	a.AdminCertificateExpiry = result.MapIndex(pulumi.String(ApplyResourceOutputsCertExpiry)).(pulumi.AnyOutput).AsStringOutput()
 
 	if err := ctx.RegisterResourceOutputs(a, pulumi.Map{
-		types.KubeconfigKey:  a.Credentials.MapIndex(pulumi.String(types.KubeconfigKey)),
-		types.TalosconfigKey: a.Credentials.MapIndex(pulumi.String(types.TalosconfigKey)),
+		types.KubeconfigKey:              a.Credentials.MapIndex(pulumi.String(types.KubeconfigKey)),
+		types.TalosconfigKey:             a.Credentials.MapIndex(pulumi.String(types.TalosconfigKey)),
+		ApplyResourceOutputsDrift:        a.Drift,
+		ApplyResourceOutputsPlannedDiff:  a.PlannedConfigDiff,
+		ApplyResourceOutputsMachines:     a.Machines,
+		ApplyResourceOutputsCertExpiry:   a.AdminCertificateExpiry,
+		ApplyResourceOutputsTalosconfigs: a.Talosconfigs,
 	}); err != nil {
 		return nil, err
 	}
@@ -171,6 +324,35 @@ func apply(ctx *pulumi.Context, a *Apply, name string,
 	return provider.NewConstructResult(a)
 }
 
+func parseMachineInfo(raw any, preview bool) (*types.MachineInfo, error) {
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected map[string]any, got: %T", raw)
+	}
+
+	return types.ParseMachineInfo(m, preview)
+}
+
+// skipUnknownMachine reports a machine with values which are not known yet during preview.
+// Such a machine is not planned, its resources are shown on update.
+func skipUnknownMachine(ctx *pulumi.Context, a *Apply, m *types.MachineInfo) bool {
+	if len(m.Unknown) == 0 {
+		return false
+	}
+
+	ctx.Log.Info(fmt.Sprintf("talos-cluster: %s of machine %s are not known yet, it is planned on update",
+		strings.Join(m.Unknown, ", "), m.MachineID), &pulumi.LogArgs{Resource: a})
+
+	return true
+}
+
+// unknownMap returns outputs which are not known yet.
+func unknownMap() pulumi.MapOutput {
+	return pulumi.UnsafeUnknownOutput(nil).ApplyT(func(any) map[string]any {
+		return nil
+	}).(pulumi.MapOutput)
+}
+
 func buildClientConfigurationFromMap(client pulumi.StringMapOutput) *machine.ClientConfigurationArgs {
 	return &machine.ClientConfigurationArgs{
 		CaCertificate:     client.MapIndex(pulumi.String(ClusterResourceOutputsClientConfigurationCAKey)),
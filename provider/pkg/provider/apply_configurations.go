@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/siderolabs/talos/pkg/machinery/config/configloader"
+	tmachine "github.com/siderolabs/talos/pkg/machinery/config/machine"
+
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+const (
+	ApplyInputsApplyMachines         = "applyMachines"
+	ApplyInputsMachineConfigurations = "machineConfigurations"
+	ApplyInputsClientTalosconfig     = "clientTalosconfig"
+)
+
+// parseApplyMachines parses machines from applyMachines or machineConfigurations, whichever is set.
+func parseApplyMachines(grouped map[string][]any, configurations []any, preview bool) (*ApplyMachines, error) {
+	if len(configurations) > 0 {
+		return machinesFromConfigurations(configurations, preview)
+	}
+
+	init := grouped[tmachine.TypeInit.String()]
+	if len(init) == 0 {
+		return nil, fmt.Errorf("a init node must exist")
+	}
+
+	i, err := parseGroupedMachineInfo(init[0], preview)
+	if err != nil {
+		return nil, err
+	}
+
+	machines := &ApplyMachines{InitMachineConfiguration: i}
+
+	for _, m := range grouped[tmachine.TypeControlPlane.String()] {
+		node, err := parseGroupedMachineInfo(m, preview)
+		if err != nil {
+			return nil, err
+		}
+
+		machines.ControlplaneMachineConfigurations = append(machines.ControlplaneMachineConfigurations, node)
+	}
+
+	for _, m := range grouped[tmachine.TypeWorker.String()] {
+		node, err := parseGroupedMachineInfo(m, preview)
+		if err != nil {
+			return nil, err
+		}
+
+		machines.WorkerMachineConfigurations = append(machines.WorkerMachineConfigurations, node)
+	}
+
+	return machines, nil
+}
+
+// parseGroupedMachineInfo parses a machine of applyMachines. The Talos image and the Kubernetes version
+// are optional there, they are taken from the configuration if they are not set.
+func parseGroupedMachineInfo(raw any, preview bool) (*types.MachineInfo, error) {
+	m, err := parseMachineInfo(raw, preview)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m.Unknown) > 0 || (m.TalosImage != "" && m.KubernetesVersion != "") {
+		return m, nil
+	}
+
+	fromConfiguration := &types.MachineInfo{MachineID: m.MachineID, Configuration: m.Configuration}
+	if err := MachineInfoFromConfiguration(fromConfiguration); err != nil {
+		return nil, err
+	}
+
+	if m.TalosImage == "" {
+		m.TalosImage = fromConfiguration.TalosImage
+	}
+
+	if m.KubernetesVersion == "" {
+		m.KubernetesVersion = fromConfiguration.KubernetesVersion
+	}
+
+	return m, nil
+}
+
+// machinesFromConfigurations groups raw machine configurations by the machine type from the configuration.
+// The init machine bootstraps etcd. It is the machine of the init type if there is one, the first controlplane otherwise.
+func machinesFromConfigurations(configurations []any, preview bool) (*ApplyMachines, error) {
+	machines := &ApplyMachines{}
+	controlplanes := make([]*types.MachineInfo, 0)
+
+	for _, raw := range configurations {
+		m, err := parseMachineInfo(raw, preview)
+		if err != nil {
+			return nil, err
+		}
+
+		// The type of a machine is in its configuration, so the machine can not be grouped yet.
+		if len(m.Unknown) > 0 {
+			machines.Unresolved = append(machines.Unresolved, m)
+
+			continue
+		}
+
+		if err := MachineInfoFromConfiguration(m); err != nil {
+			return nil, err
+		}
+
+		switch m.MachineType {
+		case tmachine.TypeInit.String():
+			if machines.InitMachineConfiguration != nil {
+				return nil, fmt.Errorf("machines %s and %s both have the init type, only one is allowed",
+					machines.InitMachineConfiguration.MachineID, m.MachineID)
+			}
+
+			machines.InitMachineConfiguration = m
+		case tmachine.TypeControlPlane.String():
+			controlplanes = append(controlplanes, m)
+		default:
+			machines.WorkerMachineConfigurations = append(machines.WorkerMachineConfigurations, m)
+		}
+	}
+
+	if len(machines.Unresolved) > 0 {
+		return machines, nil
+	}
+
+	if machines.InitMachineConfiguration == nil {
+		if len(controlplanes) == 0 {
+			return nil, fmt.Errorf("%s must have at least one controlplane configuration", ApplyInputsMachineConfigurations)
+		}
+
+		machines.InitMachineConfiguration, controlplanes = controlplanes[0], controlplanes[1:]
+	}
+
+	machines.ControlplaneMachineConfigurations = controlplanes
+
+	return machines, nil
+}
+
+// MachineInfoFromConfiguration fills the machine type, Talos image, Kubernetes version and cluster endpoint
+// from the machine configuration. It is used for configurations generated by other tools.
+func MachineInfoFromConfiguration(m *types.MachineInfo) error {
+	cfg, err := configloader.NewFromBytes([]byte(m.Configuration))
+	if err != nil {
+		return fmt.Errorf("machine %s: failed to load machine configuration: %w", m.MachineID, err)
+	}
+
+	if cfg.Machine() == nil {
+		return fmt.Errorf("machine %s: machine configuration has no machine section", m.MachineID)
+	}
+
+	m.MachineType = cfg.Machine().Type().String()
+	m.TalosImage = cfg.Machine().Install().Image()
+	m.KubernetesVersion = talosctl.VersionFromImage(cfg.Machine().Kubelet().Image())
+
+	if cfg.Cluster() != nil && cfg.Cluster().Endpoint() != nil {
+		m.ClusterEnpoint = cfg.Cluster().Endpoint().String()
+	}
+
+	if m.TalosImage == "" {
+		return fmt.Errorf("machine %s: machine configuration has no install image", m.MachineID)
+	}
+
+	if m.KubernetesVersion == "" {
+		return fmt.Errorf("machine %s: failed to get the Kubernetes version from the kubelet image %q",
+			m.MachineID, cfg.Machine().Kubelet().Image())
+	}
+
+	return nil
+}
+
+// applyClientConfiguration returns the client configuration from clientConfiguration or clientTalosconfig.
+func applyClientConfiguration(args *ApplyArgs) (pulumi.StringMapOutput, error) {
+	switch {
+	case args.ClientConfiguration != nil && args.ClientTalosconfig != nil:
+		return pulumi.StringMapOutput{}, fmt.Errorf("only one of %s and %s can be set",
+			ClusterResourceOutputsClientConfiguration, ApplyInputsClientTalosconfig)
+	case args.ClientConfiguration != nil:
+		return args.ClientConfiguration.ToStringMapOutput(), nil
+	case args.ClientTalosconfig != nil:
+		return args.ClientTalosconfig.ToStringOutput().ApplyT(func(raw string) (map[string]string, error) {
+			creds, err := talosctl.ParseTalosconfig(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", ApplyInputsClientTalosconfig, err)
+			}
+
+			return map[string]string{
+				ClusterResourceOutputsClientConfigurationCAKey:                creds.CACertificate,
+				ClusterResourceOutputsClientConfigurationClientCertificateKey: creds.ClientCertificate,
+				ClusterResourceOutputsClientConfigurationClientKey:            creds.ClientKey,
+			}, nil
+		}).(pulumi.StringMapOutput), nil
+	default:
+		return pulumi.StringMapOutput{}, fmt.Errorf("one of %s and %s is required",
+			ClusterResourceOutputsClientConfiguration, ApplyInputsClientTalosconfig)
+	}
+}
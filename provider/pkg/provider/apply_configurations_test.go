@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
+)
+
+func rawMachineConfiguration(t *testing.T, s *ClusterSecrets, machineType string) string {
+	t.Helper()
+
+	out, err := GenerateMachineConfiguration(&MachineConfigInput{
+		ClusterName:       "dev",
+		ClusterEndpoint:   "https://10.0.0.2:6443",
+		KubernetesVersion: "v1.33.0",
+		TalosVersion:      "v1.11.0",
+		MachineType:       machineType,
+		Patches:           []string{"machine:\n  install:\n    image: ghcr.io/siderolabs/installer:v1.11.0\n"},
+		Secrets:           s,
+	})
+	require.NoError(t, err)
+
+	return out
+}
+
+func TestMachinesFromConfigurations_Grouping(t *testing.T) {
+	s, err := NewClusterSecrets("v1.11.0", "")
+	require.NoError(t, err)
+
+	cp, worker := rawMachineConfiguration(t, s, "controlplane"), rawMachineConfiguration(t, s, "worker")
+
+	machines, err := machinesFromConfigurations([]any{
+		map[string]any{types.MachineIDKey: "worker-1", types.NodeIPKey: "10.0.0.5", types.ConfigurationKey: worker},
+		map[string]any{types.MachineIDKey: "cp-1", types.NodeIPKey: "10.0.0.2", types.ConfigurationKey: cp},
+		map[string]any{types.MachineIDKey: "cp-2", types.NodeIPKey: "10.0.0.3", types.ConfigurationKey: cp},
+	}, false)
+	require.NoError(t, err)
+
+	// The first controlplane bootstraps etcd.
+	init := machines.InitMachineConfiguration
+	require.Equal(t, "cp-1", init.MachineID)
+	require.Equal(t, "controlplane", init.MachineType)
+	require.Equal(t, "ghcr.io/siderolabs/installer:v1.11.0", init.TalosImage)
+	require.Equal(t, "v1.33.0", init.KubernetesVersion)
+	require.Equal(t, "https://10.0.0.2:6443", init.ClusterEnpoint)
+
+	require.Len(t, machines.ControlplaneMachineConfigurations, 1)
+	require.Equal(t, "cp-2", machines.ControlplaneMachineConfigurations[0].MachineID)
+	require.Len(t, machines.WorkerMachineConfigurations, 1)
+	require.Equal(t, "worker", machines.WorkerMachineConfigurations[0].MachineType)
+
+	_, err = machinesFromConfigurations([]any{
+		map[string]any{types.MachineIDKey: "worker-1", types.NodeIPKey: "10.0.0.5", types.ConfigurationKey: worker},
+	}, false)
+	require.EqualError(t, err, "machineConfigurations must have at least one controlplane configuration")
+}
+
+func TestMachinesFromConfigurations_UnknownInPreview(t *testing.T) {
+	machines, err := machinesFromConfigurations([]any{
+		map[string]any{types.MachineIDKey: "cp-1", types.NodeIPKey: "10.0.0.2", types.ConfigurationKey: plugin.UnknownStringValue},
+	}, true)
+	require.NoError(t, err)
+	require.Len(t, machines.Unresolved, 1)
+	require.Nil(t, machines.InitMachineConfiguration)
+}
+
+func TestParseApplyMachines_VersionsFromConfiguration(t *testing.T) {
+	s, err := NewClusterSecrets("v1.11.0", "")
+	require.NoError(t, err)
+
+	cp := rawMachineConfiguration(t, s, "controlplane")
+
+	machines, err := parseApplyMachines(map[string][]any{
+		"init": {map[string]any{types.MachineIDKey: "cp-1", types.NodeIPKey: "10.0.0.2", types.ConfigurationKey: cp}},
+		"controlplane": {map[string]any{
+			types.MachineIDKey: "cp-2", types.NodeIPKey: "10.0.0.3", types.ConfigurationKey: cp,
+			types.TalosImageKey: "ghcr.io/siderolabs/installer:v1.11.1", types.KubernetesVersionKey: "v1.33.1",
+		}},
+	}, nil, false)
+	require.NoError(t, err)
+
+	require.Equal(t, "ghcr.io/siderolabs/installer:v1.11.0", machines.InitMachineConfiguration.TalosImage)
+	require.Equal(t, "v1.33.0", machines.InitMachineConfiguration.KubernetesVersion)
+
+	// Set values win over the configuration.
+	require.Equal(t, "ghcr.io/siderolabs/installer:v1.11.1", machines.ControlplaneMachineConfigurations[0].TalosImage)
+	require.Equal(t, "v1.33.1", machines.ControlplaneMachineConfigurations[0].KubernetesVersion)
+}
@@ -6,12 +6,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
-	"github.com/pulumiverse/pulumi-talos/sdk/go/talos/machine"
 	tmachine "github.com/siderolabs/talos/pkg/machinery/config/machine"
-	"github.com/siderolabs/talos/pkg/machinery/config/types/v1alpha1"
 	"github.com/siderolabs/talos/pkg/machinery/gendata"
 	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/types"
-	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -25,6 +22,7 @@ const (
 	ClusterResourceOutputsControlplaneMachineConfigurations       = "controlplaneMachineConfigurations"
 	ClusterResourceOutputsWorkerMachineConfigurations             = "workerMachineConfigurations"
 	ClusterResourceOutputsInitMachineConfiguration                = "initMachineConfiguration"
+	ClusterResourceOutputsMachineSecrets                          = "machineSecrets"
 	ClusterResourceOutputsClientConfiguration                     = "clientConfiguration"
 	ClusterResourceOutputsClientConfigurationCAKey                = "caCertificate"
 	ClusterResourceOutputsClientConfigurationClientKey            = "clientKey"
@@ -35,9 +33,10 @@ type Cluster struct {
 	pulumi.ResourceState
 	ClusterArgs
 
-	ClientConfiguration     pulumi.StringMap `pulumi:"clientConfiguration"`
-	GeneratedConfigurations pulumi.StringMap `pulumi:"generatedConfigurations"`
-	Machines                pulumi.ArrayMap  `pulumi:"machines"`
+	ClientConfiguration     pulumi.StringMap    `pulumi:"clientConfiguration"`
+	GeneratedConfigurations pulumi.StringMap    `pulumi:"generatedConfigurations"`
+	Machines                pulumi.ArrayMap     `pulumi:"machines"`
+	MachineSecrets          pulumi.StringOutput `pulumi:"machineSecrets"`
 }
 
 func ClusterType() string {
@@ -49,6 +48,7 @@ type ClusterArgs struct {
 	TalosVersionContract pulumi.StringInput `pulumi:"talosVersionContract"`
 	ClusterEndpoint      pulumi.StringInput `pulumi:"clusterEndpoint"`
 	KubernetesVersion    pulumi.StringInput `pulumi:"kubernetesVersion"`
+	ExistingSecrets      pulumi.StringInput `pulumi:"existingSecrets"`
 
 	ClusterMachines []*types.ClusterMachine `pulumi:"clusterMachines"`
 }
@@ -57,7 +57,7 @@ func GenerateDefaultInstallerImage() string {
 	return fmt.Sprintf("%s/%s/installer:%s", gendata.ImagesRegistry, gendata.ImagesUsername, gendata.VersionTag)
 }
 
-func cluster(ctx *pulumi.Context, c *Cluster, name string,
+func cluster(ctx *pulumi.Context, config *Config, c *Cluster, name string,
 	args *ClusterArgs, inputs provider.ConstructInputs, opts ...pulumi.ResourceOption,
 ) (*provider.ConstructResult, error) {
 	// Blit the inputs onto the arguments struct.
@@ -70,26 +70,31 @@ func cluster(ctx *pulumi.Context, c *Cluster, name string,
 		return nil, err
 	}
 
-	secrets, err := machine.NewSecrets(ctx, fmt.Sprintf("%s:secrets", name), &machine.SecretsArgs{
-		TalosVersion: args.TalosVersionContract,
-	}, pulumi.Parent(c), pulumi.IgnoreChanges([]string{"talosVersion"}))
+	existing := args.ExistingSecrets
+	if existing == nil {
+		existing = pulumi.String("")
+	}
+
+	stored, err := clusterSecrets(ctx, config.MigrationMode, name, args.TalosVersionContract, existing, pulumi.Parent(c))
 	if err != nil {
 		return nil, err
 	}
 
+	secrets := parseKeptClusterSecrets(stored)
+	talosVersion := secrets.ApplyT(func(s any) string {
+		return s.(*ClusterSecrets).TalosVersion
+	}).(pulumi.StringOutput)
+	// Only the client key is a secret in the client configuration.
+	clientConfiguration := pulumi.Unsecret(secrets.ApplyT(func(s any) map[string]string {
+		return s.(*ClusterSecrets).ClientConfiguration()
+	})).(pulumi.AnyOutput).AsStringMapOutput()
+
 	workers := make(pulumi.Array, 0)
 	controlplanes := make(pulumi.Array, 0)
 	generated := make(pulumi.StringMap, 0)
 	c.Machines = make(pulumi.ArrayMap)
 
 	for _, m := range args.ClusterMachines {
-		// The provider doesn't know anything about init node type.
-		// It should be the controlplane for it.
-		machineType := m.MachineType
-		if m.MachineType == tmachine.TypeInit.String() {
-			machineType = tmachine.TypeControlPlane.String()
-		}
-
 		if m.ConfigPatches == nil {
 			m.ConfigPatches = pulumi.StringArray{pulumi.String("")}
 		}
@@ -99,41 +104,41 @@ func cluster(ctx *pulumi.Context, c *Cluster, name string,
 			m.TalosImage = pulumi.String(GenerateDefaultInstallerImage())
 		}
 
-		configuration := machine.GetConfigurationOutput(ctx, machine.GetConfigurationOutputArgs{
-			ClusterName:       pulumi.String(args.ClusterName),
-			MachineType:       pulumi.String(machineType),
-			ClusterEndpoint:   args.ClusterEndpoint,
-			KubernetesVersion: args.KubernetesVersion,
-			TalosVersion:      compareContractVersionWithNotify(ctx, secrets.TalosVersion, args.TalosVersionContract.ToStringOutput()),
-			ConfigPatches: pulumi.All(
-				m.ConfigPatches, // StringArrayInput  -> []string in ApplyT
-				configureTalosInstall(m.TalosImage.ToStringPtrOutput().Elem()), // StringInput -> string in ApplyT
-			).ApplyT(func(args []any) []string {
-				base := args[0].([]string) // from m.ConfigPatches
-				extra := args[1].(string)  // from configureTalosInstall(...)
-				// append safely (copy if you care about not aliasing base)
-				out := make([]string, 0, len(base)+1)
-				out = append(out, base...)
-				out = append(out, extra)
-				return out
-			}).(pulumi.StringArrayOutput),
-
-			MachineSecrets: secrets.ToSecretsOutput().MachineSecrets(),
-		}, nil)
-
-		generated[m.MachineID] = configuration.MachineConfiguration()
+		configuration := pulumi.All(
+			secrets,
+			args.ClusterEndpoint,
+			args.KubernetesVersion,
+			compareContractVersionWithNotify(ctx, talosVersion, args.TalosVersionContract.ToStringOutput()),
+			m.ConfigPatches, // StringArrayInput  -> []string in ApplyT
+			m.TalosImage.ToStringPtrOutput().Elem(),
+		).ApplyT(func(v []any) (string, error) {
+			return generateClusterMachineConfiguration(&MachineConfigInput{
+				ClusterName:       args.ClusterName,
+				ClusterEndpoint:   v[1].(string),
+				KubernetesVersion: v[2].(string),
+				TalosVersion:      v[3].(string),
+				MachineType:       m.MachineType,
+				Patches:           v[4].([]string),
+				Secrets:           v[0].(*ClusterSecrets),
+			}, v[5].(string))
+		}).(pulumi.StringOutput)
+
+		// The configuration includes cluster secrets.
+		machineConfig := pulumi.ToSecret(configuration).(pulumi.StringOutput)
+
+		generated[m.MachineID] = machineConfig
 
 		switch m.MachineType {
 		case tmachine.TypeControlPlane.String():
-			controlplanes = append(controlplanes, m.ToMachineInfoMap(args.ClusterEndpoint, args.KubernetesVersion, configuration.MachineConfiguration()))
+			controlplanes = append(controlplanes, m.ToMachineInfoMap(args.ClusterEndpoint, args.KubernetesVersion, machineConfig))
 		case tmachine.TypeWorker.String():
-			workers = append(workers, m.ToMachineInfoMap(args.ClusterEndpoint, args.KubernetesVersion, configuration.MachineConfiguration()))
+			workers = append(workers, m.ToMachineInfoMap(args.ClusterEndpoint, args.KubernetesVersion, machineConfig))
 		case tmachine.TypeInit.String():
 			if len(c.Machines) == 1 {
 				return nil, fmt.Errorf("only one init node should present. Please use 'controlplane' type for %s", m.MachineID)
 			}
 
-			c.Machines[tmachine.TypeInit.String()] = pulumi.Array{m.ToMachineInfoMap(args.ClusterEndpoint, args.KubernetesVersion, configuration.MachineConfiguration())}
+			c.Machines[tmachine.TypeInit.String()] = pulumi.Array{m.ToMachineInfoMap(args.ClusterEndpoint, args.KubernetesVersion, machineConfig)}
 		default:
 			return nil, fmt.Errorf("unknown machine type %s", m.MachineType)
 		}
@@ -145,15 +150,21 @@ func cluster(ctx *pulumi.Context, c *Cluster, name string,
 	c.GeneratedConfigurations = generated
 
 	c.ClientConfiguration = pulumi.StringMap{
-		ClusterResourceOutputsClientConfigurationCAKey:                secrets.ClientConfiguration.CaCertificate(),
-		ClusterResourceOutputsClientConfigurationClientKey:            secrets.ClientConfiguration.ClientKey(),
-		ClusterResourceOutputsClientConfigurationClientCertificateKey: secrets.ClientConfiguration.ClientCertificate(),
+		ClusterResourceOutputsClientConfigurationCAKey:                clientConfiguration.MapIndex(pulumi.String(ClusterResourceOutputsClientConfigurationCAKey)),
+		ClusterResourceOutputsClientConfigurationClientKey:            pulumi.ToSecret(clientConfiguration.MapIndex(pulumi.String(ClusterResourceOutputsClientConfigurationClientKey))).(pulumi.StringOutput),
+		ClusterResourceOutputsClientConfigurationClientCertificateKey: clientConfiguration.MapIndex(pulumi.String(ClusterResourceOutputsClientConfigurationClientCertificateKey)),
 	}
+	c.MachineSecrets = stored
 
 	if err := ctx.RegisterResourceOutputs(c, pulumi.Map{
-		ClusterResourceOutputsClientConfiguration:     secrets.ClientConfiguration,
+		ClusterResourceOutputsMachineSecrets:          stored,
+		ClusterResourceOutputsClientConfiguration:     c.ClientConfiguration,
 		ClusterResourceOutputsMachines:                c.Machines,
 		ClusterResourceOutputsGeneratedConfigurations: generated,
+		// Methods generate new configurations with them.
+		ClusterResourceOutputsClusterName:       pulumi.String(args.ClusterName),
+		ClusterResourceOutputsClusterEndpoint:   args.ClusterEndpoint,
+		ClusterResourceOutputsKubernetesVersion: args.KubernetesVersion,
 	}); err != nil {
 		return nil, err
 	}
@@ -161,25 +172,6 @@ func cluster(ctx *pulumi.Context, c *Cluster, name string,
 	return provider.NewConstructResult(c)
 }
 
-func configureTalosInstall(image pulumi.StringOutput) pulumi.StringOutput {
-	return pulumi.All(image).ApplyT(func(args []any) (string, error) {
-		image := args[0].(string)
-
-		talosImagePatch := v1alpha1.Config{
-			MachineConfig: &v1alpha1.MachineConfig{
-				MachineInstall: &v1alpha1.InstallConfig{
-					InstallImage: image,
-				},
-			},
-		}
-		encoded, err := yaml.Marshal(talosImagePatch)
-		if err != nil {
-			return "", err
-		}
-		return string(encoded), nil
-	}).(pulumi.StringOutput)
-}
-
 func compareContractVersionWithNotify(ctx *pulumi.Context, init pulumi.StringOutput, got pulumi.StringOutput) pulumi.StringOutput {
 	return pulumi.All(got, init).ApplyT(func(v []any) string {
 		got := v[0].(string)
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/siderolabs/talos/pkg/machinery/config"
+	"github.com/siderolabs/talos/pkg/machinery/config/configpatcher"
+	"github.com/siderolabs/talos/pkg/machinery/config/encoder"
+	"github.com/siderolabs/talos/pkg/machinery/config/generate"
+	tmachine "github.com/siderolabs/talos/pkg/machinery/config/machine"
+	"github.com/siderolabs/talos/pkg/machinery/config/types/v1alpha1"
+	"gopkg.in/yaml.v3"
+)
+
+// MachineConfigInput holds everything needed to render the configuration of a machine.
+type MachineConfigInput struct {
+	ClusterName       string
+	ClusterEndpoint   string
+	KubernetesVersion string
+	// TalosVersion is the version contract of the configuration.
+	TalosVersion string
+	MachineType  string
+	Patches      []string
+	Secrets      *ClusterSecrets
+}
+
+// GenerateMachineConfiguration renders a machine configuration in the same way as
+// the talos_machine_configuration data source of the talos terraform provider:
+// generate the config with the secrets bundle, apply patches in order and encode it with docs and examples.
+func GenerateMachineConfiguration(in *MachineConfigInput) (string, error) {
+	machineType, err := tmachine.ParseType(in.MachineType)
+	if err != nil {
+		return "", err
+	}
+
+	contract, err := config.ParseContractFromVersion(in.TalosVersion)
+	if err != nil {
+		return "", fmt.Errorf("invalid talos version contract %q: %w", in.TalosVersion, err)
+	}
+
+	input, err := generate.NewInput(in.ClusterName, in.ClusterEndpoint, strings.TrimPrefix(in.KubernetesVersion, "v"),
+		generate.WithVersionContract(contract),
+		generate.WithSecretsBundle(in.Secrets.Bundle),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to create generate input: %w", err)
+	}
+
+	cfg, err := input.Config(machineType)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate %s configuration: %w", machineType, err)
+	}
+
+	patches, err := loadConfigPatches(in.Patches)
+	if err != nil {
+		return "", err
+	}
+
+	if len(patches) > 0 {
+		patched, err := configpatcher.Apply(configpatcher.WithConfig(cfg), patches)
+		if err != nil {
+			return "", fmt.Errorf("failed to apply config patches: %w", err)
+		}
+
+		if cfg, err = patched.Config(); err != nil {
+			return "", err
+		}
+	}
+
+	return cfg.EncodeString(encoder.WithComments(encoder.CommentsDocs | encoder.CommentsExamples))
+}
+
+// loadConfigPatches loads patches in order. Patches are always literals, never files.
+// A blank patch is skipped: it is the default of configPatches and it is loaded as an empty JSON patch,
+// which is not supported for multi-document configurations of the v1.12 contract.
+func loadConfigPatches(raw []string) ([]configpatcher.Patch, error) {
+	patches := make([]configpatcher.Patch, 0, len(raw))
+
+	for i, p := range raw {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+
+		patch, err := configpatcher.LoadPatch([]byte(p))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config patch %d: %w", i, err)
+		}
+
+		patches = append(patches, patch)
+	}
+
+	return patches, nil
+}
+
+// generateClusterMachineConfiguration renders the configuration of a Cluster machine.
+// The install image patch is applied after the user patches.
+func generateClusterMachineConfiguration(in *MachineConfigInput, image string) (string, error) {
+	patch, err := talosInstallPatch(image)
+	if err != nil {
+		return "", err
+	}
+
+	machine := *in
+	machine.MachineType = generatedMachineType(in.MachineType)
+	machine.Patches = append(slices.Clone(in.Patches), patch)
+
+	return GenerateMachineConfiguration(&machine)
+}
+
+// generatedMachineType returns the type used for configuration generation.
+// The provider doesn't know anything about init node type.
+// It should be the controlplane for it.
+func generatedMachineType(machineType string) string {
+	if machineType == tmachine.TypeInit.String() {
+		return tmachine.TypeControlPlane.String()
+	}
+
+	return machineType
+}
+
+// talosInstallPatch returns a patch setting the installation image.
+func talosInstallPatch(image string) (string, error) {
+	talosImagePatch := v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineInstall: &v1alpha1.InstallConfig{
+				InstallImage: image,
+			},
+		},
+	}
+
+	encoded, err := yaml.Marshal(talosImagePatch)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/siderolabs/talos/pkg/machinery/config"
+	"github.com/siderolabs/talos/pkg/machinery/config/bundle"
+	"github.com/siderolabs/talos/pkg/machinery/config/configloader"
+	"github.com/siderolabs/talos/pkg/machinery/config/configpatcher"
+	"github.com/siderolabs/talos/pkg/machinery/config/encoder"
+	"github.com/siderolabs/talos/pkg/machinery/config/generate"
+	tmachine "github.com/siderolabs/talos/pkg/machinery/config/machine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateMachineConfiguration_Patches(t *testing.T) {
+	s, err := NewClusterSecrets("v1.11.0", "")
+	require.NoError(t, err)
+
+	in := &MachineConfigInput{
+		ClusterName:       "dev",
+		ClusterEndpoint:   "https://10.0.0.2:6443",
+		KubernetesVersion: "v1.33.0",
+		TalosVersion:      "v1.11.0",
+		MachineType:       "controlplane",
+		Patches:           []string{"", "machine:\n  install:\n    image: ghcr.io/siderolabs/installer:v1.11.0\n"},
+		Secrets:           s,
+	}
+
+	out, err := GenerateMachineConfiguration(in)
+	require.NoError(t, err)
+
+	again, err := GenerateMachineConfiguration(in)
+	require.NoError(t, err)
+	require.Equal(t, out, again)
+
+	cfg, err := configloader.NewFromBytes([]byte(out))
+	require.NoError(t, err)
+
+	require.Equal(t, "ghcr.io/siderolabs/installer:v1.11.0", cfg.Machine().Install().Image())
+	require.Equal(t, "registry.k8s.io/kube-apiserver:v1.33.0", cfg.Cluster().APIServer().Image())
+	require.Equal(t, s.Bundle.Cluster.ID, cfg.Cluster().ID())
+	require.Contains(t, out, "# ")
+}
+
+// updateGolden rewrites the golden files with the output of the terraform provider code path.
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// terraformMachineConfiguration renders a configuration like the talos_machine_configuration data source
+// of the talos terraform provider, which the pulumiverse provider wraps.
+func terraformMachineConfiguration(t *testing.T, in *MachineConfigInput) string {
+	t.Helper()
+
+	contract, err := config.ParseContractFromVersion(in.TalosVersion)
+	require.NoError(t, err)
+
+	machineType, err := tmachine.ParseType(in.MachineType)
+	require.NoError(t, err)
+
+	b, err := bundle.NewBundle(bundle.WithInputOptions(&bundle.InputOptions{
+		ClusterName: in.ClusterName,
+		Endpoint:    in.ClusterEndpoint,
+		KubeVersion: strings.TrimPrefix(in.KubernetesVersion, "v"),
+		GenOptions:  []generate.Option{generate.WithVersionContract(contract), generate.WithSecretsBundle(in.Secrets.Bundle)},
+	}), bundle.WithVerbose(false))
+	require.NoError(t, err)
+
+	patches, err := configpatcher.LoadPatches(in.Patches)
+	require.NoError(t, err)
+	require.NoError(t, b.ApplyPatches(patches, machineType == tmachine.TypeControlPlane, machineType == tmachine.TypeWorker))
+
+	out, err := b.Serialize(encoder.CommentsDocs|encoder.CommentsExamples, machineType)
+	require.NoError(t, err)
+
+	return string(out)
+}
+
+// The output must stay byte-identical to the pulumiverse provider, otherwise existing stacks would apply
+// a changed configuration to every node.
+func TestGenerateMachineConfiguration_Golden(t *testing.T) {
+	raw, err := os.ReadFile(filepath.Join("testdata", "secrets.yaml"))
+	require.NoError(t, err)
+
+	s, err := NewClusterSecrets("v1.11.0", string(raw))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		machineType, talosVersion string
+	}{
+		{"controlplane", "v1.11.0"},
+		{"worker", "v1.12.0"},
+	} {
+		in := &MachineConfigInput{
+			ClusterName:       "dev",
+			ClusterEndpoint:   "https://10.0.0.2:6443",
+			KubernetesVersion: "v1.33.0",
+			TalosVersion:      tc.talosVersion,
+			MachineType:       tc.machineType,
+			Patches: []string{
+				"machine:\n  install:\n    image: ghcr.io/siderolabs/installer:" + tc.talosVersion + "\n",
+				"cluster:\n  allowSchedulingOnControlPlanes: true\n",
+			},
+			Secrets: s,
+		}
+
+		golden := filepath.Join("testdata", fmt.Sprintf("%s-%s.yaml", tc.machineType, tc.talosVersion))
+		if *updateGolden {
+			require.NoError(t, os.WriteFile(golden, []byte(terraformMachineConfiguration(t, in)), 0o600))
+		}
+
+		want, err := os.ReadFile(golden)
+		require.NoError(t, err)
+		require.Equal(t, string(want), terraformMachineConfiguration(t, in), "the golden file is outdated, run the test with -update")
+
+		out, err := GenerateMachineConfiguration(in)
+		require.NoError(t, err)
+		require.Equal(t, string(want), out)
+	}
+}
+
+// configPatches of a Cluster machine defaults to a blank patch.
+func TestGenerateClusterMachineConfiguration_DefaultPatches(t *testing.T) {
+	s, err := NewClusterSecrets("v1.12.0", "")
+	require.NoError(t, err)
+
+	in := &MachineConfigInput{
+		ClusterName:       "dev",
+		ClusterEndpoint:   "https://10.0.0.2:6443",
+		KubernetesVersion: "v1.33.0",
+		TalosVersion:      "v1.12.0",
+		MachineType:       "init",
+		Patches:           []string{""},
+		Secrets:           s,
+	}
+
+	out, err := generateClusterMachineConfiguration(in, "ghcr.io/siderolabs/installer:v1.12.0")
+	require.NoError(t, err)
+
+	cfg, err := configloader.NewFromBytes([]byte(out))
+	require.NoError(t, err)
+	require.Equal(t, "controlplane", cfg.Machine().Type().String())
+	require.Equal(t, "ghcr.io/siderolabs/installer:v1.12.0", cfg.Machine().Install().Image())
+
+	// Blank patches change nothing.
+	in.MachineType = "worker"
+	blank, err := GenerateMachineConfiguration(in)
+	require.NoError(t, err)
+
+	in.Patches = nil
+	unpatched, err := GenerateMachineConfiguration(in)
+	require.NoError(t, err)
+	require.Equal(t, unpatched, blank)
+}
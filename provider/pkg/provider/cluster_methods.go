@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	pp "github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
+	tmachine "github.com/siderolabs/talos/pkg/machinery/config/machine"
+	"github.com/siderolabs/talos/pkg/machinery/role"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/version"
+)
+
+const (
+	ClusterMethodGetTalosconfig   = "getTalosconfig"
+	ClusterMethodGetMachineConfig = "getMachineConfig"
+	ClusterMethodGetJoinConfig    = "getJoinConfig"
+
+	ClusterMethodResultTalosconfig   = "talosconfig"
+	ClusterMethodResultConfiguration = "configuration"
+
+	ClusterResourceOutputsClusterName       = "clusterName"
+	ClusterResourceOutputsClusterEndpoint   = "clusterEndpoint"
+	ClusterResourceOutputsKubernetesVersion = "kubernetesVersion"
+)
+
+func ClusterMethodType(method string) string {
+	return ClusterType() + "/" + method
+}
+
+// clusterState is the registered state of a Cluster. It is the `__self__` of Cluster methods.
+type clusterState struct {
+	pulumi.ResourceState
+
+	ClusterName             pulumi.StringOutput    `pulumi:"clusterName"`
+	ClusterEndpoint         pulumi.StringOutput    `pulumi:"clusterEndpoint"`
+	KubernetesVersion       pulumi.StringOutput    `pulumi:"kubernetesVersion"`
+	MachineSecrets          pulumi.StringOutput    `pulumi:"machineSecrets"`
+	GeneratedConfigurations pulumi.StringMapOutput `pulumi:"generatedConfigurations"`
+}
+
+// clusterModule rehydrates Cluster references passed to methods.
+type clusterModule struct{}
+
+func (clusterModule) Version() semver.Version {
+	v, err := semver.ParseTolerant(version.Version)
+	if err != nil {
+		return semver.Version{}
+	}
+
+	return v
+}
+
+func (clusterModule) Construct(ctx *pulumi.Context, name, typ, urn string) (pulumi.Resource, error) {
+	if typ != ClusterType() {
+		return nil, fmt.Errorf("unknown resource type %s", typ)
+	}
+
+	r := &clusterState{}
+
+	return r, ctx.RegisterResource(typ, name, nil, r, pulumi.URN_(urn))
+}
+
+func init() {
+	pulumi.RegisterResourceModule(ProviderName, "index", clusterModule{})
+}
+
+type GetTalosconfigArgs struct {
+	Endpoints pulumi.StringArrayInput `pulumi:"endpoints"`
+	Nodes     pulumi.StringArrayInput `pulumi:"nodes"`
+	Roles     pulumi.StringArrayInput `pulumi:"roles"`
+}
+
+type GetTalosconfigResult struct {
+	Talosconfig pulumi.StringOutput `pulumi:"talosconfig"`
+}
+
+type GetMachineConfigArgs struct {
+	MachineID    string                  `pulumi:"machineId"`
+	ExtraPatches pulumi.StringArrayInput `pulumi:"extraPatches"`
+}
+
+type GetJoinConfigArgs struct {
+	Role string `pulumi:"role"`
+}
+
+type GetConfigResult struct {
+	Configuration pulumi.StringOutput `pulumi:"configuration"`
+}
+
+// callCluster executes a Cluster method.
+func callCluster(method string, args pp.CallArgs) (*pp.CallResult, error) {
+	switch method {
+	case ClusterMethodGetTalosconfig:
+		a := &GetTalosconfigArgs{}
+
+		c, err := clusterSelf(args, a)
+		if err != nil {
+			return nil, err
+		}
+
+		talosconfig := pulumi.All(c.ClusterName, c.MachineSecrets, stringArray(a.Endpoints), stringArray(a.Nodes), stringArray(a.Roles)).
+			ApplyT(func(v []any) (string, error) {
+				return ClusterTalosconfig(v[1].(string), v[0].(string), v[2].([]string), v[3].([]string), v[4].([]string), time.Now())
+			}).(pulumi.StringOutput)
+
+		return pp.NewCallResult(&GetTalosconfigResult{Talosconfig: pulumi.ToSecret(talosconfig).(pulumi.StringOutput)})
+	case ClusterMethodGetMachineConfig:
+		a := &GetMachineConfigArgs{}
+
+		c, err := clusterSelf(args, a)
+		if err != nil {
+			return nil, err
+		}
+
+		configuration := pulumi.All(c.GeneratedConfigurations, stringArray(a.ExtraPatches)).ApplyT(func(v []any) (string, error) {
+			return ClusterMachineConfig(v[0].(map[string]string), a.MachineID, v[1].([]string))
+		}).(pulumi.StringOutput)
+
+		return pp.NewCallResult(&GetConfigResult{Configuration: pulumi.ToSecret(configuration).(pulumi.StringOutput)})
+	case ClusterMethodGetJoinConfig:
+		a := &GetJoinConfigArgs{}
+
+		c, err := clusterSelf(args, a)
+		if err != nil {
+			return nil, err
+		}
+
+		configuration := pulumi.All(c.ClusterName, c.ClusterEndpoint, c.KubernetesVersion, c.MachineSecrets).ApplyT(func(v []any) (string, error) {
+			return ClusterJoinConfig(v[3].(string), &MachineConfigInput{
+				ClusterName:       v[0].(string),
+				ClusterEndpoint:   v[1].(string),
+				KubernetesVersion: v[2].(string),
+				MachineType:       a.Role,
+			})
+		}).(pulumi.StringOutput)
+
+		return pp.NewCallResult(&GetConfigResult{Configuration: pulumi.ToSecret(configuration).(pulumi.StringOutput)})
+	default:
+		return nil, fmt.Errorf("unknown method %s of %s", method, ClusterType())
+	}
+}
+
+func clusterSelf(args pp.CallArgs, methodArgs any) (*clusterState, error) {
+	self, err := args.CopyTo(methodArgs)
+	if err != nil {
+		return nil, fmt.Errorf("setting args: %w", err)
+	}
+
+	c, ok := self.(*clusterState)
+	if !ok {
+		return nil, fmt.Errorf("__self__ is not a %s", ClusterType())
+	}
+
+	return c, nil
+}
+
+// stringArray returns an empty array for unset inputs.
+func stringArray(in pulumi.StringArrayInput) pulumi.StringArrayOutput {
+	if in == nil {
+		return pulumi.StringArray{}.ToStringArrayOutput()
+	}
+
+	return in.ToStringArrayOutput()
+}
+
+// ClusterTalosconfig issues a talosconfig signed by the Talos CA of the cluster secrets.
+// The admin role is used if roles are empty.
+func ClusterTalosconfig(secrets, name string, endpoints, nodes, roles []string, now time.Time) (string, error) {
+	s, err := ParseClusterSecrets(secrets)
+	if err != nil {
+		return "", err
+	}
+
+	if len(roles) == 0 {
+		roles = []string{string(role.Admin)}
+	}
+
+	return talosctl.IssueTalosconfig(name, endpoints, nodes, roles, applier.DefaultTalosconfigTTL, s.Bundle.Certs.OS, now)
+}
+
+// ClusterMachineConfig returns the generated configuration of a machine merged with extra patches.
+func ClusterMachineConfig(configurations map[string]string, machineID string, extraPatches []string) (string, error) {
+	configuration, ok := configurations[machineID]
+	if !ok {
+		return "", fmt.Errorf("unknown machine %s", machineID)
+	}
+
+	return applier.RenderConfig(configuration, strings.Join(extraPatches, "\n---\n"), "")
+}
+
+// ClusterJoinConfig generates a configuration for a new machine of the role, which joins the cluster.
+func ClusterJoinConfig(secrets string, in *MachineConfigInput) (string, error) {
+	roles := []string{tmachine.TypeControlPlane.String(), tmachine.TypeWorker.String()}
+	if !slices.Contains(roles, in.MachineType) {
+		return "", fmt.Errorf("unsupported role %q, supported: %s", in.MachineType, strings.Join(roles, ", "))
+	}
+
+	s, err := ParseClusterSecrets(secrets)
+	if err != nil {
+		return "", err
+	}
+
+	join := *in
+	join.TalosVersion = s.TalosVersion
+	join.Secrets = s
+
+	return generateClusterMachineConfiguration(&join, GenerateDefaultInstallerImage())
+}
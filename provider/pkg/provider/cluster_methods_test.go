@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/siderolabs/talos/pkg/machinery/config/configloader"
+	"github.com/siderolabs/talos/pkg/machinery/gendata"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func storedSecrets(t *testing.T) (string, *ClusterSecrets) {
+	t.Helper()
+
+	s, err := NewClusterSecrets(gendata.VersionTag, "")
+	require.NoError(t, err)
+
+	raw, err := yaml.Marshal(s)
+	require.NoError(t, err)
+
+	return string(raw), s
+}
+
+func TestClusterTalosconfig(t *testing.T) {
+	raw, _ := storedSecrets(t)
+
+	talosconfig, err := ClusterTalosconfig(raw, "dev", []string{"10.0.0.2"}, []string{"10.0.0.3"}, []string{"os:reader"}, time.Now())
+	require.NoError(t, err)
+	require.Contains(t, talosconfig, "context: dev")
+
+	_, err = ClusterTalosconfig(raw, "dev", nil, nil, []string{"os:unknown"}, time.Now())
+	require.ErrorContains(t, err, "unknown roles")
+}
+
+func TestClusterMachineConfig(t *testing.T) {
+	configurations := map[string]string{"w-1": "machine:\n  type: worker\n"}
+
+	out, err := ClusterMachineConfig(configurations, "w-1", []string{"machine:\n  network:\n    hostname: w-1\n"})
+	require.NoError(t, err)
+	require.Contains(t, out, "hostname: w-1")
+
+	_, err = ClusterMachineConfig(configurations, "w-2", nil)
+	require.ErrorContains(t, err, "unknown machine w-2")
+}
+
+func TestClusterJoinConfig(t *testing.T) {
+	raw, s := storedSecrets(t)
+
+	in := &MachineConfigInput{
+		ClusterName:       "dev",
+		ClusterEndpoint:   "https://10.0.0.2:6443",
+		KubernetesVersion: "v1.33.0",
+		MachineType:       "worker",
+	}
+
+	out, err := ClusterJoinConfig(raw, in)
+	require.NoError(t, err)
+
+	cfg, err := configloader.NewFromBytes([]byte(out))
+	require.NoError(t, err)
+	require.Equal(t, s.Bundle.Cluster.ID, cfg.Cluster().ID())
+	require.Equal(t, "worker", cfg.Machine().Type().String())
+
+	in.MachineType = "init"
+	_, err = ClusterJoinConfig(raw, in)
+	require.ErrorContains(t, err, "unsupported role")
+}
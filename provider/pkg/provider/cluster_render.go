@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	tmachine "github.com/siderolabs/talos/pkg/machinery/config/machine"
+	"github.com/siderolabs/talos/pkg/machinery/gendata"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+)
+
+// ClusterSpec is the plain form of ClusterArgs. It is used to render a cluster without Pulumi.
+type ClusterSpec struct {
+	ClusterName          string                `yaml:"clusterName"`
+	ClusterEndpoint      string                `yaml:"clusterEndpoint"`
+	KubernetesVersion    string                `yaml:"kubernetesVersion"`
+	TalosVersionContract string                `yaml:"talosVersionContract"`
+	ExistingSecrets      string                `yaml:"existingSecrets"`
+	ClusterMachines      []*ClusterMachineSpec `yaml:"clusterMachines"`
+}
+
+// ClusterMachineSpec is the plain form of types.ClusterMachine.
+type ClusterMachineSpec struct {
+	MachineID     string   `yaml:"machineId"`
+	MachineType   string   `yaml:"machineType"`
+	NodeIP        string   `yaml:"nodeIp"`
+	TalosImage    string   `yaml:"talosImage"`
+	ConfigPatches []string `yaml:"configPatches"`
+}
+
+// RenderedCluster is the result of RenderCluster.
+type RenderedCluster struct {
+	Spec        *ClusterSpec
+	Secrets     *ClusterSecrets
+	Machines    []*RenderedMachine
+	Talosconfig string
+}
+
+// RenderedMachine is a machine with the configuration which is sent to the node by the Apply component.
+type RenderedMachine struct {
+	MachineID     string `yaml:"machineId"`
+	MachineType   string `yaml:"machineType"`
+	NodeIP        string `yaml:"nodeIp"`
+	TalosImage    string `yaml:"talosImage"`
+	ConfigHash    string `yaml:"configHash"`
+	Configuration string `yaml:"-"`
+}
+
+// WithDefaults fills unset fields with the defaults of the Cluster component.
+func (s *ClusterSpec) WithDefaults() *ClusterSpec {
+	if s.KubernetesVersion == "" {
+		s.KubernetesVersion = DefaultK8SVersion
+	}
+
+	if s.TalosVersionContract == "" {
+		s.TalosVersionContract = gendata.VersionTag
+	}
+
+	for _, m := range s.ClusterMachines {
+		if m.TalosImage == "" {
+			m.TalosImage = GenerateDefaultInstallerImage()
+		}
+
+		if len(m.ConfigPatches) == 0 {
+			m.ConfigPatches = []string{""}
+		}
+	}
+
+	return s
+}
+
+// Validate checks the spec in the same way as the Cluster component does.
+func (s *ClusterSpec) Validate() error {
+	if s.ClusterName == "" || s.ClusterEndpoint == "" {
+		return fmt.Errorf("clusterName and clusterEndpoint are required")
+	}
+
+	inits := 0
+
+	for _, m := range s.ClusterMachines {
+		if m.MachineID == "" || m.NodeIP == "" {
+			return fmt.Errorf("machineId and nodeIp are required for every machine")
+		}
+
+		switch m.MachineType {
+		case tmachine.TypeInit.String():
+			if inits++; inits > 1 {
+				return fmt.Errorf("only one init node should present. Please use 'controlplane' type for %s", m.MachineID)
+			}
+		case tmachine.TypeControlPlane.String(), tmachine.TypeWorker.String():
+		default:
+			return fmt.Errorf("unknown machine type %s", m.MachineType)
+		}
+	}
+
+	return nil
+}
+
+// RenderCluster renders configurations of all machines and an admin talosconfig without contacting any node.
+// Configurations are generated like in the Cluster component and merged with patches like in the Apply component.
+func RenderCluster(spec *ClusterSpec, secrets *ClusterSecrets) (*RenderedCluster, error) {
+	spec.WithDefaults()
+
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	rendered := &RenderedCluster{
+		Spec:    spec,
+		Secrets: secrets,
+	}
+
+	var endpoints, nodes []string
+
+	for _, m := range spec.ClusterMachines {
+		configuration, err := generateClusterMachineConfiguration(&MachineConfigInput{
+			ClusterName:       spec.ClusterName,
+			ClusterEndpoint:   spec.ClusterEndpoint,
+			KubernetesVersion: spec.KubernetesVersion,
+			TalosVersion:      secrets.TalosVersion,
+			MachineType:       m.MachineType,
+			Patches:           m.ConfigPatches,
+			Secrets:           secrets,
+		}, m.TalosImage)
+		if err != nil {
+			return nil, fmt.Errorf("machine %s: %w", m.MachineID, err)
+		}
+
+		final, err := applier.RenderConfig(configuration, strings.Join(m.ConfigPatches, "\n---\n"), "")
+		if err != nil {
+			return nil, fmt.Errorf("machine %s: %w", m.MachineID, err)
+		}
+
+		rendered.Machines = append(rendered.Machines, &RenderedMachine{
+			MachineID:     m.MachineID,
+			MachineType:   m.MachineType,
+			NodeIP:        m.NodeIP,
+			TalosImage:    m.TalosImage,
+			ConfigHash:    applier.ConfigHash(final),
+			Configuration: final,
+		})
+
+		if m.MachineType != tmachine.TypeWorker.String() {
+			endpoints = append(endpoints, m.NodeIP)
+		}
+
+		nodes = append(nodes, m.NodeIP)
+	}
+
+	talosconfig, err := talosctl.NewTalosconfig(spec.ClusterName, endpoints, nodes, clientCredentials(secrets))
+	if err != nil {
+		return nil, err
+	}
+
+	rendered.Talosconfig = talosconfig
+
+	return rendered, nil
+}
+
+// clientCredentials returns the admin client credentials of the cluster secrets.
+func clientCredentials(s *ClusterSecrets) *talosctl.ClientCredentials {
+	c := s.ClientConfiguration()
+
+	return &talosctl.ClientCredentials{
+		CACertificate:     c[ClusterResourceOutputsClientConfigurationCAKey],
+		ClientCertificate: c[ClusterResourceOutputsClientConfigurationClientCertificateKey],
+		ClientKey:         c[ClusterResourceOutputsClientConfigurationClientKey],
+	}
+}
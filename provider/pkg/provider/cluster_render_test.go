@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/siderolabs/talos/pkg/machinery/gendata"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderCluster(t *testing.T) {
+	spec := &ClusterSpec{
+		ClusterName:     "dev",
+		ClusterEndpoint: "https://10.0.0.2:6443",
+		ClusterMachines: []*ClusterMachineSpec{
+			{MachineID: "cp-1", MachineType: "init", NodeIP: "10.0.0.2", ConfigPatches: []string{"machine:\n  network:\n    hostname: cp-1\n"}},
+			{MachineID: "w-1", MachineType: "worker", NodeIP: "10.0.0.3"},
+		},
+	}
+
+	// The default contract generates multi-document configurations.
+	s, err := NewClusterSecrets(gendata.VersionTag, "")
+	require.NoError(t, err)
+
+	rendered, err := RenderCluster(spec, s)
+	require.NoError(t, err)
+	require.Len(t, rendered.Machines, 2)
+
+	require.Contains(t, rendered.Machines[0].Configuration, "hostname: cp-1")
+	require.Contains(t, rendered.Machines[0].Configuration, GenerateDefaultInstallerImage())
+	require.Contains(t, rendered.Talosconfig, "- 10.0.0.2")
+
+	again, err := RenderCluster(spec, s)
+	require.NoError(t, err)
+	require.Equal(t, rendered.Machines[1].ConfigHash, again.Machines[1].ConfigHash)
+}
+
+func TestRenderCluster_Invalid(t *testing.T) {
+	s, err := NewClusterSecrets(gendata.VersionTag, "")
+	require.NoError(t, err)
+
+	_, err = RenderCluster(&ClusterSpec{
+		ClusterName:     "dev",
+		ClusterEndpoint: "https://10.0.0.2:6443",
+		ClusterMachines: []*ClusterMachineSpec{
+			{MachineID: "cp-1", MachineType: "init", NodeIP: "10.0.0.2"},
+			{MachineID: "cp-2", MachineType: "init", NodeIP: "10.0.0.3"},
+		},
+	}, s)
+	require.ErrorContains(t, err, "only one init node")
+}
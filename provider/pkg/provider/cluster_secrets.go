@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumiverse/pulumi-talos/sdk/go/talos/machine"
+	"github.com/siderolabs/crypto/x509"
+	"github.com/siderolabs/talos/pkg/machinery/config"
+	"github.com/siderolabs/talos/pkg/machinery/config/generate/secrets"
+	"github.com/siderolabs/talos/pkg/machinery/role"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier"
+	"gopkg.in/yaml.v3"
+)
+
+// ClientCertificateTTL is the lifetime of the admin client certificate in clientConfiguration.
+const ClientCertificateTTL = 87600 * time.Hour
+
+// ClusterSecrets is everything generated once for a cluster and kept in the state.
+type ClusterSecrets struct {
+	// TalosVersion is the version contract the bundle was generated for.
+	TalosVersion string                            `yaml:"talosVersion"`
+	Bundle       *secrets.Bundle                   `yaml:"bundle"`
+	Client       *x509.PEMEncodedCertificateAndKey `yaml:"client"`
+}
+
+// NewClusterSecrets generates a secrets bundle for the version contract and an admin client certificate.
+// If existing is not empty, the bundle is loaded from it instead. It is a bundle in the format of
+// `talosctl gen secrets` or the machineSecrets output of the pulumiverse talos Secrets resource.
+func NewClusterSecrets(talosVersion, existing string) (*ClusterSecrets, error) {
+	contract, err := config.ParseContractFromVersion(talosVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid talos version contract %q: %w", talosVersion, err)
+	}
+
+	var bundle *secrets.Bundle
+
+	if strings.TrimSpace(existing) != "" {
+		bundle, err = ParseSecretsBundle(existing)
+	} else {
+		bundle, err = secrets.NewBundle(secrets.NewClock(), contract)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := secrets.NewAdminCertificateAndKey(time.Now(), bundle.Certs.OS, role.MakeSet(role.Admin), ClientCertificateTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue admin client certificate: %w", err)
+	}
+
+	return &ClusterSecrets{
+		TalosVersion: talosVersion,
+		Bundle:       bundle,
+		Client:       client,
+	}, nil
+}
+
+// ParseSecretsBundle parses a secrets bundle in the format of `talosctl gen secrets`.
+// The machineSecrets format of the pulumiverse talos provider (camelCase keys, cert instead of crt) is accepted as well.
+func ParseSecretsBundle(raw string) (*secrets.Bundle, error) {
+	var m any
+	if err := yaml.Unmarshal([]byte(raw), &m); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets bundle: %w", err)
+	}
+
+	normalized, err := yaml.Marshal(normalizeBundleKeys(m))
+	if err != nil {
+		return nil, err
+	}
+
+	var bundle secrets.Bundle
+	if err := yaml.Unmarshal(normalized, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets bundle: %w", err)
+	}
+
+	if bundle.Cluster == nil || bundle.Secrets == nil || bundle.TrustdInfo == nil || bundle.Certs == nil ||
+		bundle.Certs.OS == nil || bundle.Certs.K8s == nil || bundle.Certs.Etcd == nil ||
+		bundle.Certs.K8sAggregator == nil || bundle.Certs.K8sServiceAccount == nil {
+		return nil, fmt.Errorf("secrets bundle must contain cluster, secrets, trustdinfo and all certs")
+	}
+
+	bundle.Clock = secrets.NewClock()
+
+	return &bundle, nil
+}
+
+// normalizeBundleKeys converts keys to the lowercase form of `talosctl gen secrets`.
+func normalizeBundleKeys(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		res := make(map[string]any, len(v))
+
+		for k, val := range v {
+			k = strings.ToLower(k)
+			if k == "cert" {
+				k = "crt"
+			}
+
+			res[k] = normalizeBundleKeys(val)
+		}
+
+		return res
+	case []any:
+		for i := range v {
+			v[i] = normalizeBundleKeys(v[i])
+		}
+	}
+
+	return v
+}
+
+// ParseClusterSecrets parses secrets stored by the Cluster component.
+func ParseClusterSecrets(raw string) (*ClusterSecrets, error) {
+	var s ClusterSecrets
+	if err := yaml.Unmarshal([]byte(raw), &s); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster secrets: %w", err)
+	}
+
+	if s.Bundle == nil || s.Client == nil {
+		return nil, fmt.Errorf("cluster secrets are incomplete")
+	}
+
+	s.Bundle.Clock = secrets.NewClock()
+
+	return &s, nil
+}
+
+// ClientConfiguration returns credentials of the admin client in the clientConfiguration format.
+func (s *ClusterSecrets) ClientConfiguration() map[string]string {
+	return map[string]string{
+		ClusterResourceOutputsClientConfigurationCAKey:                base64.StdEncoding.EncodeToString(s.Bundle.Certs.OS.Crt),
+		ClusterResourceOutputsClientConfigurationClientKey:            base64.StdEncoding.EncodeToString(s.Client.Key),
+		ClusterResourceOutputsClientConfigurationClientCertificateKey: base64.StdEncoding.EncodeToString(s.Client.Crt),
+	}
+}
+
+// clusterSecrets keeps the secrets of the cluster. New stacks get a bundle generated in-process.
+// Stacks created with older versions have the pulumiverse Secrets resource. A component can not look into the state,
+// so the resource is registered and its bundle is used only in a migration mode, which is set for the first run
+// after the upgrade. The secrets are kept since then and the resource is dropped when the mode is unset.
+func clusterSecrets(ctx *pulumi.Context, migrationMode, name string, talosVersion, existing pulumi.StringInput, opts ...pulumi.ResourceOption) (pulumi.StringOutput, error) {
+	legacy := pulumi.String("").ToStringOutput()
+
+	if migrationMode != applier.MigrationModeNone {
+		var err error
+
+		legacy, err = legacyClusterSecrets(ctx, name, talosVersion, opts...)
+		if err != nil {
+			return pulumi.StringOutput{}, err
+		}
+
+		// The import mode takes the old state as is, including the bundle of the Secrets resource.
+		if migrationMode == applier.MigrationModeImport {
+			existing = pulumi.String("")
+		}
+	}
+
+	return keepClusterSecrets(ctx, name, talosVersion, existing, legacy, opts...)
+}
+
+// legacySecretsName is the name of the pulumiverse Secrets resource which stacks created with older versions have.
+func legacySecretsName(name string) string {
+	return fmt.Sprintf("%s:secrets", name)
+}
+
+// legacyClusterSecrets registers the pulumiverse Secrets resource like older versions did, so existing stacks
+// keep it unchanged, and returns its bundle in the machineSecrets format.
+func legacyClusterSecrets(ctx *pulumi.Context, name string, talosVersion pulumi.StringInput, opts ...pulumi.ResourceOption) (pulumi.StringOutput, error) {
+	legacy, err := machine.NewSecrets(ctx, legacySecretsName(name), &machine.SecretsArgs{
+		TalosVersion: talosVersion,
+	}, append(opts, pulumi.IgnoreChanges([]string{"talosVersion"}))...)
+	if err != nil {
+		return pulumi.StringOutput{}, err
+	}
+
+	return legacy.MachineSecrets.ApplyT(func(s machine.MachineSecrets) (string, error) {
+		out, err := yaml.Marshal(s)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode machine secrets of %s: %w", legacySecretsName(name), err)
+		}
+
+		return string(out), nil
+	}).(pulumi.StringOutput), nil
+}
+
+// keepClusterSecrets builds cluster secrets and keeps the first built ones in the state.
+// The bundle is loaded from existing if it is set, from the legacy bundle of the pulumiverse Secrets resource
+// if it is set, and it is generated otherwise.
+// The stored secrets never change, like the talosVersion of the pulumiverse Secrets resource which was ignored.
+func keepClusterSecrets(ctx *pulumi.Context, name string, talosVersion, existing, legacy pulumi.StringInput, opts ...pulumi.ResourceOption) (pulumi.StringOutput, error) {
+	generated := pulumi.All(talosVersion, existing, legacy).ApplyT(func(v []any) (string, error) {
+		bundle := v[1].(string)
+		if strings.TrimSpace(bundle) == "" {
+			bundle = v[2].(string)
+		}
+
+		s, err := NewClusterSecrets(v[0].(string), bundle)
+		if err != nil {
+			return "", err
+		}
+
+		out, err := yaml.Marshal(s)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode cluster secrets: %w", err)
+		}
+
+		return string(out), nil
+	}).(pulumi.StringOutput)
+
+	return applier.KeepSecret(ctx, fmt.Sprintf("%s:machine-secrets", name), generated, nil, opts...)
+}
+
+// parseKeptClusterSecrets parses secrets stored by keepClusterSecrets.
+func parseKeptClusterSecrets(stored pulumi.StringOutput) pulumi.AnyOutput {
+	return stored.ApplyT(func(raw string) (*ClusterSecrets, error) {
+		return ParseClusterSecrets(raw)
+	}).(pulumi.AnyOutput)
+}
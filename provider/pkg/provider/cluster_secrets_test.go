@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"encoding/base64"
+	"sync"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumiverse/pulumi-talos/sdk/go/talos/machine"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier"
+)
+
+// typesMocks records types of registered resources.
+type typesMocks struct {
+	mu    sync.Mutex
+	types []string
+}
+
+func (m *typesMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.types = append(m.types, args.TypeToken)
+
+	return args.Name + "-id", args.Inputs, nil
+}
+
+func (m *typesMocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	return args.Args, nil
+}
+
+func TestClusterSecrets_StoredRoundTrip(t *testing.T) {
+	s, err := NewClusterSecrets("v1.11.0", "")
+	require.NoError(t, err)
+
+	raw, err := yaml.Marshal(s)
+	require.NoError(t, err)
+
+	parsed, err := ParseClusterSecrets(string(raw))
+	require.NoError(t, err)
+
+	require.Equal(t, "v1.11.0", parsed.TalosVersion)
+	require.Equal(t, s.Bundle.Cluster.ID, parsed.Bundle.Cluster.ID)
+	require.Equal(t, s.Bundle.Certs.OS.Crt, parsed.Bundle.Certs.OS.Crt)
+	require.Equal(t, s.Client.Key, parsed.Client.Key)
+
+	client := parsed.ClientConfiguration()
+	require.Equal(t, base64.StdEncoding.EncodeToString(s.Bundle.Certs.OS.Crt), client[ClusterResourceOutputsClientConfigurationCAKey])
+}
+
+func TestClusterSecrets_PulumiverseFormat(t *testing.T) {
+	s, err := NewClusterSecrets("v1.11.0", "")
+	require.NoError(t, err)
+
+	b := s.Bundle
+	enc := base64.StdEncoding.EncodeToString
+	cert := func(crt, key []byte) map[string]string {
+		return map[string]string{"cert": enc(crt), "key": enc(key)}
+	}
+
+	// The machineSecrets output of the pulumiverse Secrets resource.
+	old, err := yaml.Marshal(map[string]any{
+		"cluster": map[string]string{"id": b.Cluster.ID, "secret": b.Cluster.Secret},
+		"secrets": map[string]string{
+			"bootstrapToken":            b.Secrets.BootstrapToken,
+			"secretboxEncryptionSecret": b.Secrets.SecretboxEncryptionSecret,
+		},
+		"trustdinfo": map[string]string{"token": b.TrustdInfo.Token},
+		"certs": map[string]any{
+			"etcd":              cert(b.Certs.Etcd.Crt, b.Certs.Etcd.Key),
+			"k8s":               cert(b.Certs.K8s.Crt, b.Certs.K8s.Key),
+			"k8sAggregator":     cert(b.Certs.K8sAggregator.Crt, b.Certs.K8sAggregator.Key),
+			"k8sServiceaccount": map[string]string{"key": enc(b.Certs.K8sServiceAccount.Key)},
+			"os":                cert(b.Certs.OS.Crt, b.Certs.OS.Key),
+		},
+	})
+	require.NoError(t, err)
+
+	migrated, err := NewClusterSecrets("v1.11.0", string(old))
+	require.NoError(t, err)
+
+	require.Equal(t, s.Bundle.Cluster, migrated.Bundle.Cluster)
+	require.Equal(t, s.Bundle.Secrets, migrated.Bundle.Secrets)
+	require.Equal(t, s.Bundle.Certs.OS.Key, migrated.Bundle.Certs.OS.Key)
+	require.Equal(t, s.Bundle.Certs.K8sServiceAccount.Key, migrated.Bundle.Certs.K8sServiceAccount.Key)
+}
+
+func TestClusterSecrets_LegacyResource(t *testing.T) {
+	s, err := NewClusterSecrets("v1.11.0", "")
+	require.NoError(t, err)
+
+	b := s.Bundle
+	enc := base64.StdEncoding.EncodeToString
+	cert := func(crt, key []byte) machine.Certificate {
+		return machine.Certificate{Cert: enc(crt), Key: enc(key)}
+	}
+
+	// The output of the pulumiverse Secrets resource is encoded like legacyClusterSecrets does.
+	old, err := yaml.Marshal(machine.MachineSecrets{
+		Cluster: machine.Cluster{Id: b.Cluster.ID, Secret: b.Cluster.Secret},
+		Secrets: machine.KubernetesSecrets{
+			BootstrapToken:            b.Secrets.BootstrapToken,
+			SecretboxEncryptionSecret: b.Secrets.SecretboxEncryptionSecret,
+		},
+		Trustdinfo: machine.TrustdInfo{Token: b.TrustdInfo.Token},
+		Certs: machine.Certificates{
+			Etcd:              cert(b.Certs.Etcd.Crt, b.Certs.Etcd.Key),
+			K8s:               cert(b.Certs.K8s.Crt, b.Certs.K8s.Key),
+			K8sAggregator:     cert(b.Certs.K8sAggregator.Crt, b.Certs.K8sAggregator.Key),
+			K8sServiceaccount: machine.Key{Key: enc(b.Certs.K8sServiceAccount.Key)},
+			Os:                cert(b.Certs.OS.Crt, b.Certs.OS.Key),
+		},
+	})
+	require.NoError(t, err)
+
+	migrated, err := NewClusterSecrets("v1.11.0", string(old))
+	require.NoError(t, err)
+	require.Equal(t, s.Bundle.Cluster, migrated.Bundle.Cluster)
+	require.Equal(t, s.Bundle.Secrets, migrated.Bundle.Secrets)
+	require.Equal(t, s.Bundle.Certs.OS, migrated.Bundle.Certs.OS)
+	require.Equal(t, s.Bundle.Certs.K8sAggregator, migrated.Bundle.Certs.K8sAggregator)
+	require.Equal(t, s.Bundle.Certs.K8sServiceAccount.Key, migrated.Bundle.Certs.K8sServiceAccount.Key)
+}
+
+func TestClusterSecrets_Invalid(t *testing.T) {
+	_, err := NewClusterSecrets("v1.11.0", "cluster:\n  id: foo\n")
+	require.Error(t, err)
+
+	_, err = NewClusterSecrets("not-a-version", "")
+	require.Error(t, err)
+}
+
+func TestClusterSecrets_LegacyResourceOnlyForMigrations(t *testing.T) {
+	old, err := NewClusterSecrets("v1.11.0", "")
+	require.NoError(t, err)
+
+	existing, err := yaml.Marshal(old.Bundle)
+	require.NoError(t, err)
+
+	registered := func(mode string, existing string) []string {
+		mocks := &typesMocks{}
+		err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+			_, err := clusterSecrets(ctx, mode, "dev", pulumi.String("v1.11.0"), pulumi.String(existing))
+			return err
+		}, pulumi.WithMocks("project", "stack", mocks))
+		require.NoError(t, err)
+
+		return mocks.types
+	}
+
+	fresh := registered(applier.MigrationModeNone, "")
+	require.NotContains(t, fresh, "talos:machine/secrets:Secrets")
+	require.Contains(t, fresh, "command:local:Command")
+
+	require.Contains(t, registered(applier.MigrationModeAliases, string(existing)), "talos:machine/secrets:Secrets")
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/mapper"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/status"
+)
+
+const (
+	GetClusterStatusNodesKey = "nodes"
+)
+
+func GetClusterStatusType() string {
+	return ProviderName + ":index:getClusterStatus"
+}
+
+type GetClusterStatusArgs struct {
+	ClientConfiguration talosctl.ClientCredentials `pulumi:"clientConfiguration"`
+	Nodes               []string                   `pulumi:"nodes"`
+}
+
+type GetClusterStatusResult struct {
+	Nodes []*status.NodeStatus `pulumi:"nodes"`
+}
+
+func getClusterStatus(logger pulumi.Log, config *Config, inputs resource.PropertyMap) (resource.PropertyMap, error) {
+	var args GetClusterStatusArgs
+	if err := mapper.MapIU(inputs.Mappable(), &args); err != nil {
+		return nil, errors.Wrap(err, "setting args")
+	}
+
+	workDirs, err := config.workDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := status.Collect(&args.ClientConfiguration, args.Nodes, config.Talosctl.Default.Path, workDirs, logger)
+
+	result, err := mapper.Unmap(&GetClusterStatusResult{Nodes: nodes})
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding result")
+	}
+
+	return resource.NewPropertyMapFromMap(result), nil
+}
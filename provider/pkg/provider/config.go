@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+)
+
+const (
+	ConfigTalosctlPath         = "talosctlPath"
+	ConfigTalosctlVersionCheck = "talosctlVersionCheck"
+	ConfigWorkDir              = "workDir"
+	ConfigMigrationMode        = "migrationMode"
+	ConfigDebugBundle          = "debugBundle"
+	ConfigDebugBundleDir       = "debugBundleDir"
+)
+
+// Config is the provider-level configuration.
+type Config struct {
+	// TalosctlPath is a talosctl binary or a directory of talosctl binaries for different Talos versions.
+	TalosctlPath string
+	// TalosctlVersionCheck is the reaction on skew between talosctl and the Talos version of a node.
+	TalosctlVersionCheck string
+	// WorkDir is the root of private working directories for talosctl. Empty means the system temporary directory.
+	WorkDir string
+	// MigrationMode is how stacks created with pulumiverse resources are migrated. Empty means no migration.
+	MigrationMode string
+	// DebugBundle is what is collected from a node when an upgrade or apply-config fails.
+	DebugBundle string
+	// DebugBundleDir is the root of debug bundles. Empty means the debug directory inside the work dir.
+	DebugBundleDir string
+
+	Talosctl *talosctl.Binaries
+	WorkDirs *talosctl.WorkDirs
+}
+
+// DefaultConfig returns the configuration used when the provider is not configured.
+// WorkDirs is left empty until the first use, see Config.workDirs.
+func DefaultConfig() *Config {
+	return &Config{
+		TalosctlVersionCheck: talosctl.VersionCheckWarn,
+		DebugBundle:          applier.DebugBundleBasic,
+		Talosctl: &talosctl.Binaries{
+			Default: &talosctl.Binary{Path: "talosctl"},
+		},
+	}
+}
+
+// NewConfig parses provider configuration and discovers talosctl binaries.
+// Keys are accepted both as plain names and as `talos-cluster:config:<name>`.
+func NewConfig(args resource.PropertyMap, logger pulumi.Log) (*Config, error) {
+	c := DefaultConfig()
+
+	for k, v := range args {
+		if !v.IsString() {
+			continue
+		}
+
+		switch strings.TrimPrefix(string(k), ProviderName+":config:") {
+		case ConfigTalosctlPath:
+			c.TalosctlPath = v.StringValue()
+		case ConfigTalosctlVersionCheck:
+			c.TalosctlVersionCheck = v.StringValue()
+		case ConfigWorkDir:
+			c.WorkDir = v.StringValue()
+		case ConfigMigrationMode:
+			c.MigrationMode = v.StringValue()
+		case ConfigDebugBundle:
+			c.DebugBundle = v.StringValue()
+		case ConfigDebugBundleDir:
+			c.DebugBundleDir = v.StringValue()
+		}
+	}
+
+	workDirs, err := talosctl.NewWorkDirs(c.WorkDir)
+	if err != nil {
+		return nil, err
+	}
+
+	c.WorkDirs = workDirs
+
+	if !slices.Contains(talosctl.VersionChecks, c.TalosctlVersionCheck) {
+		return nil, fmt.Errorf("unknown %s %q, supported: %s",
+			ConfigTalosctlVersionCheck, c.TalosctlVersionCheck, strings.Join(talosctl.VersionChecks, ", "))
+	}
+
+	if c.MigrationMode != applier.MigrationModeNone && !slices.Contains(applier.MigrationModes, c.MigrationMode) {
+		return nil, fmt.Errorf("unknown %s %q, supported: %s",
+			ConfigMigrationMode, c.MigrationMode, strings.Join(applier.MigrationModes, ", "))
+	}
+
+	if !slices.Contains(applier.DebugBundleModes, c.DebugBundle) {
+		return nil, fmt.Errorf("unknown %s %q, supported: %s",
+			ConfigDebugBundle, c.DebugBundle, strings.Join(applier.DebugBundleModes, ", "))
+	}
+
+	bins, err := talosctl.DiscoverBinaries(c.TalosctlPath)
+	if err != nil {
+		if c.TalosctlVersionCheck == talosctl.VersionCheckFail {
+			return nil, fmt.Errorf("talosctl check failed: %w", err)
+		}
+
+		// Keep going with the configured binary, commands fail later if it is really missing.
+		if c.TalosctlPath != "" {
+			c.Talosctl.Default.Path = c.TalosctlPath
+		}
+
+		if c.TalosctlVersionCheck == talosctl.VersionCheckWarn {
+			_ = logger.Warn(fmt.Sprintf("talos-cluster: talosctl version is unknown: %s", err), nil)
+		}
+	} else {
+		c.Talosctl = bins
+
+		for _, err := range bins.Skipped {
+			_ = logger.Warn(fmt.Sprintf("talos-cluster: skipping talosctl binary: %s", err), nil)
+		}
+	}
+
+	for _, b := range c.Talosctl.Versions {
+		_ = logger.Debug(fmt.Sprintf("talos-cluster: found talosctl %s at %s", b.Version, b.Path), nil)
+	}
+
+	return c, nil
+}
+
+// workDirs returns the configured working directories or creates them in the system temporary directory.
+func (c *Config) workDirs() (*talosctl.WorkDirs, error) {
+	if c.WorkDirs != nil {
+		return c.WorkDirs, nil
+	}
+
+	workDirs, err := talosctl.NewWorkDirs(c.WorkDir)
+	if err != nil {
+		return nil, err
+	}
+
+	c.WorkDirs = workDirs
+
+	return workDirs, nil
+}
@@ -0,0 +1,92 @@
+package kubeconfig
+
+import (
+	stdx509 "crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/siderolabs/crypto/x509"
+	"github.com/siderolabs/talos/pkg/machinery/config/configloader"
+	"gopkg.in/yaml.v3"
+)
+
+// GenerateAdmin returns the admin kubeconfig of a cluster without a request to its nodes.
+// The client certificate is signed by the Kubernetes CA from a controlplane machine configuration.
+// The content is the same as `talosctl kubeconfig` returns: the server is the cluster endpoint,
+// the context and the user are admin@<cluster name>.
+// A zero lifetime means cluster.adminKubeconfig.certLifetime of the configuration.
+func GenerateAdmin(configuration string, lifetime time.Duration, now time.Time) (string, error) {
+	cfg, err := configloader.NewFromBytes([]byte(configuration))
+	if err != nil {
+		return "", fmt.Errorf("failed to load machine configuration: %w", err)
+	}
+
+	cluster := cfg.Cluster()
+	if cluster == nil || cluster.Endpoint() == nil {
+		return "", fmt.Errorf("machine configuration has no cluster endpoint")
+	}
+
+	ca := cluster.IssuingCA()
+	if ca == nil || len(ca.Crt) == 0 || len(ca.Key) == 0 {
+		return "", fmt.Errorf("machine configuration has no Kubernetes CA with a key, it must be a controlplane configuration")
+	}
+
+	admin := cluster.AdminKubeconfig()
+	if lifetime == 0 {
+		lifetime = admin.CertLifetime()
+	}
+
+	k8sCA, err := x509.NewCertificateAuthorityFromCertificateAndKey(ca)
+	if err != nil {
+		return "", fmt.Errorf("failed to load Kubernetes CA: %w", err)
+	}
+
+	keyPair, err := x509.NewKeyPair(k8sCA,
+		x509.CommonName(admin.CommonName()),
+		x509.Organization(admin.CertOrganization()),
+		x509.NotBefore(now),
+		x509.NotAfter(now.Add(lifetime)),
+		x509.KeyUsage(stdx509.KeyUsageDigitalSignature|stdx509.KeyUsageKeyEncipherment),
+		x509.ExtKeyUsage([]stdx509.ExtKeyUsage{stdx509.ExtKeyUsageClientAuth}),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to issue admin certificate: %w", err)
+	}
+
+	cert := x509.NewCertificateAndKeyFromKeyPair(keyPair)
+	user := fmt.Sprintf("%s@%s", admin.CommonName(), cluster.Name())
+
+	out, err := yaml.Marshal(&Config{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Clusters: []NamedCluster{{
+			Name: cluster.Name(),
+			Cluster: map[string]any{
+				"server":                     cluster.Endpoint().String(),
+				"certificate-authority-data": base64.StdEncoding.EncodeToString(ca.Crt),
+			},
+		}},
+		Contexts: []NamedContext{{
+			Name: user,
+			Context: map[string]any{
+				"cluster":   cluster.Name(),
+				"namespace": "default",
+				"user":      user,
+			},
+		}},
+		CurrentContext: user,
+		Users: []NamedUser{{
+			Name: user,
+			User: map[string]any{
+				"client-certificate-data": base64.StdEncoding.EncodeToString(cert.Crt),
+				"client-key-data":         base64.StdEncoding.EncodeToString(cert.Key),
+			},
+		}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode kubeconfig: %w", err)
+	}
+
+	return string(out), nil
+}
@@ -0,0 +1,74 @@
+package kubeconfig
+
+import (
+	stdx509 "crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/siderolabs/talos/pkg/machinery/config/generate"
+	"github.com/siderolabs/talos/pkg/machinery/config/machine"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func controlplaneConfig(t *testing.T, typ machine.Type) string {
+	t.Helper()
+
+	in, err := generate.NewInput("dev", "https://10.0.0.2:6443", "1.33.0")
+	require.NoError(t, err)
+
+	cfg, err := in.Config(typ)
+	require.NoError(t, err)
+
+	out, err := cfg.EncodeString()
+	require.NoError(t, err)
+
+	return out
+}
+
+func TestGenerateAdmin(t *testing.T) {
+	now := time.Now()
+
+	raw, err := GenerateAdmin(controlplaneConfig(t, machine.TypeControlPlane), 24*time.Hour, now)
+	require.NoError(t, err)
+
+	var c Config
+	require.NoError(t, yaml.Unmarshal([]byte(raw), &c))
+
+	require.Equal(t, "dev", c.Clusters[0].Name)
+	require.Equal(t, "https://10.0.0.2:6443", c.Clusters[0].Cluster["server"])
+	require.Equal(t, "admin@dev", c.CurrentContext)
+	require.Equal(t, "admin@dev", c.Users[0].Name)
+
+	ca, err := base64.StdEncoding.DecodeString(c.Clusters[0].Cluster["certificate-authority-data"].(string))
+	require.NoError(t, err)
+
+	crt, err := base64.StdEncoding.DecodeString(c.Users[0].User["client-certificate-data"].(string))
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(crt)
+	require.NotNil(t, block)
+
+	cert, err := stdx509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	require.Equal(t, "admin", cert.Subject.CommonName)
+	require.Equal(t, []string{"system:masters"}, cert.Subject.Organization)
+	require.WithinDuration(t, now.Add(24*time.Hour), cert.NotAfter, time.Minute)
+
+	pool := stdx509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(ca))
+
+	_, err = cert.Verify(stdx509.VerifyOptions{Roots: pool, KeyUsages: []stdx509.ExtKeyUsage{stdx509.ExtKeyUsageClientAuth}})
+	require.NoError(t, err)
+
+	expiry, err := CertificateExpiry(raw)
+	require.NoError(t, err)
+	require.True(t, cert.NotAfter.Equal(expiry))
+}
+
+func TestGenerateAdmin_Worker(t *testing.T) {
+	_, err := GenerateAdmin(controlplaneConfig(t, machine.TypeWorker), 0, time.Now())
+	require.ErrorContains(t, err, "must be a controlplane configuration")
+}
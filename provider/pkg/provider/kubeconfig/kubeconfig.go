@@ -0,0 +1,166 @@
+// Package kubeconfig customizes admin kubeconfigs of a cluster.
+package kubeconfig
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	OptionServer       = "server"
+	OptionClusterName  = "clusterName"
+	OptionContextName  = "contextName"
+	OptionUserName     = "userName"
+	OptionCertLifetime = "certLifetime"
+)
+
+// Options overrides fields of a kubeconfig. Empty fields keep the original values.
+type Options struct {
+	Server      string
+	ClusterName string
+	ContextName string
+	UserName    string
+}
+
+// NewOptions returns options from the `kubeconfig` input of the Apply component.
+func NewOptions(opts map[string]string) Options {
+	return Options{
+		Server:      opts[OptionServer],
+		ClusterName: opts[OptionClusterName],
+		ContextName: opts[OptionContextName],
+		UserName:    opts[OptionUserName],
+	}
+}
+
+// Config is the part of a kubeconfig with names and credentials.
+// Unknown fields are kept as is.
+type Config struct {
+	APIVersion     string         `yaml:"apiVersion"`
+	Kind           string         `yaml:"kind"`
+	Clusters       []NamedCluster `yaml:"clusters"`
+	Contexts       []NamedContext `yaml:"contexts"`
+	CurrentContext string         `yaml:"current-context"`
+	Users          []NamedUser    `yaml:"users"`
+	Rest           map[string]any `yaml:",inline"`
+}
+
+type NamedCluster struct {
+	Name    string         `yaml:"name"`
+	Cluster map[string]any `yaml:"cluster"`
+}
+
+type NamedContext struct {
+	Name    string         `yaml:"name"`
+	Context map[string]any `yaml:"context"`
+}
+
+type NamedUser struct {
+	Name string         `yaml:"name"`
+	User map[string]any `yaml:"user"`
+}
+
+// Rewrite applies options to a kubeconfig with a single cluster, context and user, like the one generated by Talos.
+func Rewrite(raw string, o Options) (string, error) {
+	var c Config
+	if err := yaml.Unmarshal([]byte(raw), &c); err != nil {
+		return "", fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	if len(c.Clusters) != 1 || len(c.Contexts) != 1 || len(c.Users) != 1 {
+		return "", fmt.Errorf("expected a kubeconfig with one cluster, context and user, got %d, %d and %d",
+			len(c.Clusters), len(c.Contexts), len(c.Users))
+	}
+
+	if c.Clusters[0].Cluster == nil {
+		c.Clusters[0].Cluster = map[string]any{}
+	}
+
+	if c.Contexts[0].Context == nil {
+		c.Contexts[0].Context = map[string]any{}
+	}
+
+	if o.Server != "" {
+		c.Clusters[0].Cluster["server"] = o.Server
+	}
+
+	if o.ClusterName != "" {
+		c.Clusters[0].Name = o.ClusterName
+		c.Contexts[0].Context["cluster"] = o.ClusterName
+	}
+
+	if o.UserName != "" {
+		c.Users[0].Name = o.UserName
+		c.Contexts[0].Context["user"] = o.UserName
+	}
+
+	if o.ContextName != "" {
+		c.Contexts[0].Name = o.ContextName
+		c.CurrentContext = o.ContextName
+	}
+
+	out, err := yaml.Marshal(&c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode kubeconfig: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// CertificateExpiry returns the expiry time of the client certificate of the first user.
+func CertificateExpiry(raw string) (time.Time, error) {
+	var c Config
+	if err := yaml.Unmarshal([]byte(raw), &c); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	if len(c.Users) == 0 {
+		return time.Time{}, fmt.Errorf("kubeconfig has no users")
+	}
+
+	data, _ := c.Users[0].User["client-certificate-data"].(string)
+	if data == "" {
+		return time.Time{}, fmt.Errorf("user %s has no client-certificate-data", c.Users[0].Name)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode client certificate: %w", err)
+	}
+
+	block, _ := pem.Decode(decoded)
+	if block == nil {
+		return time.Time{}, fmt.Errorf("client certificate is not PEM encoded")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse client certificate: %w", err)
+	}
+
+	return cert.NotAfter, nil
+}
+
+// ClusterFingerprint returns a hash of the cluster entries of a kubeconfig: their names, servers and CAs.
+// It changes only if the cluster itself is changed, not the client certificate.
+func ClusterFingerprint(raw string) (string, error) {
+	var c Config
+	if err := yaml.Unmarshal([]byte(raw), &c); err != nil {
+		return "", fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	b, err := yaml.Marshal(c.Clusters)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:]), nil
+}
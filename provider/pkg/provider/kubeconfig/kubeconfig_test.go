@@ -0,0 +1,98 @@
+package kubeconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func testKubeconfig(t *testing.T, notAfter time.Time) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "admin"},
+		NotBefore:    notAfter.Add(-time.Hour),
+		NotAfter:     notAfter,
+	}, &x509.Certificate{SerialNumber: big.NewInt(1)}, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	return fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+  - name: dev
+    cluster:
+      server: https://10.0.0.2:6443
+      certificate-authority-data: Y2E=
+contexts:
+  - name: admin@dev
+    context:
+      cluster: dev
+      namespace: default
+      user: admin@dev
+current-context: admin@dev
+users:
+  - name: admin@dev
+    user:
+      client-certificate-data: %s
+      client-key-data: a2V5
+`, cert)
+}
+
+func TestRewrite(t *testing.T) {
+	raw := testKubeconfig(t, time.Now().Add(time.Hour))
+
+	out, err := Rewrite(raw, Options{
+		Server:      "https://lb.example.com:6443",
+		ClusterName: "prod",
+		ContextName: "prod-admin",
+		UserName:    "prod-admin",
+	})
+	require.NoError(t, err)
+
+	var c Config
+	require.NoError(t, yaml.Unmarshal([]byte(out), &c))
+
+	require.Equal(t, "prod", c.Clusters[0].Name)
+	require.Equal(t, "https://lb.example.com:6443", c.Clusters[0].Cluster["server"])
+	require.Equal(t, "Y2E=", c.Clusters[0].Cluster["certificate-authority-data"])
+	require.Equal(t, "prod-admin", c.Contexts[0].Name)
+	require.Equal(t, "prod", c.Contexts[0].Context["cluster"])
+	require.Equal(t, "prod-admin", c.Contexts[0].Context["user"])
+	require.Equal(t, "default", c.Contexts[0].Context["namespace"])
+	require.Equal(t, "prod-admin", c.CurrentContext)
+	require.Equal(t, "prod-admin", c.Users[0].Name)
+	require.Equal(t, "a2V5", c.Users[0].User["client-key-data"])
+}
+
+func TestRewrite_NoOptions(t *testing.T) {
+	raw := testKubeconfig(t, time.Now().Add(time.Hour))
+
+	out, err := Rewrite(raw, Options{})
+	require.NoError(t, err)
+	require.YAMLEq(t, raw, out)
+}
+
+func TestCertificateExpiry(t *testing.T) {
+	notAfter := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	expiry, err := CertificateExpiry(testKubeconfig(t, notAfter))
+	require.NoError(t, err)
+	require.True(t, notAfter.Equal(expiry))
+}
@@ -1,11 +1,15 @@
 package provider
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/v3/resource/provider"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	pp "github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 )
 
 const (
@@ -14,22 +18,45 @@ const (
 
 // Serve launches the gRPC server for the resource provider.
 func Serve(version string, schema []byte) {
-	if err := provider.ComponentMain(ProviderName, version, schema, Construct); err != nil {
+	if err := provider.Main(ProviderName, func(host *provider.HostClient) (pulumirpc.ResourceProviderServer, error) {
+		return newServer(host, version, schema), nil
+	}); err != nil {
 		cmdutil.ExitError(err.Error())
 	}
 }
 
 // Construct is the RPC call that initiates the creation of a new component resource. It
 // creates, registers, and returns the resulting object.
-func Construct(ctx *pulumi.Context, typ, name string, inputs pp.ConstructInputs,
+func Construct(ctx *pulumi.Context, config *Config, typ, name string, inputs pp.ConstructInputs,
 	opts pulumi.ResourceOption,
 ) (*pp.ConstructResult, error) {
 	switch typ {
 	case ClusterType():
-		return cluster(ctx, &Cluster{}, name, &ClusterArgs{}, inputs, opts)
+		return cluster(ctx, config, &Cluster{}, name, &ClusterArgs{}, inputs, opts)
 	case ApplyType():
-		return apply(ctx, &Apply{}, name, &ApplyArgs{}, inputs, opts)
+		return apply(ctx, config, &Apply{}, name, &ApplyArgs{}, inputs, opts)
 	default:
 		return nil, errors.Errorf("unknown resource type %s", typ)
 	}
 }
+
+// Call is the RPC call that executes a method of a component resource.
+func Call(tok string, args pp.CallArgs) (*pp.CallResult, error) {
+	if method, ok := strings.CutPrefix(tok, ClusterType()+"/"); ok {
+		return callCluster(method, args)
+	}
+
+	return nil, errors.Errorf("unknown method %s", tok)
+}
+
+// Invoke is the RPC call that executes a provider function and returns its result.
+func Invoke(logger pulumi.Log, config *Config, tok string, args resource.PropertyMap) (resource.PropertyMap, error) {
+	switch tok {
+	case GetClusterStatusType():
+		return getClusterStatus(logger, config, args)
+	case RenderMachineConfigType():
+		return renderMachineConfig(args)
+	default:
+		return nil, errors.Errorf("unknown function %s", tok)
+	}
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/mapper"
+
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier"
+)
+
+const (
+	RenderMachineConfigCurrentConfigurationKey = "currentConfiguration"
+	RenderMachineConfigMachineConfigurationKey = "machineConfiguration"
+)
+
+func RenderMachineConfigType() string {
+	return ProviderName + ":index:renderMachineConfig"
+}
+
+type RenderMachineConfigArgs struct {
+	Configuration        string `pulumi:"configuration"`
+	UserConfigPatches    string `pulumi:"userConfigPatches,optional"`
+	CurrentConfiguration string `pulumi:"currentConfiguration,optional"`
+}
+
+func renderMachineConfig(inputs resource.PropertyMap) (resource.PropertyMap, error) {
+	var args RenderMachineConfigArgs
+	if err := mapper.MapIU(inputs.Mappable(), &args); err != nil {
+		return nil, errors.Wrap(err, "setting args")
+	}
+
+	rendered, err := applier.RenderConfig(args.Configuration, args.UserConfigPatches, args.CurrentConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	// The configuration contains cluster secrets.
+	return resource.PropertyMap{
+		RenderMachineConfigMachineConfigurationKey: resource.MakeSecret(resource.NewStringProperty(rendered)),
+	}, nil
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/provider"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	pp "github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// server is the gRPC resource provider.
+// It mirrors the component provider from the Pulumi SDK and additionally serves provider functions.
+type server struct {
+	pulumirpc.UnimplementedResourceProviderServer
+
+	host    *provider.HostClient
+	version string
+	schema  []byte
+	config  *Config
+}
+
+func newServer(host *provider.HostClient, version string, schema []byte) *server {
+	return &server{
+		host:    host,
+		version: version,
+		schema:  schema,
+		config:  DefaultConfig(),
+	}
+}
+
+// GetPluginInfo returns generic information about this plugin, like its version.
+func (s *server) GetPluginInfo(context.Context, *emptypb.Empty) (*pulumirpc.PluginInfo, error) {
+	return &pulumirpc.PluginInfo{
+		Version: s.version,
+	}, nil
+}
+
+// GetSchema returns the JSON-encoded schema for this provider's package.
+func (s *server) GetSchema(_ context.Context, req *pulumirpc.GetSchemaRequest) (*pulumirpc.GetSchemaResponse, error) {
+	if v := req.GetVersion(); v != 0 {
+		return nil, fmt.Errorf("unsupported schema version %d", v)
+	}
+
+	schema := string(s.schema)
+	if schema == "" {
+		schema = "{}"
+	}
+
+	return &pulumirpc.GetSchemaResponse{Schema: schema}, nil
+}
+
+// Configure configures the resource provider with "globals" that control its behavior.
+func (s *server) Configure(ctx context.Context, req *pulumirpc.ConfigureRequest) (*pulumirpc.ConfigureResponse, error) {
+	var args resource.PropertyMap
+
+	if req.GetArgs() != nil {
+		var err error
+
+		args, err = plugin.UnmarshalProperties(req.GetArgs(), plugin.MarshalOptions{
+			Label:     "config",
+			SkipNulls: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Older engines send only variables.
+		args = resource.NewPropertyMapFromMap(toAnyMap(req.GetVariables()))
+	}
+
+	config, err := NewConfig(args, &hostLog{ctx: ctx, host: s.host})
+	if err != nil {
+		return nil, err
+	}
+
+	s.config = config
+
+	return &pulumirpc.ConfigureResponse{
+		AcceptSecrets:   true,
+		SupportsPreview: true,
+		AcceptResources: true,
+		AcceptOutputs:   true,
+	}, nil
+}
+
+// Construct creates a new instance of the provided component resource and returns its state.
+func (s *server) Construct(ctx context.Context, req *pulumirpc.ConstructRequest) (*pulumirpc.ConstructResponse, error) {
+	return pp.Construct(ctx, req, s.host.EngineConn(), func(ctx *pulumi.Context, typ, name string,
+		inputs pp.ConstructInputs, opts pulumi.ResourceOption,
+	) (*pp.ConstructResult, error) {
+		return Construct(ctx, s.config, typ, name, inputs, opts)
+	})
+}
+
+// Invoke dynamically executes a provider function.
+func (s *server) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest) (*pulumirpc.InvokeResponse, error) {
+	args, err := plugin.UnmarshalProperties(req.GetArgs(), plugin.MarshalOptions{
+		Label:     fmt.Sprintf("%s.args", req.GetTok()),
+		SkipNulls: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := Invoke(&hostLog{ctx: ctx, host: s.host}, s.config, req.GetTok(), args)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := plugin.MarshalProperties(result, plugin.MarshalOptions{
+		Label:       fmt.Sprintf("%s.result", req.GetTok()),
+		SkipNulls:   true,
+		KeepSecrets: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pulumirpc.InvokeResponse{Return: ret}, nil
+}
+
+// Call dynamically executes a method in the provider associated with a component resource.
+func (s *server) Call(ctx context.Context, req *pulumirpc.CallRequest) (*pulumirpc.CallResponse, error) {
+	return pp.Call(ctx, req, s.host.EngineConn(), func(_ *pulumi.Context, tok string, args pp.CallArgs) (*pp.CallResult, error) {
+		return Call(tok, args)
+	})
+}
+
+// Cancel signals the provider to gracefully shut down and abort any ongoing resource operations.
+func (s *server) Cancel(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	// Commands remove their directories on exit, but get commands may be interrupted before they start.
+	if s.config != nil && s.config.WorkDirs != nil {
+		if err := s.config.WorkDirs.Cleanup(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// Attach attaches to the engine for an already running provider.
+func (s *server) Attach(_ context.Context, req *pulumirpc.PluginAttach) (*emptypb.Empty, error) {
+	host, err := provider.NewHostClient(req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	s.host = host
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetMapping fetches the conversion mapping (if any) for this resource provider.
+func (s *server) GetMapping(context.Context, *pulumirpc.GetMappingRequest) (*pulumirpc.GetMappingResponse, error) {
+	return &pulumirpc.GetMappingResponse{Provider: "", Data: nil}, nil
+}
+
+func toAnyMap(m map[string]string) map[string]any {
+	res := make(map[string]any, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+
+	return res
+}
+
+// hostLog sends log messages of provider functions to the engine.
+type hostLog struct {
+	ctx  context.Context
+	host *provider.HostClient
+}
+
+var _ pulumi.Log = (*hostLog)(nil)
+
+func (l *hostLog) Debug(msg string, _ *pulumi.LogArgs) error {
+	return l.host.Log(l.ctx, diag.Debug, "", msg)
+}
+
+func (l *hostLog) Info(msg string, _ *pulumi.LogArgs) error {
+	return l.host.Log(l.ctx, diag.Info, "", msg)
+}
+
+func (l *hostLog) Warn(msg string, _ *pulumi.LogArgs) error {
+	return l.host.Log(l.ctx, diag.Warning, "", msg)
+}
+
+func (l *hostLog) Error(msg string, _ *pulumi.LogArgs) error {
+	return l.host.Log(l.ctx, diag.Error, "", msg)
+}
@@ -0,0 +1,216 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	tmachine "github.com/siderolabs/talos/pkg/machinery/config/machine"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/hooks"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	StageUnknown = "unknown"
+	StageRunning = "running"
+
+	commandTimeout = 10 * time.Second
+)
+
+// NodeStatus is the observed state of a single node.
+type NodeStatus struct {
+	Node           string `pulumi:"node"`
+	Stage          string `pulumi:"stage"`
+	Ready          bool   `pulumi:"ready"`
+	MachineType    string `pulumi:"machineType"`
+	TalosVersion   string `pulumi:"talosVersion"`
+	KubeletVersion string `pulumi:"kubeletVersion"`
+	EtcdMember     bool   `pulumi:"etcdMember"`
+	EtcdMemberID   string `pulumi:"etcdMemberId"`
+	EtcdLearner    bool   `pulumi:"etcdLearner"`
+	Error          string `pulumi:"error"`
+}
+
+type resourceOutput struct {
+	Spec yaml.Node `yaml:"spec"`
+}
+
+type machineStatusSpec struct {
+	Stage  string `yaml:"stage"`
+	Status struct {
+		Ready bool `yaml:"ready"`
+	} `yaml:"status"`
+}
+
+// Collect queries every node via the talosctl binary and returns their statuses in the same order.
+// Errors of a single node, including a failed setup of its client, do not fail the whole collection
+// and are reported in NodeStatus.Error.
+func Collect(creds *talosctl.ClientCredentials, nodes []string, binary string, workDirs *talosctl.WorkDirs, logger pulumi.Log) []*NodeStatus {
+	statuses := make([]*NodeStatus, 0, len(nodes))
+
+	for _, node := range nodes {
+		s, err := collectNode(creds, node, binary, workDirs, logger)
+		if err != nil {
+			s = &NodeStatus{
+				Node:  node,
+				Stage: StageUnknown,
+				Error: err.Error(),
+			}
+		}
+
+		statuses = append(statuses, s)
+	}
+
+	return statuses
+}
+
+func collectNode(creds *talosctl.ClientCredentials, node, binary string, workDirs *talosctl.WorkDirs, logger pulumi.Log) (*NodeStatus, error) {
+	dir, err := workDirs.TempDir("status-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfg, err := talosctl.NewTalosconfig("status", []string{node}, []string{node}, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := talosctl.PrepareDir(dir, cfg); err != nil {
+		return nil, err
+	}
+
+	run := hooks.NewTalosRunner(talosctl.New().WithBinary(binary).WithNodeIP(node), dir, logger)
+
+	etcd, err := hooks.NewEtcdClient(context.Background(), node, filepath.Join(dir, talosctl.ConfigName))
+	if err != nil {
+		return nil, err
+	}
+	defer etcd.Close()
+
+	return nodeStatus(run, etcd, node), nil
+}
+
+func nodeStatus(run hooks.Runner, etcd hooks.EtcdClient, node string) *NodeStatus {
+	s := &NodeStatus{
+		Node:  node,
+		Stage: StageUnknown,
+	}
+
+	var ms machineStatusSpec
+	if err := getSpec(run, "machinestatus", &ms); err != nil {
+		// Nodes in maintenance mode accept only insecure connections.
+		if ierr := getSpec(run, "machinestatus", &ms, "--insecure"); ierr != nil {
+			s.Error = err.Error()
+			return s
+		}
+
+		s.Stage = ms.Stage
+
+		return s
+	}
+
+	s.Stage = ms.Stage
+
+	var errs []error
+
+	var version struct {
+		Version string `yaml:"version"`
+	}
+	if err := getSpec(run, "version", &version); err != nil {
+		errs = append(errs, err)
+	}
+	s.TalosVersion = version.Version
+
+	if err := getSpec(run, "machinetype", &s.MachineType); err != nil {
+		errs = append(errs, err)
+	}
+
+	var kubelet struct {
+		Image string `yaml:"image"`
+	}
+	// kubeletspec does not exist until kubelet is configured, so it is not an error.
+	if err := getSpec(run, "kubeletspec", &kubelet); err == nil {
+		s.KubeletVersion = imageTag(kubelet.Image)
+	}
+
+	etcdReady := true
+
+	if s.MachineType == tmachine.TypeControlPlane.String() || s.MachineType == tmachine.TypeInit.String() {
+		etcdReady = false
+
+		if err := etcdStatus(run, etcd, s); err != nil {
+			errs = append(errs, err)
+		} else {
+			etcdReady = s.EtcdMember && !s.EtcdLearner
+		}
+	}
+
+	if len(errs) > 0 {
+		s.Error = errors.Join(errs...).Error()
+	}
+
+	s.Ready = ms.Status.Ready && s.Stage == StageRunning && etcdReady
+
+	return s
+}
+
+func etcdStatus(run hooks.Runner, etcd hooks.EtcdClient, s *NodeStatus) error {
+	var hostname struct {
+		Hostname string `yaml:"hostname"`
+	}
+	if err := getSpec(run, "hostname", &hostname); err != nil {
+		return err
+	}
+
+	peers, err := hooks.ListEtcdPeers(context.Background(), etcd, commandTimeout)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range peers {
+		if p.Hostname == hostname.Hostname {
+			s.EtcdMember = true
+			s.EtcdMemberID = p.ID
+			s.EtcdLearner = p.Learner
+		}
+	}
+
+	return nil
+}
+
+// getSpec runs `talosctl get <resource> -oyaml` and decodes the spec of the resource into out.
+func getSpec(run hooks.Runner, resource string, out any, flags ...string) error {
+	args := append([]string{"get", resource, "-oyaml"}, flags...)
+
+	raw, err := run(commandTimeout, args...)
+	if err != nil {
+		return err
+	}
+
+	var res resourceOutput
+	if err := yaml.Unmarshal(raw, &res); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", resource, err)
+	}
+
+	if err := res.Spec.Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s spec: %w", resource, err)
+	}
+
+	return nil
+}
+
+func imageTag(image string) string {
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return ""
+	}
+
+	return image[i+1:]
+}
@@ -0,0 +1,103 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	machineapi "github.com/siderolabs/talos/pkg/machinery/api/machine"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/hooks"
+	"github.com/spigell/pulumi-talos-cluster/provider/pkg/provider/applier/talosctl"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func fakeRunner(outputs map[string]string) func(time.Duration, ...string) ([]byte, error) {
+	return func(_ time.Duration, args ...string) ([]byte, error) {
+		out, ok := outputs[strings.Join(args, " ")]
+		if !ok {
+			return nil, errors.New("connection refused")
+		}
+		return []byte(out), nil
+	}
+}
+
+// fakeEtcd is an in-memory etcd API of a node.
+type fakeEtcd struct {
+	hooks.EtcdClient
+
+	members []*machineapi.EtcdMember
+}
+
+func (f *fakeEtcd) EtcdMemberList(context.Context, *machineapi.EtcdMemberListRequest, ...grpc.CallOption) (*machineapi.EtcdMemberListResponse, error) {
+	if f.members == nil {
+		return nil, errors.New("connection refused")
+	}
+
+	return &machineapi.EtcdMemberListResponse{
+		Messages: []*machineapi.EtcdMembers{{Members: f.members}},
+	}, nil
+}
+
+func TestNodeStatus_RunningControlplane(t *testing.T) {
+	run := fakeRunner(map[string]string{
+		"get machinestatus -oyaml": "node: 10.0.0.2\nspec:\n  stage: running\n  status:\n    ready: true\n",
+		"get version -oyaml":       "node: 10.0.0.2\nspec:\n  version: v1.12.0\n",
+		"get machinetype -oyaml":   "node: 10.0.0.2\nspec: controlplane\n",
+		"get kubeletspec -oyaml":   "node: 10.0.0.2\nspec:\n  image: ghcr.io/siderolabs/kubelet:v1.33.0\n",
+		"get hostname -oyaml":      "node: 10.0.0.2\nspec:\n  hostname: talos-8me-09g\n",
+	})
+	etcd := &fakeEtcd{members: []*machineapi.EtcdMember{
+		{Id: 0x97f365161a13b437, Hostname: "talos-8me-09g"},
+		{Id: 0xc22a6165837acd5b, Hostname: "talos-apx-beq", IsLearner: true},
+	}}
+
+	s := nodeStatus(run, etcd, "10.0.0.2")
+	require.Equal(t, &NodeStatus{
+		Node:           "10.0.0.2",
+		Stage:          StageRunning,
+		Ready:          true,
+		MachineType:    "controlplane",
+		TalosVersion:   "v1.12.0",
+		KubeletVersion: "v1.33.0",
+		EtcdMember:     true,
+		EtcdMemberID:   "97f365161a13b437",
+	}, s)
+}
+
+func TestNodeStatus_Maintenance(t *testing.T) {
+	run := fakeRunner(map[string]string{
+		"get machinestatus -oyaml --insecure": "spec:\n  stage: maintenance\n  status:\n    ready: false\n",
+	})
+
+	s := nodeStatus(run, &fakeEtcd{}, "10.0.0.3")
+	require.Equal(t, "maintenance", s.Stage)
+	require.False(t, s.Ready)
+	require.Empty(t, s.Error)
+}
+
+func TestNodeStatus_Unreachable(t *testing.T) {
+	s := nodeStatus(fakeRunner(nil), &fakeEtcd{}, "10.0.0.4")
+	require.Equal(t, StageUnknown, s.Stage)
+	require.False(t, s.Ready)
+	require.Contains(t, s.Error, "connection refused")
+}
+
+func TestCollect_SetupErrorIsPerNode(t *testing.T) {
+	workDirs, err := talosctl.NewWorkDirs(t.TempDir())
+	require.NoError(t, err)
+
+	// The client of every node can not be created with these credentials.
+	creds := &talosctl.ClientCredentials{CACertificate: "not-base64", ClientCertificate: "not-base64", ClientKey: "not-base64"}
+
+	statuses := Collect(creds, []string{"10.0.0.2", "10.0.0.3"}, "talosctl", workDirs, nil)
+	require.Len(t, statuses, 2)
+
+	for i, node := range []string{"10.0.0.2", "10.0.0.3"} {
+		require.Equal(t, node, statuses[i].Node)
+		require.Equal(t, StageUnknown, statuses[i].Stage)
+		require.Contains(t, statuses[i].Error, "failed to create Talos API client for "+node)
+	}
+}
@@ -4,21 +4,29 @@ package types
 // revive:enable:var-naming
 
 import (
+	"fmt"
+	"math"
 	"strings"
+	"time"
 
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
 const (
-	MachineIDKey         = "machineId"
-	NodeIPKey            = "nodeIp"
-	TalosImageKey        = "talosImage"
-	UserConfigPatchesKey = "userConfigPatches"
-	ConfigurationKey     = "configuration"
-	KubernetesVersionKey = "kubernetesVersion"
-	ClusterEnpointKey    = "clusterEndpoint"
-	KubeconfigKey        = "kubeconfig"
-	TalosconfigKey       = "talosconfig"
+	MachineInfoVersionKey = "version"
+	MachineIDKey          = "machineId"
+	MachineTypeKey        = "machineType"
+	NodeIPKey             = "nodeIp"
+	TalosImageKey         = "talosImage"
+	UserConfigPatchesKey  = "userConfigPatches"
+	ConfigurationKey      = "configuration"
+	KubernetesVersionKey  = "kubernetesVersion"
+	ClusterEnpointKey     = "clusterEndpoint"
+	KubeconfigKey         = "kubeconfig"
+	TalosconfigKey        = "talosconfig"
+	ApplyModeKey          = "applyMode"
+	TryTimeoutKey         = "tryTimeout"
 )
 
 type ClusterMachine struct {
@@ -27,11 +35,15 @@ type ClusterMachine struct {
 	NodeIP        pulumi.StringPtrInput   `pulumi:"nodeIp"`
 	TalosImage    pulumi.StringPtrInput   `pulumi:"talosImage"`
 	ConfigPatches pulumi.StringArrayInput `pulumi:"configPatches"`
+	ApplyMode     pulumi.StringPtrInput   `pulumi:"applyMode"`
+	TryTimeout    pulumi.StringPtrInput   `pulumi:"tryTimeout"`
 }
 
 func (m *ClusterMachine) ToMachineInfoMap(clusterEndpoint pulumi.StringInput, k8sVer pulumi.StringInput, config pulumi.StringOutput) *pulumi.Map {
 	return &pulumi.Map{
-		MachineIDKey: pulumi.String(m.MachineID),
+		MachineInfoVersionKey: pulumi.Int(MachineInfoVersion),
+		MachineIDKey:          pulumi.String(m.MachineID),
+		MachineTypeKey:        pulumi.String(m.MachineType),
 		UserConfigPatchesKey: m.ConfigPatches.ToStringArrayOutput().
 			ApplyT(func(arr []string) string {
 				return strings.Join(arr, "\n---\n")
@@ -41,27 +53,225 @@ func (m *ClusterMachine) ToMachineInfoMap(clusterEndpoint pulumi.StringInput, k8
 		TalosImageKey:        m.TalosImage.ToStringPtrOutput().Elem(),
 		ClusterEnpointKey:    clusterEndpoint,
 		ConfigurationKey:     config,
+		ApplyModeKey:         optionalString(m.ApplyMode),
+		TryTimeoutKey:        optionalString(m.TryTimeout),
 	}
 }
 
+// optionalString returns an empty string for unset inputs.
+func optionalString(in pulumi.StringPtrInput) pulumi.StringOutput {
+	if in == nil {
+		return pulumi.String("").ToStringOutput()
+	}
+
+	return in.ToStringPtrOutput().Elem()
+}
+
+// MachineInfoVersion is the version of the machine info wire format written by this provider.
+// Version 0 is the format of releases before it was versioned.
+// Keys unknown to the reader are ignored, so new optional keys do not require a new version.
+const MachineInfoVersion = 1
+
 type MachineInfo struct {
+	Version           int    `pulumi:"version"`
 	MachineID         string `pulumi:"machineId"`
+	MachineType       string `pulumi:"machineType"`
 	NodeIP            string `pulumi:"nodeIp"`
 	ClusterEnpoint    string `pulumi:"clusterEndpoint"`
 	UserConfigPatches string `pulumi:"userConfigPatches"`
 	TalosImage        string `pulumi:"talosImage"`
 	KubernetesVersion string `pulumi:"kubernetesVersion"`
 	Configuration     string `pulumi:"configuration"`
+	ApplyMode         string `pulumi:"applyMode"`
+	TryTimeout        string `pulumi:"tryTimeout"`
+
+	// Unknown lists keys with values which are not known yet. It is filled only during preview.
+	Unknown []string
+}
+
+// ParseMachineInfo parses an entry of applyMachines.
+// Missing optional keys are left empty. During preview values which are not known yet are accepted
+// and listed in Unknown, otherwise a missing or unknown required key is an error.
+func ParseMachineInfo(m map[string]any, preview bool) (*MachineInfo, error) {
+	info := &MachineInfo{}
+	p := &machineInfoParser{raw: m, preview: preview, info: info}
+
+	p.required(MachineIDKey, &info.MachineID)
+	p.required(NodeIPKey, &info.NodeIP)
+	p.required(ConfigurationKey, &info.Configuration)
+
+	p.optional(MachineTypeKey, &info.MachineType)
+	p.optional(ClusterEnpointKey, &info.ClusterEnpoint)
+	p.optional(TalosImageKey, &info.TalosImage)
+	p.optional(KubernetesVersionKey, &info.KubernetesVersion)
+	p.optional(UserConfigPatchesKey, &info.UserConfigPatches)
+	p.optional(ApplyModeKey, &info.ApplyMode)
+	p.duration(TryTimeoutKey, &info.TryTimeout)
+	p.version()
+
+	if len(p.errs) > 0 {
+		return nil, fmt.Errorf("invalid machine %s: %s", p.name(), strings.Join(p.errs, "; "))
+	}
+
+	return info, nil
+}
+
+type machineInfoParser struct {
+	raw     map[string]any
+	preview bool
+	info    *MachineInfo
+	errs    []string
+}
+
+func (p *machineInfoParser) name() string {
+	if p.info.MachineID != "" {
+		return p.info.MachineID
+	}
+
+	return "<unnamed>"
+}
+
+// value returns the value of the key if it is set and known.
+// Unknown values are accepted during preview only. Values which are not known yet
+// may also be passed as nulls, so nulls are taken as unknown during preview.
+func (p *machineInfoParser) value(key string, required bool) (any, bool) {
+	v, ok := p.raw[key]
+
+	unknown := v == nil && ok && p.preview
+	if s, isString := v.(string); isString && s == plugin.UnknownStringValue {
+		unknown = true
+	}
+
+	switch {
+	case unknown && p.preview:
+		p.info.Unknown = append(p.info.Unknown, key)
+	case unknown:
+		p.errs = append(p.errs, fmt.Sprintf("%s is unknown", key))
+	case v == nil && required:
+		p.errs = append(p.errs, fmt.Sprintf("%s is required", key))
+	case v != nil:
+		return v, true
+	}
+
+	return nil, false
+}
+
+func (p *machineInfoParser) required(key string, dest *string) {
+	if v, ok := p.value(key, true); ok {
+		p.assign(key, v, dest)
+	}
 }
 
-func ParseMachineInfo(m map[string]any) *MachineInfo {
-	return &MachineInfo{
-		MachineID:         m[MachineIDKey].(string),
-		NodeIP:            m[NodeIPKey].(string),
-		ClusterEnpoint:    m[ClusterEnpointKey].(string),
-		TalosImage:        m[TalosImageKey].(string),
-		KubernetesVersion: m[KubernetesVersionKey].(string),
-		UserConfigPatches: m[UserConfigPatchesKey].(string),
-		Configuration:     m[ConfigurationKey].(string),
+func (p *machineInfoParser) optional(key string, dest *string) {
+	if v, ok := p.value(key, false); ok {
+		p.assign(key, v, dest)
 	}
 }
+
+func (p *machineInfoParser) assign(key string, v any, dest *string) {
+	s, ok := v.(string)
+	if !ok {
+		p.errs = append(p.errs, fmt.Sprintf("%s must be a string, got %T", key, v))
+
+		return
+	}
+
+	*dest = s
+}
+
+// duration parses an optional positive duration. It is kept in the canonical form, e.g. 1m0s for 60s.
+func (p *machineInfoParser) duration(key string, dest *string) {
+	var s string
+
+	p.optional(key, &s)
+
+	if s == "" {
+		return
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		p.errs = append(p.errs, fmt.Sprintf("%s must be a positive duration, got %q", key, s))
+
+		return
+	}
+
+	*dest = d.String()
+}
+
+// version parses the wire format version. Numbers are passed as float64 by Pulumi.
+// Machines written by a newer provider are rejected.
+func (p *machineInfoParser) version() {
+	v, ok := p.value(MachineInfoVersionKey, false)
+	if !ok {
+		return
+	}
+
+	f, ok := v.(float64)
+	if !ok || f < 0 || f != math.Trunc(f) {
+		p.errs = append(p.errs, fmt.Sprintf("%s must be a non-negative integer, got %v", MachineInfoVersionKey, v))
+
+		return
+	}
+
+	// Newer formats may carry keys which change the meaning of the known ones.
+	if f > MachineInfoVersion {
+		p.errs = append(p.errs, fmt.Sprintf("%s %v is newer than %d supported by this provider, upgrade the provider",
+			MachineInfoVersionKey, v, MachineInfoVersion))
+
+		return
+	}
+
+	p.info.Version = int(f)
+}
+
+const (
+	TalosconfigNameKey      = "name"
+	TalosconfigRolesKey     = "roles"
+	TalosconfigTTLKey       = "ttl"
+	TalosconfigEndpointsKey = "endpoints"
+	TalosconfigNodesKey     = "nodes"
+)
+
+// TalosconfigSpec describes an additional talosconfig issued by the Apply component.
+type TalosconfigSpec struct {
+	Name      string
+	Roles     []string
+	TTL       string
+	Endpoints []string
+	Nodes     []string
+}
+
+// ParseTalosconfigSpec parses an entry of additionalTalosconfigs.
+func ParseTalosconfigSpec(m map[string]any) (*TalosconfigSpec, error) {
+	spec := &TalosconfigSpec{}
+
+	spec.Name, _ = m[TalosconfigNameKey].(string)
+	if spec.Name == "" {
+		return nil, fmt.Errorf("%s is required for an additional talosconfig", TalosconfigNameKey)
+	}
+
+	spec.TTL, _ = m[TalosconfigTTLKey].(string)
+	spec.Roles = toStrings(m[TalosconfigRolesKey])
+	spec.Endpoints = toStrings(m[TalosconfigEndpointsKey])
+	spec.Nodes = toStrings(m[TalosconfigNodesKey])
+
+	if len(spec.Roles) == 0 {
+		return nil, fmt.Errorf("talosconfig %s: %s are required", spec.Name, TalosconfigRolesKey)
+	}
+
+	return spec, nil
+}
+
+func toStrings(v any) []string {
+	arr, _ := v.([]any)
+
+	res := make([]string, 0, len(arr))
+	for _, e := range arr {
+		if s, ok := e.(string); ok {
+			res = append(res, s)
+		}
+	}
+
+	return res
+}
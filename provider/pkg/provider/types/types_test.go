@@ -0,0 +1,73 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMachineInfo_Current(t *testing.T) {
+	info, err := ParseMachineInfo(map[string]any{
+		MachineInfoVersionKey: float64(MachineInfoVersion),
+		MachineIDKey:          "cp-1",
+		MachineTypeKey:        "controlplane",
+		NodeIPKey:             "10.0.0.2",
+		ConfigurationKey:      "machine: {}",
+		TalosImageKey:         "ghcr.io/siderolabs/installer:v1.12.0",
+		ApplyModeKey:          "no-reboot",
+		TryTimeoutKey:         "90s",
+		// Keys of newer versions are ignored.
+		"futureKey": "value",
+	}, false)
+	require.NoError(t, err)
+
+	require.Equal(t, MachineInfoVersion, info.Version)
+	require.Equal(t, "cp-1", info.MachineID)
+	require.Equal(t, "controlplane", info.MachineType)
+	require.Equal(t, "no-reboot", info.ApplyMode)
+	require.Equal(t, "1m30s", info.TryTimeout)
+	require.Empty(t, info.KubernetesVersion)
+	require.Empty(t, info.Unknown)
+}
+
+func TestParseMachineInfo_Errors(t *testing.T) {
+	_, err := ParseMachineInfo(map[string]any{
+		MachineIDKey:          "worker-1",
+		NodeIPKey:             42.0,
+		ConfigurationKey:      plugin.UnknownStringValue,
+		TryTimeoutKey:         "1m; reboot",
+		MachineInfoVersionKey: 1.5,
+	}, false)
+	require.EqualError(t, err, "invalid machine worker-1: nodeIp must be a string, got float64; "+
+		"configuration is unknown; tryTimeout must be a positive duration, got \"1m; reboot\"; "+
+		"version must be a non-negative integer, got 1.5")
+
+	_, err = ParseMachineInfo(map[string]any{ConfigurationKey: nil}, false)
+	require.EqualError(t, err, "invalid machine <unnamed>: machineId is required; nodeIp is required; configuration is required")
+}
+
+func TestParseMachineInfo_NewerVersion(t *testing.T) {
+	_, err := ParseMachineInfo(map[string]any{
+		MachineIDKey:          "cp-1",
+		NodeIPKey:             "10.0.0.2",
+		ConfigurationKey:      "machine: {}",
+		MachineInfoVersionKey: float64(MachineInfoVersion + 1),
+	}, false)
+	require.EqualError(t, err, "invalid machine cp-1: version 2 is newer than 1 supported by this provider, upgrade the provider")
+}
+
+func TestParseMachineInfo_UnknownsInPreview(t *testing.T) {
+	info, err := ParseMachineInfo(map[string]any{
+		MachineIDKey:     "worker-1",
+		NodeIPKey:        plugin.UnknownStringValue,
+		ConfigurationKey: nil,
+		TalosImageKey:    plugin.UnknownStringValue,
+	}, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{NodeIPKey, ConfigurationKey, TalosImageKey}, info.Unknown)
+
+	// A missing required key is an error in preview as well.
+	_, err = ParseMachineInfo(map[string]any{MachineIDKey: "worker-1", NodeIPKey: "10.0.0.3"}, true)
+	require.EqualError(t, err, "invalid machine worker-1: configuration is required")
+}
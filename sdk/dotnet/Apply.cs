@@ -15,9 +15,44 @@ namespace Pulumi.TalosCluster
     [TalosClusterResourceType("talos-cluster:index:Apply")]
     public partial class Apply : global::Pulumi.ComponentResource
     {
+        /// <summary>
+        /// Expiry time of the admin kubeconfig client certificate in RFC 3339 format.
+        /// </summary>
+        [Output("adminCertificateExpiry")]
+        public Output<string?> AdminCertificateExpiry { get; private set; } = null!;
+
+        /// <summary>
+        /// Kubeconfig and talosconfig of the cluster.
+        /// </summary>
         [Output("credentials")]
         public Output<Outputs.Credentials> Credentials { get; private set; } = null!;
 
+        /// <summary>
+        /// Configuration fields which differ between the desired and the running configuration, keyed by machine ID. 
+        /// Populated only if detectDrift is enabled.
+        /// </summary>
+        [Output("drift")]
+        public Output<ImmutableDictionary<string, ImmutableArray<string>>?> Drift { get; private set; } = null!;
+
+        /// <summary>
+        /// Applied state of every machine keyed by machine ID.
+        /// </summary>
+        [Output("machines")]
+        public Output<ImmutableDictionary<string, Outputs.MachineStatus>?> Machines { get; private set; } = null!;
+
+        /// <summary>
+        /// Output of `talosctl apply-config --dry-run` for machines with changed configuration, keyed by machine ID. 
+        /// Populated only during preview. Values of keys, certificates and tokens are redacted.
+        /// </summary>
+        [Output("plannedConfigDiff")]
+        public Output<ImmutableDictionary<string, string>?> PlannedConfigDiff { get; private set; } = null!;
+
+        /// <summary>
+        /// Talosconfigs from additionalTalosconfigs keyed by name.
+        /// </summary>
+        [Output("talosconfigs")]
+        public Output<ImmutableDictionary<string, string>?> Talosconfigs { get; private set; } = null!;
+
 
         /// <summary>
         /// Create a Apply resource with the given unique name, arguments, and options.
@@ -26,7 +61,7 @@ namespace Pulumi.TalosCluster
         /// <param name="name">The unique name of the resource</param>
         /// <param name="args">The arguments used to populate this resource's properties</param>
         /// <param name="options">A bag of options that control this resource's behavior</param>
-        public Apply(string name, ApplyArgs args, ComponentResourceOptions? options = null)
+        public Apply(string name, ApplyArgs? args = null, ComponentResourceOptions? options = null)
             : base("talos-cluster:index:Apply", name, args ?? new ApplyArgs(), MakeResourceOptions(options, ""), remote: true)
         {
         }
@@ -37,6 +72,12 @@ namespace Pulumi.TalosCluster
             {
                 Version = Utilities.Version,
                 PluginDownloadURL = "github://api.github.com/spigell/pulumi-talos-cluster",
+                AdditionalSecretOutputs =
+                {
+                    "credentials",
+                    "plannedConfigDiff",
+                    "talosconfigs",
+                },
             };
             var merged = ComponentResourceOptions.Merge(defaultOptions, options);
             // Override the ID if one was specified for consistency with other language SDKs.
@@ -47,17 +88,104 @@ namespace Pulumi.TalosCluster
 
     public sealed class ApplyArgs : global::Pulumi.ResourceArgs
     {
+        [Input("additionalTalosconfigs")]
+        private InputList<Inputs.TalosconfigSpecArgs>? _additionalTalosconfigs;
+
+        /// <summary>
+        /// Talosconfigs with their own roles, e.g. os:reader for on-call engineers. 
+        /// Client certificates are signed by the Talos CA from the configuration of the init node.
+        /// </summary>
+        public InputList<Inputs.TalosconfigSpecArgs> AdditionalTalosconfigs
+        {
+            get => _additionalTalosconfigs ?? (_additionalTalosconfigs = new InputList<Inputs.TalosconfigSpecArgs>());
+            set => _additionalTalosconfigs = value;
+        }
+
+        /// <summary>
+        /// The machine configurations to apply, usually the machines output of the Cluster resource. 
+        /// Either applyMachines or machineConfigurations is required.
+        /// </summary>
+        [Input("applyMachines")]
+        public Input<Inputs.ApplyMachinesArgs>? ApplyMachines { get; set; }
+
+        /// <summary>
+        /// Default mode of `talosctl apply-config` for machines without their own applyMode. 
+        /// Changes requiring a reboot fail in no-reboot and try modes before reaching the node. 
+        /// Default is auto.
+        /// </summary>
+        [Input("applyMode")]
+        public Input<Pulumi.TalosCluster.ApplyMode>? ApplyMode { get; set; }
+
+        /// <summary>
+        /// Client configuration for bootstrapping and applying resources. 
+        /// Either clientConfiguration or clientTalosconfig is required.
+        /// </summary>
+        [Input("clientConfiguration")]
+        public Input<Inputs.ClientConfigurationArgs>? ClientConfiguration { get; set; }
+
+        [Input("clientTalosconfig")]
+        private Input<string>? _clientTalosconfig;
+
+        /// <summary>
+        /// Talosconfig whose current context gives the client credentials to access the nodes. 
+        /// Either clientConfiguration or clientTalosconfig is required.
+        /// </summary>
+        public Input<string>? ClientTalosconfig
+        {
+            get => _clientTalosconfig;
+            set
+            {
+                var emptySecret = Output.CreateSecret(0);
+                _clientTalosconfig = Output.Tuple<Input<string>?, int>(value, emptySecret).Apply(t => t.Item1);
+            }
+        }
+
+        /// <summary>
+        /// detectDrift fetches the running configuration from every machine after apply 
+        /// and compares it with the desired one to find changes made out of band (e.g. via `talosctl edit mc`). 
+        /// Images of Kubernetes components are ignored since they are managed by upgrade-k8s. 
+        /// Default is false.
+        /// </summary>
+        [Input("detectDrift")]
+        public Input<bool>? DetectDrift { get; set; }
+
+        /// <summary>
+        /// Options of the admin kubeconfig in credentials. 
+        /// The kubeconfig is signed locally by the Kubernetes CA from the configuration of the init node.
+        /// </summary>
+        [Input("kubeconfig")]
+        public Input<Inputs.KubeconfigOptionsArgs>? Kubeconfig { get; set; }
+
+        [Input("machineConfigurations")]
+        private InputList<Inputs.MachineConfigurationArgs>? _machineConfigurations;
+
+        /// <summary>
+        /// Machine configurations generated by other tools, e.g. `talosctl gen config` or talhelper. 
+        /// The machine type, Talos image, Kubernetes version and cluster endpoint are taken from every configuration. 
+        /// etcd is bootstrapped on the machine of the init type or on the first controlplane. 
+        /// Either applyMachines or machineConfigurations is required.
+        /// </summary>
+        public InputList<Inputs.MachineConfigurationArgs> MachineConfigurations
+        {
+            get => _machineConfigurations ?? (_machineConfigurations = new InputList<Inputs.MachineConfigurationArgs>());
+            set => _machineConfigurations = value;
+        }
+
         /// <summary>
-        /// The machine configurations to apply.
+        /// reapplyOnDrift applies the desired configuration again if a drift is detected. 
+        /// Requires detectDrift. 
+        /// Default is false.
         /// </summary>
-        [Input("applyMachines", required: true)]
-        public Input<Inputs.ApplyMachinesArgs> ApplyMachines { get; set; } = null!;
+        [Input("reapplyOnDrift")]
+        public Input<bool>? ReapplyOnDrift { get; set; }
 
         /// <summary>
-        /// Client configuration for bootstrapping and applying resources.
+        /// How long to wait for a node to come back after a reboot. 
+        /// The node is back when it reports a new boot ID and reaches the running or maintenance stage. 
+        /// Default is 10m0s.
         /// </summary>
-        [Input("clientConfiguration", required: true)]
-        public Input<Inputs.ClientConfigurationArgs> ClientConfiguration { get; set; } = null!;
+        [Input("rebootTimeout")]
+        public Input<string>? RebootTimeout { get; set; }
 
         /// <summary>
         /// skipInitApply indicates that machines will be managed or configured by external tools. 
@@ -69,9 +197,21 @@ namespace Pulumi.TalosCluster
         [Input("skipInitApply")]
         public Input<bool>? SkipInitApply { get; set; }
 
+        /// <summary>
+        /// Default duration after which a configuration applied in try mode is rolled back. 
+        /// Default is 1m.
+        /// </summary>
+        [Input("tryTimeout")]
+        public Input<string>? TryTimeout { get; set; }
+
         public ApplyArgs()
         {
+            ApplyMode = Pulumi.TalosCluster.ApplyMode.Auto;
+            DetectDrift = false;
+            ReapplyOnDrift = false;
+            RebootTimeout = "10m0s";
             SkipInitApply = false;
+            TryTimeout = "1m";
         }
         public static new ApplyArgs Empty => new ApplyArgs();
     }
@@ -29,11 +29,18 @@ namespace Pulumi.TalosCluster
         [Output("generatedConfigurations")]
         public Output<ImmutableDictionary<string, string>> GeneratedConfigurations { get; private set; } = null!;
 
+        /// <summary>
+        /// Cluster secrets bundle and admin client certificate generated on creation (YAML). 
+        /// It is kept in the state and never regenerated.
+        /// </summary>
+        [Output("machineSecrets")]
+        public Output<string> MachineSecrets { get; private set; } = null!;
+
         /// <summary>
         /// Machine information grouped by machine type.
         /// </summary>
         [Output("machines")]
-        public Output<Outputs.ApplyMachines> Machines { get; private set; } = null!;
+        public Output<Outputs.MachinesByType> Machines { get; private set; } = null!;
 
 
         /// <summary>
@@ -54,12 +61,37 @@ namespace Pulumi.TalosCluster
             {
                 Version = Utilities.Version,
                 PluginDownloadURL = "github://api.github.com/spigell/pulumi-talos-cluster",
+                AdditionalSecretOutputs =
+                {
+                    "generatedConfigurations",
+                    "machineSecrets",
+                },
             };
             var merged = ComponentResourceOptions.Merge(defaultOptions, options);
             // Override the ID if one was specified for consistency with other language SDKs.
             merged.Id = id ?? merged.Id;
             return merged;
         }
+
+        /// <summary>
+        /// Generate a configuration for a new machine of the role, which joins the cluster. 
+        /// It uses the cluster secrets and the default installation image.
+        /// </summary>
+        public global::Pulumi.Output<ClusterGetJoinConfigResult> GetJoinConfig(ClusterGetJoinConfigArgs args)
+            => global::Pulumi.Deployment.Instance.Call<ClusterGetJoinConfigResult>("talos-cluster:index:Cluster/getJoinConfig", args ?? new ClusterGetJoinConfigArgs(), this);
+
+        /// <summary>
+        /// Get the generated configuration of a machine merged with extra patches. No node is contacted.
+        /// </summary>
+        public global::Pulumi.Output<ClusterGetMachineConfigResult> GetMachineConfig(ClusterGetMachineConfigArgs args)
+            => global::Pulumi.Deployment.Instance.Call<ClusterGetMachineConfigResult>("talos-cluster:index:Cluster/getMachineConfig", args ?? new ClusterGetMachineConfigArgs(), this);
+
+        /// <summary>
+        /// Issue a talosconfig with a new client certificate signed by the Talos CA of the cluster. 
+        /// A new certificate is issued on every call.
+        /// </summary>
+        public global::Pulumi.Output<ClusterGetTalosconfigResult> GetTalosconfig(ClusterGetTalosconfigArgs? args = null)
+            => global::Pulumi.Deployment.Instance.Call<ClusterGetTalosconfigResult>("talos-cluster:index:Cluster/getTalosconfig", args ?? new ClusterGetTalosconfigArgs(), this);
     }
 
     public sealed class ClusterArgs : global::Pulumi.ResourceArgs
@@ -88,6 +120,24 @@ namespace Pulumi.TalosCluster
         [Input("clusterName", required: true)]
         public string ClusterName { get; set; } = null!;
 
+        [Input("existingSecrets")]
+        private Input<string>? _existingSecrets;
+
+        /// <summary>
+        /// Existing secrets bundle (YAML) to use instead of a generated one. 
+        /// Accepts the output of `talosctl gen secrets` or the machineSecrets of the pulumiverse talos Secrets resource. 
+        /// It is only read on creation of machineSecrets, e.g. to adopt a cluster created outside of the stack.
+        /// </summary>
+        public Input<string>? ExistingSecrets
+        {
+            get => _existingSecrets;
+            set
+            {
+                var emptySecret = Output.CreateSecret(0);
+                _existingSecrets = Output.Tuple<Input<string>?, int>(value, emptySecret).Apply(t => t.Item1);
+            }
+        }
+
         /// <summary>
         /// Kubernetes version to install. 
         /// Default is v1.33.0.
@@ -98,8 +148,8 @@ namespace Pulumi.TalosCluster
         /// <summary>
         /// Version of Talos features used for configuration generation. 
         /// Do not confuse this with the talosImage property. 
-        /// Used in NewSecrets() and GetConfigurationOutput() resources. 
-        /// This property is immutable to prevent version conflicts across provider updates. 
+        /// Used to generate the secrets bundle and machine configurations. 
+        /// This property is immutable: the value used on creation is kept in machineSecrets. 
         /// See issue: https://github.com/siderolabs/terraform-provider-talos/issues/168 
         /// The default value is based on gendata.VersionTag, current: v1.12.0.
         /// </summary>
@@ -113,4 +163,151 @@ namespace Pulumi.TalosCluster
         }
         public static new ClusterArgs Empty => new ClusterArgs();
     }
+
+    /// <summary>
+    /// The set of arguments for the <see cref="Cluster.GetJoinConfig"/> method.
+    /// </summary>
+    public sealed class ClusterGetJoinConfigArgs : global::Pulumi.CallArgs
+    {
+        /// <summary>
+        /// Machine type of the new machine: controlplane or worker.
+        /// </summary>
+        [Input("role", required: true)]
+        public Input<string> Role { get; set; } = null!;
+
+        public ClusterGetJoinConfigArgs()
+        {
+        }
+        public static new ClusterGetJoinConfigArgs Empty => new ClusterGetJoinConfigArgs();
+    }
+
+    /// <summary>
+    /// The results of the <see cref="Cluster.GetJoinConfig"/> method.
+    /// </summary>
+    [OutputType]
+    public sealed class ClusterGetJoinConfigResult
+    {
+        /// <summary>
+        /// Machine configuration YAML.
+        /// </summary>
+        public readonly string Configuration;
+
+        [OutputConstructor]
+        private ClusterGetJoinConfigResult(string configuration)
+        {
+            Configuration = configuration;
+        }
+    }
+
+    /// <summary>
+    /// The set of arguments for the <see cref="Cluster.GetMachineConfig"/> method.
+    /// </summary>
+    public sealed class ClusterGetMachineConfigArgs : global::Pulumi.CallArgs
+    {
+        [Input("extraPatches")]
+        private InputList<string>? _extraPatches;
+
+        /// <summary>
+        /// Patches to merge into the configuration in the same way as configPatches.
+        /// </summary>
+        public InputList<string> ExtraPatches
+        {
+            get => _extraPatches ?? (_extraPatches = new InputList<string>());
+            set => _extraPatches = value;
+        }
+
+        /// <summary>
+        /// ID of the machine.
+        /// </summary>
+        [Input("machineId", required: true)]
+        public Input<string> MachineId { get; set; } = null!;
+
+        public ClusterGetMachineConfigArgs()
+        {
+        }
+        public static new ClusterGetMachineConfigArgs Empty => new ClusterGetMachineConfigArgs();
+    }
+
+    /// <summary>
+    /// The results of the <see cref="Cluster.GetMachineConfig"/> method.
+    /// </summary>
+    [OutputType]
+    public sealed class ClusterGetMachineConfigResult
+    {
+        /// <summary>
+        /// Machine configuration YAML.
+        /// </summary>
+        public readonly string Configuration;
+
+        [OutputConstructor]
+        private ClusterGetMachineConfigResult(string configuration)
+        {
+            Configuration = configuration;
+        }
+    }
+
+    /// <summary>
+    /// The set of arguments for the <see cref="Cluster.GetTalosconfig"/> method.
+    /// </summary>
+    public sealed class ClusterGetTalosconfigArgs : global::Pulumi.CallArgs
+    {
+        [Input("endpoints")]
+        private InputList<string>? _endpoints;
+
+        /// <summary>
+        /// Endpoints of the talosconfig context.
+        /// </summary>
+        public InputList<string> Endpoints
+        {
+            get => _endpoints ?? (_endpoints = new InputList<string>());
+            set => _endpoints = value;
+        }
+
+        [Input("nodes")]
+        private InputList<string>? _nodes;
+
+        /// <summary>
+        /// Nodes of the talosconfig context.
+        /// </summary>
+        public InputList<string> Nodes
+        {
+            get => _nodes ?? (_nodes = new InputList<string>());
+            set => _nodes = value;
+        }
+
+        [Input("roles")]
+        private InputList<string>? _roles;
+
+        /// <summary>
+        /// Talos API roles of the certificate, e.g. os:reader. Default is os:admin.
+        /// </summary>
+        public InputList<string> Roles
+        {
+            get => _roles ?? (_roles = new InputList<string>());
+            set => _roles = value;
+        }
+
+        public ClusterGetTalosconfigArgs()
+        {
+        }
+        public static new ClusterGetTalosconfigArgs Empty => new ClusterGetTalosconfigArgs();
+    }
+
+    /// <summary>
+    /// The results of the <see cref="Cluster.GetTalosconfig"/> method.
+    /// </summary>
+    [OutputType]
+    public sealed class ClusterGetTalosconfigResult
+    {
+        /// <summary>
+        /// The talosconfig.
+        /// </summary>
+        public readonly string Talosconfig;
+
+        [OutputConstructor]
+        private ClusterGetTalosconfigResult(string talosconfig)
+        {
+            Talosconfig = talosconfig;
+        }
+    }
 }
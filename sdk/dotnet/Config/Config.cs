@@ -0,0 +1,115 @@
+// *** WARNING: this file was generated by pulumi-language-dotnet. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Immutable;
+
+namespace Pulumi.TalosCluster
+{
+    public static class Config
+    {
+        [global::System.Diagnostics.CodeAnalysis.SuppressMessage("Microsoft.Design", "IDE1006", Justification = 
+        "Double underscore prefix used to avoid conflicts with variable names.")]
+        private sealed class __Value<T>
+        {
+            private readonly Func<T> _getter;
+            private T _value = default!;
+            private bool _set;
+
+            public __Value(Func<T> getter)
+            {
+                _getter = getter;
+            }
+
+            public T Get() => _set ? _value : _getter();
+
+            public void Set(T value)
+            {
+                _value = value;
+                _set = true;
+            }
+        }
+
+        private static readonly global::Pulumi.Config __config = new global::Pulumi.Config("talos-cluster");
+
+        private static readonly __Value<Pulumi.TalosCluster.DebugBundle?> _debugBundle = new __Value<Pulumi.TalosCluster.DebugBundle?>(() => __config.GetObject<Pulumi.TalosCluster.DebugBundle>("debugBundle"));
+        /// <summary>
+        /// Diagnostics collected from a node when `talosctl upgrade` or `talosctl apply-config` fails. 
+        /// `basic` collects dmesg, the service list, logs of machined (and etcd on controlplanes during upgrade) and the machine status. 
+        /// `support` adds a `talosctl support` archive, which takes a few minutes. 
+        /// The path of the bundle is a part of the error message. 
+        /// Default is basic.
+        /// </summary>
+        public static Pulumi.TalosCluster.DebugBundle? DebugBundle
+        {
+            get => _debugBundle.Get();
+            set => _debugBundle.Set(value);
+        }
+
+        private static readonly __Value<string?> _debugBundleDir = new __Value<string?>(() => __config.Get("debugBundleDir"));
+        /// <summary>
+        /// Root directory for debug bundles. 
+        /// Bundles are written to `&lt;debugBundleDir&gt;/&lt;stack&gt;/&lt;cluster&gt;/&lt;stage&gt;-&lt;machine&gt;/&lt;time&gt;` and are not removed. 
+        /// Default is the debug directory inside the working directories of workDir.
+        /// </summary>
+        public static string? DebugBundleDir
+        {
+            get => _debugBundleDir.Get();
+            set => _debugBundleDir.Set(value);
+        }
+
+        private static readonly __Value<Pulumi.TalosCluster.MigrationMode?> _migrationMode = new __Value<Pulumi.TalosCluster.MigrationMode?>(() => __config.GetObject<Pulumi.TalosCluster.MigrationMode>("migrationMode"));
+        /// <summary>
+        /// Migration of stacks created with pulumiverse/talos resources. 
+        /// `aliases` registers the initial apply and bootstrap with aliases to the former resources, they are rerun, but skip configured nodes. 
+        /// `import` takes the initial apply and bootstrap as done without contacting nodes. 
+        /// In both modes the preview fails if a node is not configured, its etcd is not bootstrapped 
+        /// or it runs other cluster secrets than the generated configuration. 
+        /// Unset it after the migration.
+        /// </summary>
+        public static Pulumi.TalosCluster.MigrationMode? MigrationMode
+        {
+            get => _migrationMode.Get();
+            set => _migrationMode.Set(value);
+        }
+
+        private static readonly __Value<string?> _talosctlPath = new __Value<string?>(() => __config.Get("talosctlPath"));
+        /// <summary>
+        /// Path to the talosctl binary or to a directory with talosctl binaries for different Talos versions. 
+        /// If a directory is set, the binary matching the Talos version of a machine image is used for the machine, 
+        /// and the newest one otherwise. 
+        /// Default is talosctl from PATH.
+        /// </summary>
+        public static string? TalosctlPath
+        {
+            get => _talosctlPath.Get();
+            set => _talosctlPath.Set(value);
+        }
+
+        private static readonly __Value<Pulumi.TalosCluster.TalosctlVersionCheck?> _talosctlVersionCheck = new __Value<Pulumi.TalosCluster.TalosctlVersionCheck?>(() => __config.GetObject<Pulumi.TalosCluster.TalosctlVersionCheck>("talosctlVersionCheck"));
+        /// <summary>
+        /// Reaction on skew between the talosctl version and the Talos version of a machine image. 
+        /// The version of every binary is checked via `talosctl version --client` on provider start. 
+        /// Default is warn.
+        /// </summary>
+        public static Pulumi.TalosCluster.TalosctlVersionCheck? TalosctlVersionCheck
+        {
+            get => _talosctlVersionCheck.Get();
+            set => _talosctlVersionCheck.Set(value);
+        }
+
+        private static readonly __Value<string?> _workDir = new __Value<string?>(() => __config.Get("workDir"));
+        /// <summary>
+        /// Root directory for talosctl working directories. 
+        /// Files with credentials and machine configurations are written to `&lt;workDir&gt;/talos-cluster-&lt;uid&gt;`, 
+        /// which is accessible only by the current user. 
+        /// Default is the system temporary directory.
+        /// </summary>
+        public static string? WorkDir
+        {
+            get => _workDir.Get();
+            set => _workDir.Set(value);
+        }
+
+    }
+}
@@ -7,6 +7,72 @@ using Pulumi;
 
 namespace Pulumi.TalosCluster
 {
+    /// <summary>
+    /// Modes of talosctl apply-config
+    /// </summary>
+    [EnumType]
+    public readonly struct ApplyMode : IEquatable<ApplyMode>
+    {
+        private readonly string _value;
+
+        private ApplyMode(string value)
+        {
+            _value = value ?? throw new ArgumentNullException(nameof(value));
+        }
+
+        public static ApplyMode Auto { get; } = new ApplyMode("auto");
+        public static ApplyMode No_reboot { get; } = new ApplyMode("no-reboot");
+        public static ApplyMode Reboot { get; } = new ApplyMode("reboot");
+        public static ApplyMode Staged { get; } = new ApplyMode("staged");
+        public static ApplyMode @Try { get; } = new ApplyMode("try");
+
+        public static bool operator ==(ApplyMode left, ApplyMode right) => left.Equals(right);
+        public static bool operator !=(ApplyMode left, ApplyMode right) => !left.Equals(right);
+
+        public static explicit operator string(ApplyMode value) => value._value;
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override bool Equals(object? obj) => obj is ApplyMode other && Equals(other);
+        public bool Equals(ApplyMode other) => string.Equals(_value, other._value, StringComparison.Ordinal);
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override int GetHashCode() => _value?.GetHashCode() ?? 0;
+
+        public override string ToString() => _value;
+    }
+
+    /// <summary>
+    /// Diagnostics collected from a node after a failed operation
+    /// </summary>
+    [EnumType]
+    public readonly struct DebugBundle : IEquatable<DebugBundle>
+    {
+        private readonly string _value;
+
+        private DebugBundle(string value)
+        {
+            _value = value ?? throw new ArgumentNullException(nameof(value));
+        }
+
+        public static DebugBundle None { get; } = new DebugBundle("none");
+        public static DebugBundle Basic { get; } = new DebugBundle("basic");
+        public static DebugBundle Support { get; } = new DebugBundle("support");
+
+        public static bool operator ==(DebugBundle left, DebugBundle right) => left.Equals(right);
+        public static bool operator !=(DebugBundle left, DebugBundle right) => !left.Equals(right);
+
+        public static explicit operator string(DebugBundle value) => value._value;
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override bool Equals(object? obj) => obj is DebugBundle other && Equals(other);
+        public bool Equals(DebugBundle other) => string.Equals(_value, other._value, StringComparison.Ordinal);
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override int GetHashCode() => _value?.GetHashCode() ?? 0;
+
+        public override string ToString() => _value;
+    }
+
     /// <summary>
     /// Allowed machine types
     /// </summary>
@@ -38,4 +104,101 @@ namespace Pulumi.TalosCluster
 
         public override string ToString() => _value;
     }
+
+    /// <summary>
+    /// Modes of migration from pulumiverse/talos resources
+    /// </summary>
+    [EnumType]
+    public readonly struct MigrationMode : IEquatable<MigrationMode>
+    {
+        private readonly string _value;
+
+        private MigrationMode(string value)
+        {
+            _value = value ?? throw new ArgumentNullException(nameof(value));
+        }
+
+        public static MigrationMode Aliases { get; } = new MigrationMode("aliases");
+        public static MigrationMode Import { get; } = new MigrationMode("import");
+
+        public static bool operator ==(MigrationMode left, MigrationMode right) => left.Equals(right);
+        public static bool operator !=(MigrationMode left, MigrationMode right) => !left.Equals(right);
+
+        public static explicit operator string(MigrationMode value) => value._value;
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override bool Equals(object? obj) => obj is MigrationMode other && Equals(other);
+        public bool Equals(MigrationMode other) => string.Equals(_value, other._value, StringComparison.Ordinal);
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override int GetHashCode() => _value?.GetHashCode() ?? 0;
+
+        public override string ToString() => _value;
+    }
+
+    /// <summary>
+    /// Operations which change a machine
+    /// </summary>
+    [EnumType]
+    public readonly struct Operation : IEquatable<Operation>
+    {
+        private readonly string _value;
+
+        private Operation(string value)
+        {
+            _value = value ?? throw new ArgumentNullException(nameof(value));
+        }
+
+        public static Operation Unknown { get; } = new Operation("unknown");
+        public static Operation Initial_apply { get; } = new Operation("initial-apply");
+        public static Operation Upgrade { get; } = new Operation("upgrade");
+        public static Operation Upgrade_k8s { get; } = new Operation("upgrade-k8s");
+        public static Operation Apply_config { get; } = new Operation("apply-config");
+
+        public static bool operator ==(Operation left, Operation right) => left.Equals(right);
+        public static bool operator !=(Operation left, Operation right) => !left.Equals(right);
+
+        public static explicit operator string(Operation value) => value._value;
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override bool Equals(object? obj) => obj is Operation other && Equals(other);
+        public bool Equals(Operation other) => string.Equals(_value, other._value, StringComparison.Ordinal);
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override int GetHashCode() => _value?.GetHashCode() ?? 0;
+
+        public override string ToString() => _value;
+    }
+
+    /// <summary>
+    /// Reactions on talosctl version skew
+    /// </summary>
+    [EnumType]
+    public readonly struct TalosctlVersionCheck : IEquatable<TalosctlVersionCheck>
+    {
+        private readonly string _value;
+
+        private TalosctlVersionCheck(string value)
+        {
+            _value = value ?? throw new ArgumentNullException(nameof(value));
+        }
+
+        public static TalosctlVersionCheck Warn { get; } = new TalosctlVersionCheck("warn");
+        public static TalosctlVersionCheck Fail { get; } = new TalosctlVersionCheck("fail");
+        public static TalosctlVersionCheck Skip { get; } = new TalosctlVersionCheck("skip");
+
+        public static bool operator ==(TalosctlVersionCheck left, TalosctlVersionCheck right) => left.Equals(right);
+        public static bool operator !=(TalosctlVersionCheck left, TalosctlVersionCheck right) => !left.Equals(right);
+
+        public static explicit operator string(TalosctlVersionCheck value) => value._value;
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override bool Equals(object? obj) => obj is TalosctlVersionCheck other && Equals(other);
+        public bool Equals(TalosctlVersionCheck other) => string.Equals(_value, other._value, StringComparison.Ordinal);
+
+        [EditorBrowsable(EditorBrowsableState.Never)]
+        public override int GetHashCode() => _value?.GetHashCode() ?? 0;
+
+        public override string ToString() => _value;
+    }
 }
@@ -0,0 +1,113 @@
+// *** WARNING: this file was generated by pulumi-language-dotnet. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.TalosCluster
+{
+    public static class GetClusterStatus
+    {
+        /// <summary>
+        /// Get the observed status of Talos nodes: 
+        /// - Talos and kubelet versions 
+        /// - Machine type and stage 
+        /// - Etcd membership 
+        /// Nodes which can't be reached are reported with an error instead of failing the whole call.
+        /// </summary>
+        public static Task<GetClusterStatusResult> InvokeAsync(GetClusterStatusArgs args, InvokeOptions? options = null)
+            => global::Pulumi.Deployment.Instance.InvokeAsync<GetClusterStatusResult>("talos-cluster:index:getClusterStatus", args ?? new GetClusterStatusArgs(), options.WithDefaults());
+
+        /// <summary>
+        /// Get the observed status of Talos nodes: 
+        /// - Talos and kubelet versions 
+        /// - Machine type and stage 
+        /// - Etcd membership 
+        /// Nodes which can't be reached are reported with an error instead of failing the whole call.
+        /// </summary>
+        public static Output<GetClusterStatusResult> Invoke(GetClusterStatusInvokeArgs args, InvokeOptions? options = null)
+            => global::Pulumi.Deployment.Instance.Invoke<GetClusterStatusResult>("talos-cluster:index:getClusterStatus", args ?? new GetClusterStatusInvokeArgs(), options.WithDefaults());
+
+        /// <summary>
+        /// Get the observed status of Talos nodes: 
+        /// - Talos and kubelet versions 
+        /// - Machine type and stage 
+        /// - Etcd membership 
+        /// Nodes which can't be reached are reported with an error instead of failing the whole call.
+        /// </summary>
+        public static Output<GetClusterStatusResult> Invoke(GetClusterStatusInvokeArgs args, InvokeOutputOptions options)
+            => global::Pulumi.Deployment.Instance.Invoke<GetClusterStatusResult>("talos-cluster:index:getClusterStatus", args ?? new GetClusterStatusInvokeArgs(), options.WithDefaults());
+    }
+
+
+    public sealed class GetClusterStatusArgs : global::Pulumi.InvokeArgs
+    {
+        /// <summary>
+        /// Client configuration for bootstrapping and applying resources.
+        /// </summary>
+        [Input("clientConfiguration", required: true)]
+        public Inputs.ClientConfiguration ClientConfiguration { get; set; } = null!;
+
+        [Input("nodes", required: true)]
+        private List<string>? _nodes;
+
+        /// <summary>
+        /// IP addresses of nodes to query. Every node is used as its own endpoint.
+        /// </summary>
+        public List<string> Nodes
+        {
+            get => _nodes ?? (_nodes = new List<string>());
+            set => _nodes = value;
+        }
+
+        public GetClusterStatusArgs()
+        {
+        }
+        public static new GetClusterStatusArgs Empty => new GetClusterStatusArgs();
+    }
+
+    public sealed class GetClusterStatusInvokeArgs : global::Pulumi.InvokeArgs
+    {
+        /// <summary>
+        /// Client configuration for bootstrapping and applying resources.
+        /// </summary>
+        [Input("clientConfiguration", required: true)]
+        public Input<Inputs.ClientConfigurationArgs> ClientConfiguration { get; set; } = null!;
+
+        [Input("nodes", required: true)]
+        private InputList<string>? _nodes;
+
+        /// <summary>
+        /// IP addresses of nodes to query. Every node is used as its own endpoint.
+        /// </summary>
+        public InputList<string> Nodes
+        {
+            get => _nodes ?? (_nodes = new InputList<string>());
+            set => _nodes = value;
+        }
+
+        public GetClusterStatusInvokeArgs()
+        {
+        }
+        public static new GetClusterStatusInvokeArgs Empty => new GetClusterStatusInvokeArgs();
+    }
+
+
+    [OutputType]
+    public sealed class GetClusterStatusResult
+    {
+        /// <summary>
+        /// Status of every requested node in the same order.
+        /// </summary>
+        public readonly ImmutableArray<Outputs.NodeStatus> Nodes;
+
+        [OutputConstructor]
+        private GetClusterStatusResult(ImmutableArray<Outputs.NodeStatus> nodes)
+        {
+            Nodes = nodes;
+        }
+    }
+}
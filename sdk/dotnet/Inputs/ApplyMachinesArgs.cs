@@ -10,10 +10,18 @@ using Pulumi.Serialization;
 namespace Pulumi.TalosCluster.Inputs
 {
 
+    /// <summary>
+    /// Machines to apply grouped by machine type. 
+    /// It is usually the machines output of the Cluster resource.
+    /// </summary>
     public sealed class ApplyMachinesArgs : global::Pulumi.ResourceArgs
     {
         [Input("controlplane")]
         private InputList<Inputs.MachineInfoArgs>? _controlplane;
+
+        /// <summary>
+        /// Machines of the controlplane type.
+        /// </summary>
         public InputList<Inputs.MachineInfoArgs> Controlplane
         {
             get => _controlplane ?? (_controlplane = new InputList<Inputs.MachineInfoArgs>());
@@ -22,6 +30,10 @@ namespace Pulumi.TalosCluster.Inputs
 
         [Input("init", required: true)]
         private InputList<Inputs.MachineInfoArgs>? _init;
+
+        /// <summary>
+        /// Machines of the init type.
+        /// </summary>
         public InputList<Inputs.MachineInfoArgs> Init
         {
             get => _init ?? (_init = new InputList<Inputs.MachineInfoArgs>());
@@ -30,6 +42,10 @@ namespace Pulumi.TalosCluster.Inputs
 
         [Input("worker")]
         private InputList<Inputs.MachineInfoArgs>? _worker;
+
+        /// <summary>
+        /// Machines of the worker type.
+        /// </summary>
         public InputList<Inputs.MachineInfoArgs> Worker
         {
             get => _worker ?? (_worker = new InputList<Inputs.MachineInfoArgs>());
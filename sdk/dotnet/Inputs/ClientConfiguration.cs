@@ -0,0 +1,44 @@
+// *** WARNING: this file was generated by pulumi-language-dotnet. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.TalosCluster.Inputs
+{
+
+    public sealed class ClientConfiguration : global::Pulumi.InvokeArgs
+    {
+        /// <summary>
+        /// The Certificate Authority (CA) certificate used to verify connections to the Talos API server.
+        /// </summary>
+        [Input("caCertificate")]
+        public string? CaCertificate { get; set; }
+
+        /// <summary>
+        /// The client certificate used to authenticate to the Talos API server.
+        /// </summary>
+        [Input("clientCertificate")]
+        public string? ClientCertificate { get; set; }
+
+        [Input("clientKey")]
+        private string? _clientKey;
+
+        /// <summary>
+        /// The private key for the client certificate, used for authenticating the client to the Talos API server.
+        /// </summary>
+        public string? ClientKey
+        {
+            get => _clientKey;
+            set => _clientKey = value;
+        }
+
+        public ClientConfiguration()
+        {
+        }
+        public static new ClientConfiguration Empty => new ClientConfiguration();
+    }
+}
@@ -24,11 +24,21 @@ namespace Pulumi.TalosCluster.Inputs
         [Input("clientCertificate")]
         public Input<string>? ClientCertificate { get; set; }
 
+        [Input("clientKey")]
+        private Input<string>? _clientKey;
+
         /// <summary>
         /// The private key for the client certificate, used for authenticating the client to the Talos API server.
         /// </summary>
-        [Input("clientKey")]
-        public Input<string>? ClientKey { get; set; }
+        public Input<string>? ClientKey
+        {
+            get => _clientKey;
+            set
+            {
+                var emptySecret = Output.CreateSecret(0);
+                _clientKey = Output.Tuple<Input<string>?, int>(value, emptySecret).Apply(t => t.Item1);
+            }
+        }
 
         public ClientConfigurationArgs()
         {
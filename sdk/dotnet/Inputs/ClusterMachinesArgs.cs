@@ -12,6 +12,13 @@ namespace Pulumi.TalosCluster.Inputs
 
     public sealed class ClusterMachinesArgs : global::Pulumi.ResourceArgs
     {
+        /// <summary>
+        /// Mode of `talosctl apply-config` for the machine. 
+        /// Overrides the applyMode of the Apply resource.
+        /// </summary>
+        [Input("applyMode")]
+        public Input<Pulumi.TalosCluster.ApplyMode>? ApplyMode { get; set; }
+
         [Input("configPatches")]
         private InputList<string>? _configPatches;
 
@@ -52,6 +59,13 @@ namespace Pulumi.TalosCluster.Inputs
         [Input("talosImage")]
         public Input<string>? TalosImage { get; set; }
 
+        /// <summary>
+        /// Duration after which a configuration applied in try mode is rolled back. 
+        /// Overrides the tryTimeout of the Apply resource.
+        /// </summary>
+        [Input("tryTimeout")]
+        public Input<string>? TryTimeout { get; set; }
+
         public ClusterMachinesArgs()
         {
             TalosImage = "ghcr.io/siderolabs/installer:v1.12.0";
@@ -0,0 +1,53 @@
+// *** WARNING: this file was generated by pulumi-language-dotnet. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.TalosCluster.Inputs
+{
+
+    public sealed class KubeconfigOptionsArgs : global::Pulumi.ResourceArgs
+    {
+        /// <summary>
+        /// Lifetime of the admin client certificate, e.g. 24h. 
+        /// The certificate is renewed in the middle of its lifetime. 
+        /// Default is cluster.adminKubeconfig.certLifetime of the init node configuration, one year unless it is patched.
+        /// </summary>
+        [Input("certLifetime")]
+        public Input<string>? CertLifetime { get; set; }
+
+        /// <summary>
+        /// Name of the cluster entry. Default is the cluster name.
+        /// </summary>
+        [Input("clusterName")]
+        public Input<string>? ClusterName { get; set; }
+
+        /// <summary>
+        /// Name of the context, which is also the current context. Default is admin@&lt;cluster name&gt;.
+        /// </summary>
+        [Input("contextName")]
+        public Input<string>? ContextName { get; set; }
+
+        /// <summary>
+        /// URL of the Kubernetes API server, e.g. https://lb.example.com:6443. 
+        /// Default is the cluster endpoint from the configuration of the init node.
+        /// </summary>
+        [Input("server")]
+        public Input<string>? Server { get; set; }
+
+        /// <summary>
+        /// Name of the user entry. Default is admin@&lt;cluster name&gt;.
+        /// </summary>
+        [Input("userName")]
+        public Input<string>? UserName { get; set; }
+
+        public KubeconfigOptionsArgs()
+        {
+        }
+        public static new KubeconfigOptionsArgs Empty => new KubeconfigOptionsArgs();
+    }
+}
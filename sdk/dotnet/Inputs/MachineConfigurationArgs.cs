@@ -0,0 +1,62 @@
+// *** WARNING: this file was generated by pulumi-language-dotnet. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.TalosCluster.Inputs
+{
+
+    public sealed class MachineConfigurationArgs : global::Pulumi.ResourceArgs
+    {
+        /// <summary>
+        /// Mode of `talosctl apply-config` for the machine. 
+        /// Overrides the applyMode of the Apply resource.
+        /// </summary>
+        [Input("applyMode")]
+        public Input<Pulumi.TalosCluster.ApplyMode>? ApplyMode { get; set; }
+
+        [Input("configuration", required: true)]
+        private Input<string>? _configuration;
+
+        /// <summary>
+        /// Complete machine configuration of the node (YAML).
+        /// </summary>
+        public Input<string>? Configuration
+        {
+            get => _configuration;
+            set
+            {
+                var emptySecret = Output.CreateSecret(0);
+                _configuration = Output.Tuple<Input<string>?, int>(value, emptySecret).Apply(t => t.Item1);
+            }
+        }
+
+        /// <summary>
+        /// ID or name of the machine.
+        /// </summary>
+        [Input("machineId", required: true)]
+        public Input<string> MachineId { get; set; } = null!;
+
+        /// <summary>
+        /// The IP address of the node where configuration will be applied.
+        /// </summary>
+        [Input("nodeIp", required: true)]
+        public Input<string> NodeIp { get; set; } = null!;
+
+        /// <summary>
+        /// Duration after which a configuration applied in try mode is rolled back. 
+        /// Overrides the tryTimeout of the Apply resource.
+        /// </summary>
+        [Input("tryTimeout")]
+        public Input<string>? TryTimeout { get; set; }
+
+        public MachineConfigurationArgs()
+        {
+        }
+        public static new MachineConfigurationArgs Empty => new MachineConfigurationArgs();
+    }
+}
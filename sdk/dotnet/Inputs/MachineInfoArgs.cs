@@ -12,21 +12,39 @@ namespace Pulumi.TalosCluster.Inputs
 
     public sealed class MachineInfoArgs : global::Pulumi.ResourceArgs
     {
+        /// <summary>
+        /// Mode of `talosctl apply-config` for the machine. 
+        /// Overrides the applyMode of the Apply resource.
+        /// </summary>
+        [Input("applyMode")]
+        public Input<Pulumi.TalosCluster.ApplyMode>? ApplyMode { get; set; }
+
         /// <summary>
         /// cluster endpoint applied to node
         /// </summary>
         [Input("clusterEndpoint")]
         public Input<string>? ClusterEndpoint { get; set; }
 
+        [Input("configuration", required: true)]
+        private Input<string>? _configuration;
+
         /// <summary>
         /// Configuration settings for machines to apply. 
         /// This can be retrieved from the cluster resource.
         /// </summary>
-        [Input("configuration", required: true)]
-        public Input<string> Configuration { get; set; } = null!;
+        public Input<string>? Configuration
+        {
+            get => _configuration;
+            set
+            {
+                var emptySecret = Output.CreateSecret(0);
+                _configuration = Output.Tuple<Input<string>?, int>(value, emptySecret).Apply(t => t.Item1);
+            }
+        }
 
         /// <summary>
-        /// Kubernetes version to install or upgrade on the node.
+        /// Kubernetes version to install or upgrade on the node. 
+        /// The version of the kubelet image of the configuration is used if it is not set.
         /// </summary>
         [Input("kubernetesVersion")]
         public Input<string>? KubernetesVersion { get; set; }
@@ -37,6 +55,13 @@ namespace Pulumi.TalosCluster.Inputs
         [Input("machineId", required: true)]
         public Input<string> MachineId { get; set; } = null!;
 
+        /// <summary>
+        /// Type of the machine. 
+        /// Set by the Cluster resource. The machine is applied according to its group in applyMachines.
+        /// </summary>
+        [Input("machineType")]
+        public Input<Pulumi.TalosCluster.MachineTypes>? MachineType { get; set; }
+
         /// <summary>
         /// The IP address of the node where configuration will be applied.
         /// </summary>
@@ -44,11 +69,19 @@ namespace Pulumi.TalosCluster.Inputs
         public Input<string> NodeIp { get; set; } = null!;
 
         /// <summary>
-        /// Talos OS image to install or upgrade on the node.
+        /// Talos OS image to install or upgrade on the node. 
+        /// The install image of the configuration is used if it is not set.
         /// </summary>
         [Input("talosImage")]
         public Input<string>? TalosImage { get; set; }
 
+        /// <summary>
+        /// Duration after which a configuration applied in try mode is rolled back. 
+        /// Overrides the tryTimeout of the Apply resource.
+        /// </summary>
+        [Input("tryTimeout")]
+        public Input<string>? TryTimeout { get; set; }
+
         /// <summary>
         /// User-provided machine configuration to apply. 
         /// This can be retrieved from the cluster resource.
@@ -56,6 +89,14 @@ namespace Pulumi.TalosCluster.Inputs
         [Input("userConfigPatches")]
         public Input<string>? UserConfigPatches { get; set; }
 
+        /// <summary>
+        /// Version of the machine info format. 
+        /// Set by the Cluster resource, the current version is 1. 
+        /// Machines without it are parsed as version 0.
+        /// </summary>
+        [Input("version")]
+        public Input<int>? Version { get; set; }
+
         public MachineInfoArgs()
         {
         }
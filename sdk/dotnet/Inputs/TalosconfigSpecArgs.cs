@@ -0,0 +1,71 @@
+// *** WARNING: this file was generated by pulumi-language-dotnet. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.TalosCluster.Inputs
+{
+
+    public sealed class TalosconfigSpecArgs : global::Pulumi.ResourceArgs
+    {
+        [Input("endpoints")]
+        private InputList<string>? _endpoints;
+
+        /// <summary>
+        /// Endpoints of the talosconfig. Default is all controlplanes.
+        /// </summary>
+        public InputList<string> Endpoints
+        {
+            get => _endpoints ?? (_endpoints = new InputList<string>());
+            set => _endpoints = value;
+        }
+
+        /// <summary>
+        /// Name of the talosconfig and its context.
+        /// </summary>
+        [Input("name", required: true)]
+        public Input<string> Name { get; set; } = null!;
+
+        [Input("nodes")]
+        private InputList<string>? _nodes;
+
+        /// <summary>
+        /// Nodes of the talosconfig. Default is all machines.
+        /// </summary>
+        public InputList<string> Nodes
+        {
+            get => _nodes ?? (_nodes = new InputList<string>());
+            set => _nodes = value;
+        }
+
+        [Input("roles", required: true)]
+        private InputList<string>? _roles;
+
+        /// <summary>
+        /// Talos API roles of the client certificate. 
+        /// Supported roles: os:admin, os:operator, os:reader, os:etcd:backup.
+        /// </summary>
+        public InputList<string> Roles
+        {
+            get => _roles ?? (_roles = new InputList<string>());
+            set => _roles = value;
+        }
+
+        /// <summary>
+        /// Lifetime of the client certificate, e.g. 720h. 
+        /// The certificate is renewed in the middle of its lifetime. 
+        /// Default is 8760h0m0s.
+        /// </summary>
+        [Input("ttl")]
+        public Input<string>? Ttl { get; set; }
+
+        public TalosconfigSpecArgs()
+        {
+        }
+        public static new TalosconfigSpecArgs Empty => new TalosconfigSpecArgs();
+    }
+}
@@ -13,6 +13,11 @@ namespace Pulumi.TalosCluster.Outputs
     [OutputType]
     public sealed class MachineInfo
     {
+        /// <summary>
+        /// Mode of `talosctl apply-config` for the machine. 
+        /// Overrides the applyMode of the Apply resource.
+        /// </summary>
+        public readonly Pulumi.TalosCluster.ApplyMode? ApplyMode;
         /// <summary>
         /// cluster endpoint applied to node
         /// </summary>
@@ -23,7 +28,8 @@ namespace Pulumi.TalosCluster.Outputs
         /// </summary>
         public readonly string Configuration;
         /// <summary>
-        /// Kubernetes version to install or upgrade on the node.
+        /// Kubernetes version to install or upgrade on the node. 
+        /// The version of the kubelet image of the configuration is used if it is not set.
         /// </summary>
         public readonly string? KubernetesVersion;
         /// <summary>
@@ -31,21 +37,40 @@ namespace Pulumi.TalosCluster.Outputs
         /// </summary>
         public readonly string MachineId;
         /// <summary>
+        /// Type of the machine. 
+        /// Set by the Cluster resource. The machine is applied according to its group in applyMachines.
+        /// </summary>
+        public readonly Pulumi.TalosCluster.MachineTypes? MachineType;
+        /// <summary>
         /// The IP address of the node where configuration will be applied.
         /// </summary>
         public readonly string NodeIp;
         /// <summary>
-        /// Talos OS image to install or upgrade on the node.
+        /// Talos OS image to install or upgrade on the node. 
+        /// The install image of the configuration is used if it is not set.
         /// </summary>
         public readonly string? TalosImage;
         /// <summary>
+        /// Duration after which a configuration applied in try mode is rolled back. 
+        /// Overrides the tryTimeout of the Apply resource.
+        /// </summary>
+        public readonly string? TryTimeout;
+        /// <summary>
         /// User-provided machine configuration to apply. 
         /// This can be retrieved from the cluster resource.
         /// </summary>
         public readonly string? UserConfigPatches;
+        /// <summary>
+        /// Version of the machine info format. 
+        /// Set by the Cluster resource, the current version is 1. 
+        /// Machines without it are parsed as version 0.
+        /// </summary>
+        public readonly int? Version;
 
         [OutputConstructor]
         private MachineInfo(
+            Pulumi.TalosCluster.ApplyMode? applyMode,
+
             string? clusterEndpoint,
 
             string configuration,
@@ -54,19 +79,29 @@ namespace Pulumi.TalosCluster.Outputs
 
             string machineId,
 
+            Pulumi.TalosCluster.MachineTypes? machineType,
+
             string nodeIp,
 
             string? talosImage,
 
-            string? userConfigPatches)
+            string? tryTimeout,
+
+            string? userConfigPatches,
+
+            int? version)
         {
+            ApplyMode = applyMode;
             ClusterEndpoint = clusterEndpoint;
             Configuration = configuration;
             KubernetesVersion = kubernetesVersion;
             MachineId = machineId;
+            MachineType = machineType;
             NodeIp = nodeIp;
             TalosImage = talosImage;
+            TryTimeout = tryTimeout;
             UserConfigPatches = userConfigPatches;
+            Version = version;
         }
     }
 }
@@ -0,0 +1,63 @@
+// *** WARNING: this file was generated by pulumi-language-dotnet. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.TalosCluster.Outputs
+{
+
+    [OutputType]
+    public sealed class MachineStatus
+    {
+        /// <summary>
+        /// SHA-256 hash of the last applied machine configuration.
+        /// </summary>
+        public readonly string ConfigHash;
+        /// <summary>
+        /// Kubernetes version of the machine.
+        /// </summary>
+        public readonly string KubernetesVersion;
+        /// <summary>
+        /// The last operation which ran on the machine. It is unknown for machines of migrated stacks until an operation runs on them.
+        /// </summary>
+        public readonly Pulumi.TalosCluster.Operation LastOperation;
+        /// <summary>
+        /// Time of the last operation in RFC 3339 format. Empty if the operation is unknown.
+        /// </summary>
+        public readonly string LastOperationTime;
+        /// <summary>
+        /// Talos version reported by the node after apply.
+        /// </summary>
+        public readonly string ObservedTalosVersion;
+        /// <summary>
+        /// Talos version of the machine image. Empty if the image tag is not a version.
+        /// </summary>
+        public readonly string TalosVersion;
+
+        [OutputConstructor]
+        private MachineStatus(
+            string configHash,
+
+            string kubernetesVersion,
+
+            Pulumi.TalosCluster.Operation lastOperation,
+
+            string lastOperationTime,
+
+            string observedTalosVersion,
+
+            string talosVersion)
+        {
+            ConfigHash = configHash;
+            KubernetesVersion = kubernetesVersion;
+            LastOperation = lastOperation;
+            LastOperationTime = lastOperationTime;
+            ObservedTalosVersion = observedTalosVersion;
+            TalosVersion = talosVersion;
+        }
+    }
+}
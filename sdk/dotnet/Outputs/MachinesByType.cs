@@ -10,15 +10,28 @@ using Pulumi.Serialization;
 namespace Pulumi.TalosCluster.Outputs
 {
 
+    /// <summary>
+    /// Machines of the Cluster resource grouped by machine type. 
+    /// It is passed to applyMachines of the Apply resource.
+    /// </summary>
     [OutputType]
-    public sealed class ApplyMachines
+    public sealed class MachinesByType
     {
+        /// <summary>
+        /// Machines of the controlplane type.
+        /// </summary>
         public readonly ImmutableArray<Outputs.MachineInfo> Controlplane;
+        /// <summary>
+        /// Machines of the init type.
+        /// </summary>
         public readonly ImmutableArray<Outputs.MachineInfo> Init;
+        /// <summary>
+        /// Machines of the worker type.
+        /// </summary>
         public readonly ImmutableArray<Outputs.MachineInfo> Worker;
 
         [OutputConstructor]
-        private ApplyMachines(
+        private MachinesByType(
             ImmutableArray<Outputs.MachineInfo> controlplane,
 
             ImmutableArray<Outputs.MachineInfo> init,
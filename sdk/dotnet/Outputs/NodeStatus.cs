@@ -0,0 +1,93 @@
+// *** WARNING: this file was generated by pulumi-language-dotnet. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.TalosCluster.Outputs
+{
+
+    [OutputType]
+    public sealed class NodeStatus
+    {
+        /// <summary>
+        /// Errors occurred while the status was collected.
+        /// </summary>
+        public readonly string? Error;
+        /// <summary>
+        /// The etcd member is a learner and does not vote yet.
+        /// </summary>
+        public readonly bool? EtcdLearner;
+        /// <summary>
+        /// The node is a member of the etcd cluster.
+        /// </summary>
+        public readonly bool? EtcdMember;
+        /// <summary>
+        /// ID of the etcd member.
+        /// </summary>
+        public readonly string? EtcdMemberId;
+        /// <summary>
+        /// Kubelet version from the kubelet image. Empty if kubelet is not configured yet.
+        /// </summary>
+        public readonly string? KubeletVersion;
+        /// <summary>
+        /// Machine type from the running configuration.
+        /// </summary>
+        public readonly string? MachineType;
+        /// <summary>
+        /// The IP address of the node.
+        /// </summary>
+        public readonly string Node;
+        /// <summary>
+        /// The node is running, all Talos conditions are met 
+        /// and, for controlplanes, the node is a voting etcd member.
+        /// </summary>
+        public readonly bool Ready;
+        /// <summary>
+        /// Machine stage reported by Talos, e.g. maintenance, booting, running. 
+        /// It is unknown if the node can't be reached.
+        /// </summary>
+        public readonly string Stage;
+        /// <summary>
+        /// Running Talos version.
+        /// </summary>
+        public readonly string? TalosVersion;
+
+        [OutputConstructor]
+        private NodeStatus(
+            string? error,
+
+            bool? etcdLearner,
+
+            bool? etcdMember,
+
+            string? etcdMemberId,
+
+            string? kubeletVersion,
+
+            string? machineType,
+
+            string node,
+
+            bool ready,
+
+            string stage,
+
+            string? talosVersion)
+        {
+            Error = error;
+            EtcdLearner = etcdLearner;
+            EtcdMember = etcdMember;
+            EtcdMemberId = etcdMemberId;
+            KubeletVersion = kubeletVersion;
+            MachineType = machineType;
+            Node = node;
+            Ready = ready;
+            Stage = stage;
+            TalosVersion = talosVersion;
+        }
+    }
+}
@@ -9,6 +9,9 @@ using Pulumi.Serialization;
 
 namespace Pulumi.TalosCluster
 {
+    /// <summary>
+    /// The provider type for the talos-cluster package.
+    /// </summary>
     [TalosClusterResourceType("pulumi:providers:talos-cluster")]
     public partial class Provider : global::Pulumi.ProviderResource
     {
@@ -40,6 +43,61 @@ namespace Pulumi.TalosCluster
 
     public sealed class ProviderArgs : global::Pulumi.ResourceArgs
     {
+        /// <summary>
+        /// Diagnostics collected from a node when `talosctl upgrade` or `talosctl apply-config` fails. 
+        /// `basic` collects dmesg, the service list, logs of machined (and etcd on controlplanes during upgrade) and the machine status. 
+        /// `support` adds a `talosctl support` archive, which takes a few minutes. 
+        /// The path of the bundle is a part of the error message. 
+        /// Default is basic.
+        /// </summary>
+        [Input("debugBundle", json: true)]
+        public Input<Pulumi.TalosCluster.DebugBundle>? DebugBundle { get; set; }
+
+        /// <summary>
+        /// Root directory for debug bundles. 
+        /// Bundles are written to `&lt;debugBundleDir&gt;/&lt;stack&gt;/&lt;cluster&gt;/&lt;stage&gt;-&lt;machine&gt;/&lt;time&gt;` and are not removed. 
+        /// Default is the debug directory inside the working directories of workDir.
+        /// </summary>
+        [Input("debugBundleDir")]
+        public Input<string>? DebugBundleDir { get; set; }
+
+        /// <summary>
+        /// Migration of stacks created with pulumiverse/talos resources. 
+        /// `aliases` registers the initial apply and bootstrap with aliases to the former resources, they are rerun, but skip configured nodes. 
+        /// `import` takes the initial apply and bootstrap as done without contacting nodes. 
+        /// In both modes the preview fails if a node is not configured, its etcd is not bootstrapped 
+        /// or it runs other cluster secrets than the generated configuration. 
+        /// Unset it after the migration.
+        /// </summary>
+        [Input("migrationMode", json: true)]
+        public Input<Pulumi.TalosCluster.MigrationMode>? MigrationMode { get; set; }
+
+        /// <summary>
+        /// Path to the talosctl binary or to a directory with talosctl binaries for different Talos versions. 
+        /// If a directory is set, the binary matching the Talos version of a machine image is used for the machine, 
+        /// and the newest one otherwise. 
+        /// Default is talosctl from PATH.
+        /// </summary>
+        [Input("talosctlPath")]
+        public Input<string>? TalosctlPath { get; set; }
+
+        /// <summary>
+        /// Reaction on skew between the talosctl version and the Talos version of a machine image. 
+        /// The version of every binary is checked via `talosctl version --client` on provider start. 
+        /// Default is warn.
+        /// </summary>
+        [Input("talosctlVersionCheck", json: true)]
+        public Input<Pulumi.TalosCluster.TalosctlVersionCheck>? TalosctlVersionCheck { get; set; }
+
+        /// <summary>
+        /// Root directory for talosctl working directories. 
+        /// Files with credentials and machine configurations are written to `&lt;workDir&gt;/talos-cluster-&lt;uid&gt;`, 
+        /// which is accessible only by the current user. 
+        /// Default is the system temporary directory.
+        /// </summary>
+        [Input("workDir")]
+        public Input<string>? WorkDir { get; set; }
+
         public ProviderArgs()
         {
         }
@@ -0,0 +1,148 @@
+// *** WARNING: this file was generated by pulumi-language-dotnet. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.TalosCluster
+{
+    public static class RenderMachineConfig
+    {
+        /// <summary>
+        /// Render the machine configuration exactly as the Apply resource sends it to a node. 
+        /// The same merge pipeline is used: user patches are merged into the generated configuration 
+        /// and images of Kubernetes components are kept from the running configuration if it is provided. 
+        /// No node is contacted.
+        /// </summary>
+        public static Task<RenderMachineConfigResult> InvokeAsync(RenderMachineConfigArgs args, InvokeOptions? options = null)
+            => global::Pulumi.Deployment.Instance.InvokeAsync<RenderMachineConfigResult>("talos-cluster:index:renderMachineConfig", args ?? new RenderMachineConfigArgs(), options.WithDefaults());
+
+        /// <summary>
+        /// Render the machine configuration exactly as the Apply resource sends it to a node. 
+        /// The same merge pipeline is used: user patches are merged into the generated configuration 
+        /// and images of Kubernetes components are kept from the running configuration if it is provided. 
+        /// No node is contacted.
+        /// </summary>
+        public static Output<RenderMachineConfigResult> Invoke(RenderMachineConfigInvokeArgs args, InvokeOptions? options = null)
+            => global::Pulumi.Deployment.Instance.Invoke<RenderMachineConfigResult>("talos-cluster:index:renderMachineConfig", args ?? new RenderMachineConfigInvokeArgs(), options.WithDefaults());
+
+        /// <summary>
+        /// Render the machine configuration exactly as the Apply resource sends it to a node. 
+        /// The same merge pipeline is used: user patches are merged into the generated configuration 
+        /// and images of Kubernetes components are kept from the running configuration if it is provided. 
+        /// No node is contacted.
+        /// </summary>
+        public static Output<RenderMachineConfigResult> Invoke(RenderMachineConfigInvokeArgs args, InvokeOutputOptions options)
+            => global::Pulumi.Deployment.Instance.Invoke<RenderMachineConfigResult>("talos-cluster:index:renderMachineConfig", args ?? new RenderMachineConfigInvokeArgs(), options.WithDefaults());
+    }
+
+
+    public sealed class RenderMachineConfigArgs : global::Pulumi.InvokeArgs
+    {
+        [Input("configuration", required: true)]
+        private string? _configuration;
+
+        /// <summary>
+        /// Generated machine configuration. 
+        /// This can be retrieved from the cluster resource.
+        /// </summary>
+        public string? Configuration
+        {
+            get => _configuration;
+            set => _configuration = value;
+        }
+
+        [Input("currentConfiguration")]
+        private string? _currentConfiguration;
+
+        /// <summary>
+        /// Configuration currently running on the node, e.g. spec of `talosctl get machineconfig`. 
+        /// If set, images of Kubernetes components are taken from it to prevent downgrades.
+        /// </summary>
+        public string? CurrentConfiguration
+        {
+            get => _currentConfiguration;
+            set => _currentConfiguration = value;
+        }
+
+        /// <summary>
+        /// User-provided machine configuration patches as a multi-document YAML. 
+        /// This can be retrieved from the cluster resource.
+        /// </summary>
+        [Input("userConfigPatches")]
+        public string? UserConfigPatches { get; set; }
+
+        public RenderMachineConfigArgs()
+        {
+        }
+        public static new RenderMachineConfigArgs Empty => new RenderMachineConfigArgs();
+    }
+
+    public sealed class RenderMachineConfigInvokeArgs : global::Pulumi.InvokeArgs
+    {
+        [Input("configuration", required: true)]
+        private Input<string>? _configuration;
+
+        /// <summary>
+        /// Generated machine configuration. 
+        /// This can be retrieved from the cluster resource.
+        /// </summary>
+        public Input<string>? Configuration
+        {
+            get => _configuration;
+            set
+            {
+                var emptySecret = Output.CreateSecret(0);
+                _configuration = Output.Tuple<Input<string>?, int>(value, emptySecret).Apply(t => t.Item1);
+            }
+        }
+
+        [Input("currentConfiguration")]
+        private Input<string>? _currentConfiguration;
+
+        /// <summary>
+        /// Configuration currently running on the node, e.g. spec of `talosctl get machineconfig`. 
+        /// If set, images of Kubernetes components are taken from it to prevent downgrades.
+        /// </summary>
+        public Input<string>? CurrentConfiguration
+        {
+            get => _currentConfiguration;
+            set
+            {
+                var emptySecret = Output.CreateSecret(0);
+                _currentConfiguration = Output.Tuple<Input<string>?, int>(value, emptySecret).Apply(t => t.Item1);
+            }
+        }
+
+        /// <summary>
+        /// User-provided machine configuration patches as a multi-document YAML. 
+        /// This can be retrieved from the cluster resource.
+        /// </summary>
+        [Input("userConfigPatches")]
+        public Input<string>? UserConfigPatches { get; set; }
+
+        public RenderMachineConfigInvokeArgs()
+        {
+        }
+        public static new RenderMachineConfigInvokeArgs Empty => new RenderMachineConfigInvokeArgs();
+    }
+
+
+    [OutputType]
+    public sealed class RenderMachineConfigResult
+    {
+        /// <summary>
+        /// The final machine configuration YAML.
+        /// </summary>
+        public readonly string MachineConfiguration;
+
+        [OutputConstructor]
+        private RenderMachineConfigResult(string machineConfiguration)
+        {
+            MachineConfiguration = machineConfiguration;
+        }
+    }
+}
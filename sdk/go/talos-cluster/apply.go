@@ -7,7 +7,6 @@ import (
 	"context"
 	"reflect"
 
-	"errors"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/spigell/pulumi-talos-cluster/sdk/go/talos-cluster/internal"
 )
@@ -16,25 +15,56 @@ import (
 type Apply struct {
 	pulumi.ResourceState
 
+	// Expiry time of the admin kubeconfig client certificate in RFC 3339 format.
+	AdminCertificateExpiry pulumi.StringPtrOutput `pulumi:"adminCertificateExpiry"`
+	// Kubeconfig and talosconfig of the cluster.
 	Credentials CredentialsOutput `pulumi:"credentials"`
+	// Configuration fields which differ between the desired and the running configuration, keyed by machine ID.
+	// Populated only if detectDrift is enabled.
+	Drift pulumi.StringArrayMapOutput `pulumi:"drift"`
+	// Applied state of every machine keyed by machine ID.
+	Machines MachineStatusMapOutput `pulumi:"machines"`
+	// Output of `talosctl apply-config --dry-run` for machines with changed configuration, keyed by machine ID.
+	// Populated only during preview. Values of keys, certificates and tokens are redacted.
+	PlannedConfigDiff pulumi.StringMapOutput `pulumi:"plannedConfigDiff"`
+	// Talosconfigs from additionalTalosconfigs keyed by name.
+	Talosconfigs pulumi.StringMapOutput `pulumi:"talosconfigs"`
 }
 
 // NewApply registers a new resource with the given unique name, arguments, and options.
 func NewApply(ctx *pulumi.Context,
 	name string, args *ApplyArgs, opts ...pulumi.ResourceOption) (*Apply, error) {
 	if args == nil {
-		return nil, errors.New("missing one or more required arguments")
+		args = &ApplyArgs{}
 	}
 
-	if args.ApplyMachines == nil {
-		return nil, errors.New("invalid value for required argument 'ApplyMachines'")
+	if args.ApplyMode == nil {
+		args.ApplyMode = ApplyMode("auto")
 	}
-	if args.ClientConfiguration == nil {
-		return nil, errors.New("invalid value for required argument 'ClientConfiguration'")
+	if args.DetectDrift == nil {
+		args.DetectDrift = pulumi.BoolPtr(false)
+	}
+	if args.ReapplyOnDrift == nil {
+		args.ReapplyOnDrift = pulumi.BoolPtr(false)
+	}
+	if args.RebootTimeout == nil {
+		args.RebootTimeout = pulumi.StringPtr("10m0s")
 	}
 	if args.SkipInitApply == nil {
 		args.SkipInitApply = pulumi.BoolPtr(false)
 	}
+	if args.TryTimeout == nil {
+		args.TryTimeout = pulumi.StringPtr("1m")
+	}
+	if args.ClientTalosconfig != nil {
+		args.ClientTalosconfig = pulumi.ToSecret(args.ClientTalosconfig).(pulumi.StringPtrInput)
+	}
+	secrets := pulumi.AdditionalSecretOutputs([]string{
+		"credentials",
+		"plannedConfigDiff",
+		"talosconfigs",
+	})
+	opts = append(opts, secrets)
 	opts = internal.PkgResourceDefaultOpts(opts)
 	var resource Apply
 	err := ctx.RegisterRemoteComponentResource("talos-cluster:index:Apply", name, args, &resource, opts...)
@@ -45,30 +75,102 @@ func NewApply(ctx *pulumi.Context,
 }
 
 type applyArgs struct {
-	// The machine configurations to apply.
-	ApplyMachines ApplyMachines `pulumi:"applyMachines"`
+	// Talosconfigs with their own roles, e.g. os:reader for on-call engineers.
+	// Client certificates are signed by the Talos CA from the configuration of the init node.
+	AdditionalTalosconfigs []TalosconfigSpec `pulumi:"additionalTalosconfigs"`
+	// The machine configurations to apply, usually the machines output of the Cluster resource.
+	// Either applyMachines or machineConfigurations is required.
+	ApplyMachines *ApplyMachines `pulumi:"applyMachines"`
+	// Default mode of `talosctl apply-config` for machines without their own applyMode.
+	// Changes requiring a reboot fail in no-reboot and try modes before reaching the node.
+	// Default is auto.
+	ApplyMode *ApplyMode `pulumi:"applyMode"`
 	// Client configuration for bootstrapping and applying resources.
-	ClientConfiguration ClientConfiguration `pulumi:"clientConfiguration"`
+	// Either clientConfiguration or clientTalosconfig is required.
+	ClientConfiguration *ClientConfiguration `pulumi:"clientConfiguration"`
+	// Talosconfig whose current context gives the client credentials to access the nodes.
+	// Either clientConfiguration or clientTalosconfig is required.
+	ClientTalosconfig *string `pulumi:"clientTalosconfig"`
+	// detectDrift fetches the running configuration from every machine after apply
+	// and compares it with the desired one to find changes made out of band (e.g. via `talosctl edit mc`).
+	// Images of Kubernetes components are ignored since they are managed by upgrade-k8s.
+	// Default is false.
+	DetectDrift *bool `pulumi:"detectDrift"`
+	// Options of the admin kubeconfig in credentials.
+	// The kubeconfig is signed locally by the Kubernetes CA from the configuration of the init node.
+	Kubeconfig *KubeconfigOptions `pulumi:"kubeconfig"`
+	// Machine configurations generated by other tools, e.g. `talosctl gen config` or talhelper.
+	// The machine type, Talos image, Kubernetes version and cluster endpoint are taken from every configuration.
+	// etcd is bootstrapped on the machine of the init type or on the first controlplane.
+	// Either applyMachines or machineConfigurations is required.
+	MachineConfigurations []MachineConfiguration `pulumi:"machineConfigurations"`
+	// reapplyOnDrift applies the desired configuration again if a drift is detected.
+	// Requires detectDrift.
+	// Default is false.
+	ReapplyOnDrift *bool `pulumi:"reapplyOnDrift"`
+	// How long to wait for a node to come back after a reboot.
+	// The node is back when it reports a new boot ID and reaches the running or maintenance stage.
+	// Default is 10m0s.
+	RebootTimeout *string `pulumi:"rebootTimeout"`
 	// skipInitApply indicates that machines will be managed or configured by external tools.
 	// For example, it can serve as a source for userdata in cloud provider setups.
 	// This option helps accelerate node provisioning.
 	// Note: init node is always applied.
 	// Default is false.
 	SkipInitApply *bool `pulumi:"skipInitApply"`
+	// Default duration after which a configuration applied in try mode is rolled back.
+	// Default is 1m.
+	TryTimeout *string `pulumi:"tryTimeout"`
 }
 
 // The set of arguments for constructing a Apply resource.
 type ApplyArgs struct {
-	// The machine configurations to apply.
-	ApplyMachines ApplyMachinesInput
+	// Talosconfigs with their own roles, e.g. os:reader for on-call engineers.
+	// Client certificates are signed by the Talos CA from the configuration of the init node.
+	AdditionalTalosconfigs TalosconfigSpecArrayInput
+	// The machine configurations to apply, usually the machines output of the Cluster resource.
+	// Either applyMachines or machineConfigurations is required.
+	ApplyMachines ApplyMachinesPtrInput
+	// Default mode of `talosctl apply-config` for machines without their own applyMode.
+	// Changes requiring a reboot fail in no-reboot and try modes before reaching the node.
+	// Default is auto.
+	ApplyMode ApplyModePtrInput
 	// Client configuration for bootstrapping and applying resources.
-	ClientConfiguration ClientConfigurationInput
+	// Either clientConfiguration or clientTalosconfig is required.
+	ClientConfiguration ClientConfigurationPtrInput
+	// Talosconfig whose current context gives the client credentials to access the nodes.
+	// Either clientConfiguration or clientTalosconfig is required.
+	ClientTalosconfig pulumi.StringPtrInput
+	// detectDrift fetches the running configuration from every machine after apply
+	// and compares it with the desired one to find changes made out of band (e.g. via `talosctl edit mc`).
+	// Images of Kubernetes components are ignored since they are managed by upgrade-k8s.
+	// Default is false.
+	DetectDrift pulumi.BoolPtrInput
+	// Options of the admin kubeconfig in credentials.
+	// The kubeconfig is signed locally by the Kubernetes CA from the configuration of the init node.
+	Kubeconfig KubeconfigOptionsPtrInput
+	// Machine configurations generated by other tools, e.g. `talosctl gen config` or talhelper.
+	// The machine type, Talos image, Kubernetes version and cluster endpoint are taken from every configuration.
+	// etcd is bootstrapped on the machine of the init type or on the first controlplane.
+	// Either applyMachines or machineConfigurations is required.
+	MachineConfigurations MachineConfigurationArrayInput
+	// reapplyOnDrift applies the desired configuration again if a drift is detected.
+	// Requires detectDrift.
+	// Default is false.
+	ReapplyOnDrift pulumi.BoolPtrInput
+	// How long to wait for a node to come back after a reboot.
+	// The node is back when it reports a new boot ID and reaches the running or maintenance stage.
+	// Default is 10m0s.
+	RebootTimeout pulumi.StringPtrInput
 	// skipInitApply indicates that machines will be managed or configured by external tools.
 	// For example, it can serve as a source for userdata in cloud provider setups.
 	// This option helps accelerate node provisioning.
 	// Note: init node is always applied.
 	// Default is false.
 	SkipInitApply pulumi.BoolPtrInput
+	// Default duration after which a configuration applied in try mode is rolled back.
+	// Default is 1m.
+	TryTimeout pulumi.StringPtrInput
 }
 
 func (ApplyArgs) ElementType() reflect.Type {
@@ -158,10 +260,38 @@ func (o ApplyOutput) ToApplyOutputWithContext(ctx context.Context) ApplyOutput {
 	return o
 }
 
+// Expiry time of the admin kubeconfig client certificate in RFC 3339 format.
+func (o ApplyOutput) AdminCertificateExpiry() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Apply) pulumi.StringPtrOutput { return v.AdminCertificateExpiry }).(pulumi.StringPtrOutput)
+}
+
+// Kubeconfig and talosconfig of the cluster.
 func (o ApplyOutput) Credentials() CredentialsOutput {
 	return o.ApplyT(func(v *Apply) CredentialsOutput { return v.Credentials }).(CredentialsOutput)
 }
 
+// Configuration fields which differ between the desired and the running configuration, keyed by machine ID.
+// Populated only if detectDrift is enabled.
+func (o ApplyOutput) Drift() pulumi.StringArrayMapOutput {
+	return o.ApplyT(func(v *Apply) pulumi.StringArrayMapOutput { return v.Drift }).(pulumi.StringArrayMapOutput)
+}
+
+// Applied state of every machine keyed by machine ID.
+func (o ApplyOutput) Machines() MachineStatusMapOutput {
+	return o.ApplyT(func(v *Apply) MachineStatusMapOutput { return v.Machines }).(MachineStatusMapOutput)
+}
+
+// Output of `talosctl apply-config --dry-run` for machines with changed configuration, keyed by machine ID.
+// Populated only during preview. Values of keys, certificates and tokens are redacted.
+func (o ApplyOutput) PlannedConfigDiff() pulumi.StringMapOutput {
+	return o.ApplyT(func(v *Apply) pulumi.StringMapOutput { return v.PlannedConfigDiff }).(pulumi.StringMapOutput)
+}
+
+// Talosconfigs from additionalTalosconfigs keyed by name.
+func (o ApplyOutput) Talosconfigs() pulumi.StringMapOutput {
+	return o.ApplyT(func(v *Apply) pulumi.StringMapOutput { return v.Talosconfigs }).(pulumi.StringMapOutput)
+}
+
 type ApplyArrayOutput struct{ *pulumi.OutputState }
 
 func (ApplyArrayOutput) ElementType() reflect.Type {
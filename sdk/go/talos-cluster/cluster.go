@@ -22,8 +22,11 @@ type Cluster struct {
 	ClientConfiguration ClientConfigurationOutput `pulumi:"clientConfiguration"`
 	// Generated machine configuration YAML keyed by machine ID.
 	GeneratedConfigurations pulumi.StringMapOutput `pulumi:"generatedConfigurations"`
+	// Cluster secrets bundle and admin client certificate generated on creation (YAML).
+	// It is kept in the state and never regenerated.
+	MachineSecrets pulumi.StringOutput `pulumi:"machineSecrets"`
 	// Machine information grouped by machine type.
-	Machines ApplyMachinesOutput `pulumi:"machines"`
+	Machines MachinesByTypeOutput `pulumi:"machines"`
 }
 
 // NewCluster registers a new resource with the given unique name, arguments, and options.
@@ -45,6 +48,14 @@ func NewCluster(ctx *pulumi.Context,
 	if args.TalosVersionContract == nil {
 		args.TalosVersionContract = pulumi.StringPtr("v1.12.0")
 	}
+	if args.ExistingSecrets != nil {
+		args.ExistingSecrets = pulumi.ToSecret(args.ExistingSecrets).(pulumi.StringPtrInput)
+	}
+	secrets := pulumi.AdditionalSecretOutputs([]string{
+		"generatedConfigurations",
+		"machineSecrets",
+	})
+	opts = append(opts, secrets)
 	opts = internal.PkgResourceDefaultOpts(opts)
 	var resource Cluster
 	err := ctx.RegisterRemoteComponentResource("talos-cluster:index:Cluster", name, args, &resource, opts...)
@@ -61,13 +72,17 @@ type clusterArgs struct {
 	ClusterMachines []ClusterMachines `pulumi:"clusterMachines"`
 	// Name of the cluster
 	ClusterName string `pulumi:"clusterName"`
+	// Existing secrets bundle (YAML) to use instead of a generated one.
+	// Accepts the output of `talosctl gen secrets` or the machineSecrets of the pulumiverse talos Secrets resource.
+	// It is only read on creation of machineSecrets, e.g. to adopt a cluster created outside of the stack.
+	ExistingSecrets *string `pulumi:"existingSecrets"`
 	// Kubernetes version to install.
 	// Default is v1.33.0.
 	KubernetesVersion *string `pulumi:"kubernetesVersion"`
 	// Version of Talos features used for configuration generation.
 	// Do not confuse this with the talosImage property.
-	// Used in NewSecrets() and GetConfigurationOutput() resources.
-	// This property is immutable to prevent version conflicts across provider updates.
+	// Used to generate the secrets bundle and machine configurations.
+	// This property is immutable: the value used on creation is kept in machineSecrets.
 	// See issue: https://github.com/siderolabs/terraform-provider-talos/issues/168
 	// The default value is based on gendata.VersionTag, current: v1.12.0.
 	TalosVersionContract *string `pulumi:"talosVersionContract"`
@@ -81,13 +96,17 @@ type ClusterArgs struct {
 	ClusterMachines ClusterMachinesArrayInput
 	// Name of the cluster
 	ClusterName string
+	// Existing secrets bundle (YAML) to use instead of a generated one.
+	// Accepts the output of `talosctl gen secrets` or the machineSecrets of the pulumiverse talos Secrets resource.
+	// It is only read on creation of machineSecrets, e.g. to adopt a cluster created outside of the stack.
+	ExistingSecrets pulumi.StringPtrInput
 	// Kubernetes version to install.
 	// Default is v1.33.0.
 	KubernetesVersion pulumi.StringPtrInput
 	// Version of Talos features used for configuration generation.
 	// Do not confuse this with the talosImage property.
-	// Used in NewSecrets() and GetConfigurationOutput() resources.
-	// This property is immutable to prevent version conflicts across provider updates.
+	// Used to generate the secrets bundle and machine configurations.
+	// This property is immutable: the value used on creation is kept in machineSecrets.
 	// See issue: https://github.com/siderolabs/terraform-provider-talos/issues/168
 	// The default value is based on gendata.VersionTag, current: v1.12.0.
 	TalosVersionContract pulumi.StringPtrInput
@@ -97,6 +116,140 @@ func (ClusterArgs) ElementType() reflect.Type {
 	return reflect.TypeOf((*clusterArgs)(nil)).Elem()
 }
 
+// Generate a configuration for a new machine of the role, which joins the cluster.
+// It uses the cluster secrets and the default installation image.
+func (r *Cluster) GetJoinConfig(ctx *pulumi.Context, args *ClusterGetJoinConfigArgs) (ClusterGetJoinConfigResultOutput, error) {
+	out, err := ctx.Call("talos-cluster:index:Cluster/getJoinConfig", args, ClusterGetJoinConfigResultOutput{}, r)
+	if err != nil {
+		return ClusterGetJoinConfigResultOutput{}, err
+	}
+	return out.(ClusterGetJoinConfigResultOutput), nil
+}
+
+type clusterGetJoinConfigArgs struct {
+	// Machine type of the new machine: controlplane or worker.
+	Role string `pulumi:"role"`
+}
+
+// The set of arguments for the GetJoinConfig method of the Cluster resource.
+type ClusterGetJoinConfigArgs struct {
+	// Machine type of the new machine: controlplane or worker.
+	Role pulumi.StringInput
+}
+
+func (ClusterGetJoinConfigArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*clusterGetJoinConfigArgs)(nil)).Elem()
+}
+
+type ClusterGetJoinConfigResult struct {
+	// Machine configuration YAML.
+	Configuration string `pulumi:"configuration"`
+}
+
+type ClusterGetJoinConfigResultOutput struct{ *pulumi.OutputState }
+
+func (ClusterGetJoinConfigResultOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*ClusterGetJoinConfigResult)(nil)).Elem()
+}
+
+// Machine configuration YAML.
+func (o ClusterGetJoinConfigResultOutput) Configuration() pulumi.StringOutput {
+	return o.ApplyT(func(v ClusterGetJoinConfigResult) string { return v.Configuration }).(pulumi.StringOutput)
+}
+
+// Get the generated configuration of a machine merged with extra patches. No node is contacted.
+func (r *Cluster) GetMachineConfig(ctx *pulumi.Context, args *ClusterGetMachineConfigArgs) (ClusterGetMachineConfigResultOutput, error) {
+	out, err := ctx.Call("talos-cluster:index:Cluster/getMachineConfig", args, ClusterGetMachineConfigResultOutput{}, r)
+	if err != nil {
+		return ClusterGetMachineConfigResultOutput{}, err
+	}
+	return out.(ClusterGetMachineConfigResultOutput), nil
+}
+
+type clusterGetMachineConfigArgs struct {
+	// Patches to merge into the configuration in the same way as configPatches.
+	ExtraPatches []string `pulumi:"extraPatches"`
+	// ID of the machine.
+	MachineId string `pulumi:"machineId"`
+}
+
+// The set of arguments for the GetMachineConfig method of the Cluster resource.
+type ClusterGetMachineConfigArgs struct {
+	// Patches to merge into the configuration in the same way as configPatches.
+	ExtraPatches pulumi.StringArrayInput
+	// ID of the machine.
+	MachineId pulumi.StringInput
+}
+
+func (ClusterGetMachineConfigArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*clusterGetMachineConfigArgs)(nil)).Elem()
+}
+
+type ClusterGetMachineConfigResult struct {
+	// Machine configuration YAML.
+	Configuration string `pulumi:"configuration"`
+}
+
+type ClusterGetMachineConfigResultOutput struct{ *pulumi.OutputState }
+
+func (ClusterGetMachineConfigResultOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*ClusterGetMachineConfigResult)(nil)).Elem()
+}
+
+// Machine configuration YAML.
+func (o ClusterGetMachineConfigResultOutput) Configuration() pulumi.StringOutput {
+	return o.ApplyT(func(v ClusterGetMachineConfigResult) string { return v.Configuration }).(pulumi.StringOutput)
+}
+
+// Issue a talosconfig with a new client certificate signed by the Talos CA of the cluster.
+// A new certificate is issued on every call.
+func (r *Cluster) GetTalosconfig(ctx *pulumi.Context, args *ClusterGetTalosconfigArgs) (ClusterGetTalosconfigResultOutput, error) {
+	out, err := ctx.Call("talos-cluster:index:Cluster/getTalosconfig", args, ClusterGetTalosconfigResultOutput{}, r)
+	if err != nil {
+		return ClusterGetTalosconfigResultOutput{}, err
+	}
+	return out.(ClusterGetTalosconfigResultOutput), nil
+}
+
+type clusterGetTalosconfigArgs struct {
+	// Endpoints of the talosconfig context.
+	Endpoints []string `pulumi:"endpoints"`
+	// Nodes of the talosconfig context.
+	Nodes []string `pulumi:"nodes"`
+	// Talos API roles of the certificate, e.g. os:reader. Default is os:admin.
+	Roles []string `pulumi:"roles"`
+}
+
+// The set of arguments for the GetTalosconfig method of the Cluster resource.
+type ClusterGetTalosconfigArgs struct {
+	// Endpoints of the talosconfig context.
+	Endpoints pulumi.StringArrayInput
+	// Nodes of the talosconfig context.
+	Nodes pulumi.StringArrayInput
+	// Talos API roles of the certificate, e.g. os:reader. Default is os:admin.
+	Roles pulumi.StringArrayInput
+}
+
+func (ClusterGetTalosconfigArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*clusterGetTalosconfigArgs)(nil)).Elem()
+}
+
+type ClusterGetTalosconfigResult struct {
+	// The talosconfig.
+	Talosconfig string `pulumi:"talosconfig"`
+}
+
+type ClusterGetTalosconfigResultOutput struct{ *pulumi.OutputState }
+
+func (ClusterGetTalosconfigResultOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*ClusterGetTalosconfigResult)(nil)).Elem()
+}
+
+// The talosconfig.
+func (o ClusterGetTalosconfigResultOutput) Talosconfig() pulumi.StringOutput {
+	return o.ApplyT(func(v ClusterGetTalosconfigResult) string { return v.Talosconfig }).(pulumi.StringOutput)
+}
+
 type ClusterInput interface {
 	pulumi.Input
 
@@ -190,9 +343,15 @@ func (o ClusterOutput) GeneratedConfigurations() pulumi.StringMapOutput {
 	return o.ApplyT(func(v *Cluster) pulumi.StringMapOutput { return v.GeneratedConfigurations }).(pulumi.StringMapOutput)
 }
 
+// Cluster secrets bundle and admin client certificate generated on creation (YAML).
+// It is kept in the state and never regenerated.
+func (o ClusterOutput) MachineSecrets() pulumi.StringOutput {
+	return o.ApplyT(func(v *Cluster) pulumi.StringOutput { return v.MachineSecrets }).(pulumi.StringOutput)
+}
+
 // Machine information grouped by machine type.
-func (o ClusterOutput) Machines() ApplyMachinesOutput {
-	return o.ApplyT(func(v *Cluster) ApplyMachinesOutput { return v.Machines }).(ApplyMachinesOutput)
+func (o ClusterOutput) Machines() MachinesByTypeOutput {
+	return o.ApplyT(func(v *Cluster) MachinesByTypeOutput { return v.Machines }).(MachinesByTypeOutput)
 }
 
 type ClusterArrayOutput struct{ *pulumi.OutputState }
@@ -240,6 +399,9 @@ func init() {
 	pulumi.RegisterInputType(reflect.TypeOf((*ClusterArrayInput)(nil)).Elem(), ClusterArray{})
 	pulumi.RegisterInputType(reflect.TypeOf((*ClusterMapInput)(nil)).Elem(), ClusterMap{})
 	pulumi.RegisterOutputType(ClusterOutput{})
+	pulumi.RegisterOutputType(ClusterGetJoinConfigResultOutput{})
+	pulumi.RegisterOutputType(ClusterGetMachineConfigResultOutput{})
+	pulumi.RegisterOutputType(ClusterGetTalosconfigResultOutput{})
 	pulumi.RegisterOutputType(ClusterArrayOutput{})
 	pulumi.RegisterOutputType(ClusterMapOutput{})
 }
@@ -0,0 +1,82 @@
+// Code generated by pulumi-language-go DO NOT EDIT.
+// *** WARNING: Do not edit by hand unless you're certain you know what you are doing! ***
+
+package taloscluster
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/spigell/pulumi-talos-cluster/sdk/go/talos-cluster/internal"
+)
+
+// Get the observed status of Talos nodes:
+//   - Talos and kubelet versions
+//   - Machine type and stage
+//   - Etcd membership
+//     Nodes which can't be reached are reported with an error instead of failing the whole call.
+func GetClusterStatus(ctx *pulumi.Context, args *GetClusterStatusArgs, opts ...pulumi.InvokeOption) (*GetClusterStatusResult, error) {
+	opts = internal.PkgInvokeDefaultOpts(opts)
+	var rv GetClusterStatusResult
+	err := ctx.Invoke("talos-cluster:index:getClusterStatus", args, &rv, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &rv, nil
+}
+
+type GetClusterStatusArgs struct {
+	// Client configuration for bootstrapping and applying resources.
+	ClientConfiguration ClientConfiguration `pulumi:"clientConfiguration"`
+	// IP addresses of nodes to query. Every node is used as its own endpoint.
+	Nodes []string `pulumi:"nodes"`
+}
+
+type GetClusterStatusResult struct {
+	// Status of every requested node in the same order.
+	Nodes []NodeStatus `pulumi:"nodes"`
+}
+
+func GetClusterStatusOutput(ctx *pulumi.Context, args GetClusterStatusOutputArgs, opts ...pulumi.InvokeOption) GetClusterStatusResultOutput {
+	return pulumi.ToOutputWithContext(ctx.Context(), args).
+		ApplyT(func(v interface{}) (GetClusterStatusResultOutput, error) {
+			args := v.(GetClusterStatusArgs)
+			options := pulumi.InvokeOutputOptions{InvokeOptions: internal.PkgInvokeDefaultOpts(opts)}
+			return ctx.InvokeOutput("talos-cluster:index:getClusterStatus", args, GetClusterStatusResultOutput{}, options).(GetClusterStatusResultOutput), nil
+		}).(GetClusterStatusResultOutput)
+}
+
+type GetClusterStatusOutputArgs struct {
+	// Client configuration for bootstrapping and applying resources.
+	ClientConfiguration ClientConfigurationInput `pulumi:"clientConfiguration"`
+	// IP addresses of nodes to query. Every node is used as its own endpoint.
+	Nodes pulumi.StringArrayInput `pulumi:"nodes"`
+}
+
+func (GetClusterStatusOutputArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*GetClusterStatusArgs)(nil)).Elem()
+}
+
+type GetClusterStatusResultOutput struct{ *pulumi.OutputState }
+
+func (GetClusterStatusResultOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*GetClusterStatusResult)(nil)).Elem()
+}
+
+func (o GetClusterStatusResultOutput) ToGetClusterStatusResultOutput() GetClusterStatusResultOutput {
+	return o
+}
+
+func (o GetClusterStatusResultOutput) ToGetClusterStatusResultOutputWithContext(ctx context.Context) GetClusterStatusResultOutput {
+	return o
+}
+
+// Status of every requested node in the same order.
+func (o GetClusterStatusResultOutput) Nodes() NodeStatusArrayOutput {
+	return o.ApplyT(func(v GetClusterStatusResult) []NodeStatus { return v.Nodes }).(NodeStatusArrayOutput)
+}
+
+func init() {
+	pulumi.RegisterOutputType(GetClusterStatusResultOutput{})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spigell/pulumi-talos-cluster/sdk/go/talos-cluster/internal"
 )
 
+// The provider type for the talos-cluster package.
 type Provider struct {
 	pulumi.ProviderResourceState
 }
@@ -32,10 +33,72 @@ func NewProvider(ctx *pulumi.Context,
 }
 
 type providerArgs struct {
+	// Diagnostics collected from a node when `talosctl upgrade` or `talosctl apply-config` fails.
+	// `basic` collects dmesg, the service list, logs of machined (and etcd on controlplanes during upgrade) and the machine status.
+	// `support` adds a `talosctl support` archive, which takes a few minutes.
+	// The path of the bundle is a part of the error message.
+	// Default is basic.
+	DebugBundle *DebugBundle `pulumi:"debugBundle"`
+	// Root directory for debug bundles.
+	// Bundles are written to `<debugBundleDir>/<stack>/<cluster>/<stage>-<machine>/<time>` and are not removed.
+	// Default is the debug directory inside the working directories of workDir.
+	DebugBundleDir *string `pulumi:"debugBundleDir"`
+	// Migration of stacks created with pulumiverse/talos resources.
+	// `aliases` registers the initial apply and bootstrap with aliases to the former resources, they are rerun, but skip configured nodes.
+	// `import` takes the initial apply and bootstrap as done without contacting nodes.
+	// In both modes the preview fails if a node is not configured, its etcd is not bootstrapped
+	// or it runs other cluster secrets than the generated configuration.
+	// Unset it after the migration.
+	MigrationMode *MigrationMode `pulumi:"migrationMode"`
+	// Path to the talosctl binary or to a directory with talosctl binaries for different Talos versions.
+	// If a directory is set, the binary matching the Talos version of a machine image is used for the machine,
+	// and the newest one otherwise.
+	// Default is talosctl from PATH.
+	TalosctlPath *string `pulumi:"talosctlPath"`
+	// Reaction on skew between the talosctl version and the Talos version of a machine image.
+	// The version of every binary is checked via `talosctl version --client` on provider start.
+	// Default is warn.
+	TalosctlVersionCheck *TalosctlVersionCheck `pulumi:"talosctlVersionCheck"`
+	// Root directory for talosctl working directories.
+	// Files with credentials and machine configurations are written to `<workDir>/talos-cluster-<uid>`,
+	// which is accessible only by the current user.
+	// Default is the system temporary directory.
+	WorkDir *string `pulumi:"workDir"`
 }
 
 // The set of arguments for constructing a Provider resource.
 type ProviderArgs struct {
+	// Diagnostics collected from a node when `talosctl upgrade` or `talosctl apply-config` fails.
+	// `basic` collects dmesg, the service list, logs of machined (and etcd on controlplanes during upgrade) and the machine status.
+	// `support` adds a `talosctl support` archive, which takes a few minutes.
+	// The path of the bundle is a part of the error message.
+	// Default is basic.
+	DebugBundle DebugBundlePtrInput
+	// Root directory for debug bundles.
+	// Bundles are written to `<debugBundleDir>/<stack>/<cluster>/<stage>-<machine>/<time>` and are not removed.
+	// Default is the debug directory inside the working directories of workDir.
+	DebugBundleDir pulumi.StringPtrInput
+	// Migration of stacks created with pulumiverse/talos resources.
+	// `aliases` registers the initial apply and bootstrap with aliases to the former resources, they are rerun, but skip configured nodes.
+	// `import` takes the initial apply and bootstrap as done without contacting nodes.
+	// In both modes the preview fails if a node is not configured, its etcd is not bootstrapped
+	// or it runs other cluster secrets than the generated configuration.
+	// Unset it after the migration.
+	MigrationMode MigrationModePtrInput
+	// Path to the talosctl binary or to a directory with talosctl binaries for different Talos versions.
+	// If a directory is set, the binary matching the Talos version of a machine image is used for the machine,
+	// and the newest one otherwise.
+	// Default is talosctl from PATH.
+	TalosctlPath pulumi.StringPtrInput
+	// Reaction on skew between the talosctl version and the Talos version of a machine image.
+	// The version of every binary is checked via `talosctl version --client` on provider start.
+	// Default is warn.
+	TalosctlVersionCheck TalosctlVersionCheckPtrInput
+	// Root directory for talosctl working directories.
+	// Files with credentials and machine configurations are written to `<workDir>/talos-cluster-<uid>`,
+	// which is accessible only by the current user.
+	// Default is the system temporary directory.
+	WorkDir pulumi.StringPtrInput
 }
 
 func (ProviderArgs) ElementType() reflect.Type {
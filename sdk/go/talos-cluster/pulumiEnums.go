@@ -10,6 +10,346 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
+// Modes of talosctl apply-config
+type ApplyMode string
+
+const (
+	ApplyModeAuto       = ApplyMode("auto")
+	ApplyMode_No_Reboot = ApplyMode("no-reboot")
+	ApplyModeReboot     = ApplyMode("reboot")
+	ApplyModeStaged     = ApplyMode("staged")
+	ApplyModeTry        = ApplyMode("try")
+)
+
+func (ApplyMode) ElementType() reflect.Type {
+	return reflect.TypeOf((*ApplyMode)(nil)).Elem()
+}
+
+func (e ApplyMode) ToApplyModeOutput() ApplyModeOutput {
+	return pulumi.ToOutput(e).(ApplyModeOutput)
+}
+
+func (e ApplyMode) ToApplyModeOutputWithContext(ctx context.Context) ApplyModeOutput {
+	return pulumi.ToOutputWithContext(ctx, e).(ApplyModeOutput)
+}
+
+func (e ApplyMode) ToApplyModePtrOutput() ApplyModePtrOutput {
+	return e.ToApplyModePtrOutputWithContext(context.Background())
+}
+
+func (e ApplyMode) ToApplyModePtrOutputWithContext(ctx context.Context) ApplyModePtrOutput {
+	return ApplyMode(e).ToApplyModeOutputWithContext(ctx).ToApplyModePtrOutputWithContext(ctx)
+}
+
+func (e ApplyMode) ToStringOutput() pulumi.StringOutput {
+	return pulumi.ToOutput(pulumi.String(e)).(pulumi.StringOutput)
+}
+
+func (e ApplyMode) ToStringOutputWithContext(ctx context.Context) pulumi.StringOutput {
+	return pulumi.ToOutputWithContext(ctx, pulumi.String(e)).(pulumi.StringOutput)
+}
+
+func (e ApplyMode) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return pulumi.String(e).ToStringPtrOutputWithContext(context.Background())
+}
+
+func (e ApplyMode) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return pulumi.String(e).ToStringOutputWithContext(ctx).ToStringPtrOutputWithContext(ctx)
+}
+
+type ApplyModeOutput struct{ *pulumi.OutputState }
+
+func (ApplyModeOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*ApplyMode)(nil)).Elem()
+}
+
+func (o ApplyModeOutput) ToApplyModeOutput() ApplyModeOutput {
+	return o
+}
+
+func (o ApplyModeOutput) ToApplyModeOutputWithContext(ctx context.Context) ApplyModeOutput {
+	return o
+}
+
+func (o ApplyModeOutput) ToApplyModePtrOutput() ApplyModePtrOutput {
+	return o.ToApplyModePtrOutputWithContext(context.Background())
+}
+
+func (o ApplyModeOutput) ToApplyModePtrOutputWithContext(ctx context.Context) ApplyModePtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v ApplyMode) *ApplyMode {
+		return &v
+	}).(ApplyModePtrOutput)
+}
+
+func (o ApplyModeOutput) ToStringOutput() pulumi.StringOutput {
+	return o.ToStringOutputWithContext(context.Background())
+}
+
+func (o ApplyModeOutput) ToStringOutputWithContext(ctx context.Context) pulumi.StringOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e ApplyMode) string {
+		return string(e)
+	}).(pulumi.StringOutput)
+}
+
+func (o ApplyModeOutput) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return o.ToStringPtrOutputWithContext(context.Background())
+}
+
+func (o ApplyModeOutput) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e ApplyMode) *string {
+		v := string(e)
+		return &v
+	}).(pulumi.StringPtrOutput)
+}
+
+type ApplyModePtrOutput struct{ *pulumi.OutputState }
+
+func (ApplyModePtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**ApplyMode)(nil)).Elem()
+}
+
+func (o ApplyModePtrOutput) ToApplyModePtrOutput() ApplyModePtrOutput {
+	return o
+}
+
+func (o ApplyModePtrOutput) ToApplyModePtrOutputWithContext(ctx context.Context) ApplyModePtrOutput {
+	return o
+}
+
+func (o ApplyModePtrOutput) Elem() ApplyModeOutput {
+	return o.ApplyT(func(v *ApplyMode) ApplyMode {
+		if v != nil {
+			return *v
+		}
+		var ret ApplyMode
+		return ret
+	}).(ApplyModeOutput)
+}
+
+func (o ApplyModePtrOutput) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return o.ToStringPtrOutputWithContext(context.Background())
+}
+
+func (o ApplyModePtrOutput) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e *ApplyMode) *string {
+		if e == nil {
+			return nil
+		}
+		v := string(*e)
+		return &v
+	}).(pulumi.StringPtrOutput)
+}
+
+// ApplyModeInput is an input type that accepts values of the ApplyMode enum
+// A concrete instance of `ApplyModeInput` can be one of the following:
+//
+//	ApplyModeAuto
+//	ApplyMode_No_Reboot
+//	ApplyModeReboot
+//	ApplyModeStaged
+//	ApplyModeTry
+type ApplyModeInput interface {
+	pulumi.Input
+
+	ToApplyModeOutput() ApplyModeOutput
+	ToApplyModeOutputWithContext(context.Context) ApplyModeOutput
+}
+
+var applyModePtrType = reflect.TypeOf((**ApplyMode)(nil)).Elem()
+
+type ApplyModePtrInput interface {
+	pulumi.Input
+
+	ToApplyModePtrOutput() ApplyModePtrOutput
+	ToApplyModePtrOutputWithContext(context.Context) ApplyModePtrOutput
+}
+
+type applyModePtr string
+
+func ApplyModePtr(v string) ApplyModePtrInput {
+	return (*applyModePtr)(&v)
+}
+
+func (*applyModePtr) ElementType() reflect.Type {
+	return applyModePtrType
+}
+
+func (in *applyModePtr) ToApplyModePtrOutput() ApplyModePtrOutput {
+	return pulumi.ToOutput(in).(ApplyModePtrOutput)
+}
+
+func (in *applyModePtr) ToApplyModePtrOutputWithContext(ctx context.Context) ApplyModePtrOutput {
+	return pulumi.ToOutputWithContext(ctx, in).(ApplyModePtrOutput)
+}
+
+// Diagnostics collected from a node after a failed operation
+type DebugBundle string
+
+const (
+	DebugBundleNone    = DebugBundle("none")
+	DebugBundleBasic   = DebugBundle("basic")
+	DebugBundleSupport = DebugBundle("support")
+)
+
+func (DebugBundle) ElementType() reflect.Type {
+	return reflect.TypeOf((*DebugBundle)(nil)).Elem()
+}
+
+func (e DebugBundle) ToDebugBundleOutput() DebugBundleOutput {
+	return pulumi.ToOutput(e).(DebugBundleOutput)
+}
+
+func (e DebugBundle) ToDebugBundleOutputWithContext(ctx context.Context) DebugBundleOutput {
+	return pulumi.ToOutputWithContext(ctx, e).(DebugBundleOutput)
+}
+
+func (e DebugBundle) ToDebugBundlePtrOutput() DebugBundlePtrOutput {
+	return e.ToDebugBundlePtrOutputWithContext(context.Background())
+}
+
+func (e DebugBundle) ToDebugBundlePtrOutputWithContext(ctx context.Context) DebugBundlePtrOutput {
+	return DebugBundle(e).ToDebugBundleOutputWithContext(ctx).ToDebugBundlePtrOutputWithContext(ctx)
+}
+
+func (e DebugBundle) ToStringOutput() pulumi.StringOutput {
+	return pulumi.ToOutput(pulumi.String(e)).(pulumi.StringOutput)
+}
+
+func (e DebugBundle) ToStringOutputWithContext(ctx context.Context) pulumi.StringOutput {
+	return pulumi.ToOutputWithContext(ctx, pulumi.String(e)).(pulumi.StringOutput)
+}
+
+func (e DebugBundle) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return pulumi.String(e).ToStringPtrOutputWithContext(context.Background())
+}
+
+func (e DebugBundle) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return pulumi.String(e).ToStringOutputWithContext(ctx).ToStringPtrOutputWithContext(ctx)
+}
+
+type DebugBundleOutput struct{ *pulumi.OutputState }
+
+func (DebugBundleOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*DebugBundle)(nil)).Elem()
+}
+
+func (o DebugBundleOutput) ToDebugBundleOutput() DebugBundleOutput {
+	return o
+}
+
+func (o DebugBundleOutput) ToDebugBundleOutputWithContext(ctx context.Context) DebugBundleOutput {
+	return o
+}
+
+func (o DebugBundleOutput) ToDebugBundlePtrOutput() DebugBundlePtrOutput {
+	return o.ToDebugBundlePtrOutputWithContext(context.Background())
+}
+
+func (o DebugBundleOutput) ToDebugBundlePtrOutputWithContext(ctx context.Context) DebugBundlePtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v DebugBundle) *DebugBundle {
+		return &v
+	}).(DebugBundlePtrOutput)
+}
+
+func (o DebugBundleOutput) ToStringOutput() pulumi.StringOutput {
+	return o.ToStringOutputWithContext(context.Background())
+}
+
+func (o DebugBundleOutput) ToStringOutputWithContext(ctx context.Context) pulumi.StringOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e DebugBundle) string {
+		return string(e)
+	}).(pulumi.StringOutput)
+}
+
+func (o DebugBundleOutput) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return o.ToStringPtrOutputWithContext(context.Background())
+}
+
+func (o DebugBundleOutput) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e DebugBundle) *string {
+		v := string(e)
+		return &v
+	}).(pulumi.StringPtrOutput)
+}
+
+type DebugBundlePtrOutput struct{ *pulumi.OutputState }
+
+func (DebugBundlePtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**DebugBundle)(nil)).Elem()
+}
+
+func (o DebugBundlePtrOutput) ToDebugBundlePtrOutput() DebugBundlePtrOutput {
+	return o
+}
+
+func (o DebugBundlePtrOutput) ToDebugBundlePtrOutputWithContext(ctx context.Context) DebugBundlePtrOutput {
+	return o
+}
+
+func (o DebugBundlePtrOutput) Elem() DebugBundleOutput {
+	return o.ApplyT(func(v *DebugBundle) DebugBundle {
+		if v != nil {
+			return *v
+		}
+		var ret DebugBundle
+		return ret
+	}).(DebugBundleOutput)
+}
+
+func (o DebugBundlePtrOutput) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return o.ToStringPtrOutputWithContext(context.Background())
+}
+
+func (o DebugBundlePtrOutput) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e *DebugBundle) *string {
+		if e == nil {
+			return nil
+		}
+		v := string(*e)
+		return &v
+	}).(pulumi.StringPtrOutput)
+}
+
+// DebugBundleInput is an input type that accepts values of the DebugBundle enum
+// A concrete instance of `DebugBundleInput` can be one of the following:
+//
+//	DebugBundleNone
+//	DebugBundleBasic
+//	DebugBundleSupport
+type DebugBundleInput interface {
+	pulumi.Input
+
+	ToDebugBundleOutput() DebugBundleOutput
+	ToDebugBundleOutputWithContext(context.Context) DebugBundleOutput
+}
+
+var debugBundlePtrType = reflect.TypeOf((**DebugBundle)(nil)).Elem()
+
+type DebugBundlePtrInput interface {
+	pulumi.Input
+
+	ToDebugBundlePtrOutput() DebugBundlePtrOutput
+	ToDebugBundlePtrOutputWithContext(context.Context) DebugBundlePtrOutput
+}
+
+type debugBundlePtr string
+
+func DebugBundlePtr(v string) DebugBundlePtrInput {
+	return (*debugBundlePtr)(&v)
+}
+
+func (*debugBundlePtr) ElementType() reflect.Type {
+	return debugBundlePtrType
+}
+
+func (in *debugBundlePtr) ToDebugBundlePtrOutput() DebugBundlePtrOutput {
+	return pulumi.ToOutput(in).(DebugBundlePtrOutput)
+}
+
+func (in *debugBundlePtr) ToDebugBundlePtrOutputWithContext(ctx context.Context) DebugBundlePtrOutput {
+	return pulumi.ToOutputWithContext(ctx, in).(DebugBundlePtrOutput)
+}
+
 // Allowed machine types
 type MachineTypes string
 
@@ -178,9 +518,455 @@ func (in *machineTypesPtr) ToMachineTypesPtrOutputWithContext(ctx context.Contex
 	return pulumi.ToOutputWithContext(ctx, in).(MachineTypesPtrOutput)
 }
 
+// Modes of migration from pulumiverse/talos resources
+type MigrationMode string
+
+const (
+	MigrationModeAliases = MigrationMode("aliases")
+	MigrationModeImport  = MigrationMode("import")
+)
+
+func (MigrationMode) ElementType() reflect.Type {
+	return reflect.TypeOf((*MigrationMode)(nil)).Elem()
+}
+
+func (e MigrationMode) ToMigrationModeOutput() MigrationModeOutput {
+	return pulumi.ToOutput(e).(MigrationModeOutput)
+}
+
+func (e MigrationMode) ToMigrationModeOutputWithContext(ctx context.Context) MigrationModeOutput {
+	return pulumi.ToOutputWithContext(ctx, e).(MigrationModeOutput)
+}
+
+func (e MigrationMode) ToMigrationModePtrOutput() MigrationModePtrOutput {
+	return e.ToMigrationModePtrOutputWithContext(context.Background())
+}
+
+func (e MigrationMode) ToMigrationModePtrOutputWithContext(ctx context.Context) MigrationModePtrOutput {
+	return MigrationMode(e).ToMigrationModeOutputWithContext(ctx).ToMigrationModePtrOutputWithContext(ctx)
+}
+
+func (e MigrationMode) ToStringOutput() pulumi.StringOutput {
+	return pulumi.ToOutput(pulumi.String(e)).(pulumi.StringOutput)
+}
+
+func (e MigrationMode) ToStringOutputWithContext(ctx context.Context) pulumi.StringOutput {
+	return pulumi.ToOutputWithContext(ctx, pulumi.String(e)).(pulumi.StringOutput)
+}
+
+func (e MigrationMode) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return pulumi.String(e).ToStringPtrOutputWithContext(context.Background())
+}
+
+func (e MigrationMode) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return pulumi.String(e).ToStringOutputWithContext(ctx).ToStringPtrOutputWithContext(ctx)
+}
+
+type MigrationModeOutput struct{ *pulumi.OutputState }
+
+func (MigrationModeOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*MigrationMode)(nil)).Elem()
+}
+
+func (o MigrationModeOutput) ToMigrationModeOutput() MigrationModeOutput {
+	return o
+}
+
+func (o MigrationModeOutput) ToMigrationModeOutputWithContext(ctx context.Context) MigrationModeOutput {
+	return o
+}
+
+func (o MigrationModeOutput) ToMigrationModePtrOutput() MigrationModePtrOutput {
+	return o.ToMigrationModePtrOutputWithContext(context.Background())
+}
+
+func (o MigrationModeOutput) ToMigrationModePtrOutputWithContext(ctx context.Context) MigrationModePtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v MigrationMode) *MigrationMode {
+		return &v
+	}).(MigrationModePtrOutput)
+}
+
+func (o MigrationModeOutput) ToStringOutput() pulumi.StringOutput {
+	return o.ToStringOutputWithContext(context.Background())
+}
+
+func (o MigrationModeOutput) ToStringOutputWithContext(ctx context.Context) pulumi.StringOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e MigrationMode) string {
+		return string(e)
+	}).(pulumi.StringOutput)
+}
+
+func (o MigrationModeOutput) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return o.ToStringPtrOutputWithContext(context.Background())
+}
+
+func (o MigrationModeOutput) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e MigrationMode) *string {
+		v := string(e)
+		return &v
+	}).(pulumi.StringPtrOutput)
+}
+
+type MigrationModePtrOutput struct{ *pulumi.OutputState }
+
+func (MigrationModePtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**MigrationMode)(nil)).Elem()
+}
+
+func (o MigrationModePtrOutput) ToMigrationModePtrOutput() MigrationModePtrOutput {
+	return o
+}
+
+func (o MigrationModePtrOutput) ToMigrationModePtrOutputWithContext(ctx context.Context) MigrationModePtrOutput {
+	return o
+}
+
+func (o MigrationModePtrOutput) Elem() MigrationModeOutput {
+	return o.ApplyT(func(v *MigrationMode) MigrationMode {
+		if v != nil {
+			return *v
+		}
+		var ret MigrationMode
+		return ret
+	}).(MigrationModeOutput)
+}
+
+func (o MigrationModePtrOutput) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return o.ToStringPtrOutputWithContext(context.Background())
+}
+
+func (o MigrationModePtrOutput) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e *MigrationMode) *string {
+		if e == nil {
+			return nil
+		}
+		v := string(*e)
+		return &v
+	}).(pulumi.StringPtrOutput)
+}
+
+// MigrationModeInput is an input type that accepts values of the MigrationMode enum
+// A concrete instance of `MigrationModeInput` can be one of the following:
+//
+//	MigrationModeAliases
+//	MigrationModeImport
+type MigrationModeInput interface {
+	pulumi.Input
+
+	ToMigrationModeOutput() MigrationModeOutput
+	ToMigrationModeOutputWithContext(context.Context) MigrationModeOutput
+}
+
+var migrationModePtrType = reflect.TypeOf((**MigrationMode)(nil)).Elem()
+
+type MigrationModePtrInput interface {
+	pulumi.Input
+
+	ToMigrationModePtrOutput() MigrationModePtrOutput
+	ToMigrationModePtrOutputWithContext(context.Context) MigrationModePtrOutput
+}
+
+type migrationModePtr string
+
+func MigrationModePtr(v string) MigrationModePtrInput {
+	return (*migrationModePtr)(&v)
+}
+
+func (*migrationModePtr) ElementType() reflect.Type {
+	return migrationModePtrType
+}
+
+func (in *migrationModePtr) ToMigrationModePtrOutput() MigrationModePtrOutput {
+	return pulumi.ToOutput(in).(MigrationModePtrOutput)
+}
+
+func (in *migrationModePtr) ToMigrationModePtrOutputWithContext(ctx context.Context) MigrationModePtrOutput {
+	return pulumi.ToOutputWithContext(ctx, in).(MigrationModePtrOutput)
+}
+
+// Operations which change a machine
+type Operation string
+
+const (
+	OperationUnknown        = Operation("unknown")
+	Operation_Initial_Apply = Operation("initial-apply")
+	OperationUpgrade        = Operation("upgrade")
+	Operation_Upgrade_K8s   = Operation("upgrade-k8s")
+	Operation_Apply_Config  = Operation("apply-config")
+)
+
+type OperationOutput struct{ *pulumi.OutputState }
+
+func (OperationOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*Operation)(nil)).Elem()
+}
+
+func (o OperationOutput) ToOperationOutput() OperationOutput {
+	return o
+}
+
+func (o OperationOutput) ToOperationOutputWithContext(ctx context.Context) OperationOutput {
+	return o
+}
+
+func (o OperationOutput) ToOperationPtrOutput() OperationPtrOutput {
+	return o.ToOperationPtrOutputWithContext(context.Background())
+}
+
+func (o OperationOutput) ToOperationPtrOutputWithContext(ctx context.Context) OperationPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v Operation) *Operation {
+		return &v
+	}).(OperationPtrOutput)
+}
+
+func (o OperationOutput) ToStringOutput() pulumi.StringOutput {
+	return o.ToStringOutputWithContext(context.Background())
+}
+
+func (o OperationOutput) ToStringOutputWithContext(ctx context.Context) pulumi.StringOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e Operation) string {
+		return string(e)
+	}).(pulumi.StringOutput)
+}
+
+func (o OperationOutput) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return o.ToStringPtrOutputWithContext(context.Background())
+}
+
+func (o OperationOutput) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e Operation) *string {
+		v := string(e)
+		return &v
+	}).(pulumi.StringPtrOutput)
+}
+
+type OperationPtrOutput struct{ *pulumi.OutputState }
+
+func (OperationPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**Operation)(nil)).Elem()
+}
+
+func (o OperationPtrOutput) ToOperationPtrOutput() OperationPtrOutput {
+	return o
+}
+
+func (o OperationPtrOutput) ToOperationPtrOutputWithContext(ctx context.Context) OperationPtrOutput {
+	return o
+}
+
+func (o OperationPtrOutput) Elem() OperationOutput {
+	return o.ApplyT(func(v *Operation) Operation {
+		if v != nil {
+			return *v
+		}
+		var ret Operation
+		return ret
+	}).(OperationOutput)
+}
+
+func (o OperationPtrOutput) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return o.ToStringPtrOutputWithContext(context.Background())
+}
+
+func (o OperationPtrOutput) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e *Operation) *string {
+		if e == nil {
+			return nil
+		}
+		v := string(*e)
+		return &v
+	}).(pulumi.StringPtrOutput)
+}
+
+// Reactions on talosctl version skew
+type TalosctlVersionCheck string
+
+const (
+	TalosctlVersionCheckWarn = TalosctlVersionCheck("warn")
+	TalosctlVersionCheckFail = TalosctlVersionCheck("fail")
+	TalosctlVersionCheckSkip = TalosctlVersionCheck("skip")
+)
+
+func (TalosctlVersionCheck) ElementType() reflect.Type {
+	return reflect.TypeOf((*TalosctlVersionCheck)(nil)).Elem()
+}
+
+func (e TalosctlVersionCheck) ToTalosctlVersionCheckOutput() TalosctlVersionCheckOutput {
+	return pulumi.ToOutput(e).(TalosctlVersionCheckOutput)
+}
+
+func (e TalosctlVersionCheck) ToTalosctlVersionCheckOutputWithContext(ctx context.Context) TalosctlVersionCheckOutput {
+	return pulumi.ToOutputWithContext(ctx, e).(TalosctlVersionCheckOutput)
+}
+
+func (e TalosctlVersionCheck) ToTalosctlVersionCheckPtrOutput() TalosctlVersionCheckPtrOutput {
+	return e.ToTalosctlVersionCheckPtrOutputWithContext(context.Background())
+}
+
+func (e TalosctlVersionCheck) ToTalosctlVersionCheckPtrOutputWithContext(ctx context.Context) TalosctlVersionCheckPtrOutput {
+	return TalosctlVersionCheck(e).ToTalosctlVersionCheckOutputWithContext(ctx).ToTalosctlVersionCheckPtrOutputWithContext(ctx)
+}
+
+func (e TalosctlVersionCheck) ToStringOutput() pulumi.StringOutput {
+	return pulumi.ToOutput(pulumi.String(e)).(pulumi.StringOutput)
+}
+
+func (e TalosctlVersionCheck) ToStringOutputWithContext(ctx context.Context) pulumi.StringOutput {
+	return pulumi.ToOutputWithContext(ctx, pulumi.String(e)).(pulumi.StringOutput)
+}
+
+func (e TalosctlVersionCheck) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return pulumi.String(e).ToStringPtrOutputWithContext(context.Background())
+}
+
+func (e TalosctlVersionCheck) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return pulumi.String(e).ToStringOutputWithContext(ctx).ToStringPtrOutputWithContext(ctx)
+}
+
+type TalosctlVersionCheckOutput struct{ *pulumi.OutputState }
+
+func (TalosctlVersionCheckOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*TalosctlVersionCheck)(nil)).Elem()
+}
+
+func (o TalosctlVersionCheckOutput) ToTalosctlVersionCheckOutput() TalosctlVersionCheckOutput {
+	return o
+}
+
+func (o TalosctlVersionCheckOutput) ToTalosctlVersionCheckOutputWithContext(ctx context.Context) TalosctlVersionCheckOutput {
+	return o
+}
+
+func (o TalosctlVersionCheckOutput) ToTalosctlVersionCheckPtrOutput() TalosctlVersionCheckPtrOutput {
+	return o.ToTalosctlVersionCheckPtrOutputWithContext(context.Background())
+}
+
+func (o TalosctlVersionCheckOutput) ToTalosctlVersionCheckPtrOutputWithContext(ctx context.Context) TalosctlVersionCheckPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v TalosctlVersionCheck) *TalosctlVersionCheck {
+		return &v
+	}).(TalosctlVersionCheckPtrOutput)
+}
+
+func (o TalosctlVersionCheckOutput) ToStringOutput() pulumi.StringOutput {
+	return o.ToStringOutputWithContext(context.Background())
+}
+
+func (o TalosctlVersionCheckOutput) ToStringOutputWithContext(ctx context.Context) pulumi.StringOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e TalosctlVersionCheck) string {
+		return string(e)
+	}).(pulumi.StringOutput)
+}
+
+func (o TalosctlVersionCheckOutput) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return o.ToStringPtrOutputWithContext(context.Background())
+}
+
+func (o TalosctlVersionCheckOutput) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e TalosctlVersionCheck) *string {
+		v := string(e)
+		return &v
+	}).(pulumi.StringPtrOutput)
+}
+
+type TalosctlVersionCheckPtrOutput struct{ *pulumi.OutputState }
+
+func (TalosctlVersionCheckPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**TalosctlVersionCheck)(nil)).Elem()
+}
+
+func (o TalosctlVersionCheckPtrOutput) ToTalosctlVersionCheckPtrOutput() TalosctlVersionCheckPtrOutput {
+	return o
+}
+
+func (o TalosctlVersionCheckPtrOutput) ToTalosctlVersionCheckPtrOutputWithContext(ctx context.Context) TalosctlVersionCheckPtrOutput {
+	return o
+}
+
+func (o TalosctlVersionCheckPtrOutput) Elem() TalosctlVersionCheckOutput {
+	return o.ApplyT(func(v *TalosctlVersionCheck) TalosctlVersionCheck {
+		if v != nil {
+			return *v
+		}
+		var ret TalosctlVersionCheck
+		return ret
+	}).(TalosctlVersionCheckOutput)
+}
+
+func (o TalosctlVersionCheckPtrOutput) ToStringPtrOutput() pulumi.StringPtrOutput {
+	return o.ToStringPtrOutputWithContext(context.Background())
+}
+
+func (o TalosctlVersionCheckPtrOutput) ToStringPtrOutputWithContext(ctx context.Context) pulumi.StringPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, e *TalosctlVersionCheck) *string {
+		if e == nil {
+			return nil
+		}
+		v := string(*e)
+		return &v
+	}).(pulumi.StringPtrOutput)
+}
+
+// TalosctlVersionCheckInput is an input type that accepts values of the TalosctlVersionCheck enum
+// A concrete instance of `TalosctlVersionCheckInput` can be one of the following:
+//
+//	TalosctlVersionCheckWarn
+//	TalosctlVersionCheckFail
+//	TalosctlVersionCheckSkip
+type TalosctlVersionCheckInput interface {
+	pulumi.Input
+
+	ToTalosctlVersionCheckOutput() TalosctlVersionCheckOutput
+	ToTalosctlVersionCheckOutputWithContext(context.Context) TalosctlVersionCheckOutput
+}
+
+var talosctlVersionCheckPtrType = reflect.TypeOf((**TalosctlVersionCheck)(nil)).Elem()
+
+type TalosctlVersionCheckPtrInput interface {
+	pulumi.Input
+
+	ToTalosctlVersionCheckPtrOutput() TalosctlVersionCheckPtrOutput
+	ToTalosctlVersionCheckPtrOutputWithContext(context.Context) TalosctlVersionCheckPtrOutput
+}
+
+type talosctlVersionCheckPtr string
+
+func TalosctlVersionCheckPtr(v string) TalosctlVersionCheckPtrInput {
+	return (*talosctlVersionCheckPtr)(&v)
+}
+
+func (*talosctlVersionCheckPtr) ElementType() reflect.Type {
+	return talosctlVersionCheckPtrType
+}
+
+func (in *talosctlVersionCheckPtr) ToTalosctlVersionCheckPtrOutput() TalosctlVersionCheckPtrOutput {
+	return pulumi.ToOutput(in).(TalosctlVersionCheckPtrOutput)
+}
+
+func (in *talosctlVersionCheckPtr) ToTalosctlVersionCheckPtrOutputWithContext(ctx context.Context) TalosctlVersionCheckPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, in).(TalosctlVersionCheckPtrOutput)
+}
+
 func init() {
+	pulumi.RegisterInputType(reflect.TypeOf((*ApplyModeInput)(nil)).Elem(), ApplyMode("auto"))
+	pulumi.RegisterInputType(reflect.TypeOf((*ApplyModePtrInput)(nil)).Elem(), ApplyMode("auto"))
+	pulumi.RegisterInputType(reflect.TypeOf((*DebugBundleInput)(nil)).Elem(), DebugBundle("none"))
+	pulumi.RegisterInputType(reflect.TypeOf((*DebugBundlePtrInput)(nil)).Elem(), DebugBundle("none"))
 	pulumi.RegisterInputType(reflect.TypeOf((*MachineTypesInput)(nil)).Elem(), MachineTypes("controlplane"))
 	pulumi.RegisterInputType(reflect.TypeOf((*MachineTypesPtrInput)(nil)).Elem(), MachineTypes("controlplane"))
+	pulumi.RegisterInputType(reflect.TypeOf((*MigrationModeInput)(nil)).Elem(), MigrationMode("aliases"))
+	pulumi.RegisterInputType(reflect.TypeOf((*MigrationModePtrInput)(nil)).Elem(), MigrationMode("aliases"))
+	pulumi.RegisterInputType(reflect.TypeOf((*TalosctlVersionCheckInput)(nil)).Elem(), TalosctlVersionCheck("warn"))
+	pulumi.RegisterInputType(reflect.TypeOf((*TalosctlVersionCheckPtrInput)(nil)).Elem(), TalosctlVersionCheck("warn"))
+	pulumi.RegisterOutputType(ApplyModeOutput{})
+	pulumi.RegisterOutputType(ApplyModePtrOutput{})
+	pulumi.RegisterOutputType(DebugBundleOutput{})
+	pulumi.RegisterOutputType(DebugBundlePtrOutput{})
 	pulumi.RegisterOutputType(MachineTypesOutput{})
 	pulumi.RegisterOutputType(MachineTypesPtrOutput{})
+	pulumi.RegisterOutputType(MigrationModeOutput{})
+	pulumi.RegisterOutputType(MigrationModePtrOutput{})
+	pulumi.RegisterOutputType(OperationOutput{})
+	pulumi.RegisterOutputType(OperationPtrOutput{})
+	pulumi.RegisterOutputType(TalosctlVersionCheckOutput{})
+	pulumi.RegisterOutputType(TalosctlVersionCheckPtrOutput{})
 }
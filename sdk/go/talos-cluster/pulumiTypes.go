@@ -13,10 +13,15 @@ import (
 
 var _ = internal.GetEnvOrDefault
 
+// Machines to apply grouped by machine type.
+// It is usually the machines output of the Cluster resource.
 type ApplyMachines struct {
+	// Machines of the controlplane type.
 	Controlplane []MachineInfo `pulumi:"controlplane"`
-	Init         []MachineInfo `pulumi:"init"`
-	Worker       []MachineInfo `pulumi:"worker"`
+	// Machines of the init type.
+	Init []MachineInfo `pulumi:"init"`
+	// Machines of the worker type.
+	Worker []MachineInfo `pulumi:"worker"`
 }
 
 // ApplyMachinesInput is an input type that accepts ApplyMachinesArgs and ApplyMachinesOutput values.
@@ -30,10 +35,15 @@ type ApplyMachinesInput interface {
 	ToApplyMachinesOutputWithContext(context.Context) ApplyMachinesOutput
 }
 
+// Machines to apply grouped by machine type.
+// It is usually the machines output of the Cluster resource.
 type ApplyMachinesArgs struct {
+	// Machines of the controlplane type.
 	Controlplane MachineInfoArrayInput `pulumi:"controlplane"`
-	Init         MachineInfoArrayInput `pulumi:"init"`
-	Worker       MachineInfoArrayInput `pulumi:"worker"`
+	// Machines of the init type.
+	Init MachineInfoArrayInput `pulumi:"init"`
+	// Machines of the worker type.
+	Worker MachineInfoArrayInput `pulumi:"worker"`
 }
 
 func (ApplyMachinesArgs) ElementType() reflect.Type {
@@ -48,6 +58,49 @@ func (i ApplyMachinesArgs) ToApplyMachinesOutputWithContext(ctx context.Context)
 	return pulumi.ToOutputWithContext(ctx, i).(ApplyMachinesOutput)
 }
 
+func (i ApplyMachinesArgs) ToApplyMachinesPtrOutput() ApplyMachinesPtrOutput {
+	return i.ToApplyMachinesPtrOutputWithContext(context.Background())
+}
+
+func (i ApplyMachinesArgs) ToApplyMachinesPtrOutputWithContext(ctx context.Context) ApplyMachinesPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ApplyMachinesOutput).ToApplyMachinesPtrOutputWithContext(ctx)
+}
+
+// ApplyMachinesPtrInput is an input type that accepts ApplyMachinesArgs, ApplyMachinesPtr and ApplyMachinesPtrOutput values.
+// You can construct a concrete instance of `ApplyMachinesPtrInput` via:
+//
+//	        ApplyMachinesArgs{...}
+//
+//	or:
+//
+//	        nil
+type ApplyMachinesPtrInput interface {
+	pulumi.Input
+
+	ToApplyMachinesPtrOutput() ApplyMachinesPtrOutput
+	ToApplyMachinesPtrOutputWithContext(context.Context) ApplyMachinesPtrOutput
+}
+
+type applyMachinesPtrType ApplyMachinesArgs
+
+func ApplyMachinesPtr(v *ApplyMachinesArgs) ApplyMachinesPtrInput {
+	return (*applyMachinesPtrType)(v)
+}
+
+func (*applyMachinesPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**ApplyMachines)(nil)).Elem()
+}
+
+func (i *applyMachinesPtrType) ToApplyMachinesPtrOutput() ApplyMachinesPtrOutput {
+	return i.ToApplyMachinesPtrOutputWithContext(context.Background())
+}
+
+func (i *applyMachinesPtrType) ToApplyMachinesPtrOutputWithContext(ctx context.Context) ApplyMachinesPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ApplyMachinesPtrOutput)
+}
+
+// Machines to apply grouped by machine type.
+// It is usually the machines output of the Cluster resource.
 type ApplyMachinesOutput struct{ *pulumi.OutputState }
 
 func (ApplyMachinesOutput) ElementType() reflect.Type {
@@ -62,18 +115,85 @@ func (o ApplyMachinesOutput) ToApplyMachinesOutputWithContext(ctx context.Contex
 	return o
 }
 
+func (o ApplyMachinesOutput) ToApplyMachinesPtrOutput() ApplyMachinesPtrOutput {
+	return o.ToApplyMachinesPtrOutputWithContext(context.Background())
+}
+
+func (o ApplyMachinesOutput) ToApplyMachinesPtrOutputWithContext(ctx context.Context) ApplyMachinesPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v ApplyMachines) *ApplyMachines {
+		return &v
+	}).(ApplyMachinesPtrOutput)
+}
+
+// Machines of the controlplane type.
 func (o ApplyMachinesOutput) Controlplane() MachineInfoArrayOutput {
 	return o.ApplyT(func(v ApplyMachines) []MachineInfo { return v.Controlplane }).(MachineInfoArrayOutput)
 }
 
+// Machines of the init type.
 func (o ApplyMachinesOutput) Init() MachineInfoArrayOutput {
 	return o.ApplyT(func(v ApplyMachines) []MachineInfo { return v.Init }).(MachineInfoArrayOutput)
 }
 
+// Machines of the worker type.
 func (o ApplyMachinesOutput) Worker() MachineInfoArrayOutput {
 	return o.ApplyT(func(v ApplyMachines) []MachineInfo { return v.Worker }).(MachineInfoArrayOutput)
 }
 
+type ApplyMachinesPtrOutput struct{ *pulumi.OutputState }
+
+func (ApplyMachinesPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**ApplyMachines)(nil)).Elem()
+}
+
+func (o ApplyMachinesPtrOutput) ToApplyMachinesPtrOutput() ApplyMachinesPtrOutput {
+	return o
+}
+
+func (o ApplyMachinesPtrOutput) ToApplyMachinesPtrOutputWithContext(ctx context.Context) ApplyMachinesPtrOutput {
+	return o
+}
+
+func (o ApplyMachinesPtrOutput) Elem() ApplyMachinesOutput {
+	return o.ApplyT(func(v *ApplyMachines) ApplyMachines {
+		if v != nil {
+			return *v
+		}
+		var ret ApplyMachines
+		return ret
+	}).(ApplyMachinesOutput)
+}
+
+// Machines of the controlplane type.
+func (o ApplyMachinesPtrOutput) Controlplane() MachineInfoArrayOutput {
+	return o.ApplyT(func(v *ApplyMachines) []MachineInfo {
+		if v == nil {
+			return nil
+		}
+		return v.Controlplane
+	}).(MachineInfoArrayOutput)
+}
+
+// Machines of the init type.
+func (o ApplyMachinesPtrOutput) Init() MachineInfoArrayOutput {
+	return o.ApplyT(func(v *ApplyMachines) []MachineInfo {
+		if v == nil {
+			return nil
+		}
+		return v.Init
+	}).(MachineInfoArrayOutput)
+}
+
+// Machines of the worker type.
+func (o ApplyMachinesPtrOutput) Worker() MachineInfoArrayOutput {
+	return o.ApplyT(func(v *ApplyMachines) []MachineInfo {
+		if v == nil {
+			return nil
+		}
+		return v.Worker
+	}).(MachineInfoArrayOutput)
+}
+
 type ClientConfiguration struct {
 	// The Certificate Authority (CA) certificate used to verify connections to the Talos API server.
 	CaCertificate *string `pulumi:"caCertificate"`
@@ -115,6 +235,47 @@ func (i ClientConfigurationArgs) ToClientConfigurationOutputWithContext(ctx cont
 	return pulumi.ToOutputWithContext(ctx, i).(ClientConfigurationOutput)
 }
 
+func (i ClientConfigurationArgs) ToClientConfigurationPtrOutput() ClientConfigurationPtrOutput {
+	return i.ToClientConfigurationPtrOutputWithContext(context.Background())
+}
+
+func (i ClientConfigurationArgs) ToClientConfigurationPtrOutputWithContext(ctx context.Context) ClientConfigurationPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ClientConfigurationOutput).ToClientConfigurationPtrOutputWithContext(ctx)
+}
+
+// ClientConfigurationPtrInput is an input type that accepts ClientConfigurationArgs, ClientConfigurationPtr and ClientConfigurationPtrOutput values.
+// You can construct a concrete instance of `ClientConfigurationPtrInput` via:
+//
+//	        ClientConfigurationArgs{...}
+//
+//	or:
+//
+//	        nil
+type ClientConfigurationPtrInput interface {
+	pulumi.Input
+
+	ToClientConfigurationPtrOutput() ClientConfigurationPtrOutput
+	ToClientConfigurationPtrOutputWithContext(context.Context) ClientConfigurationPtrOutput
+}
+
+type clientConfigurationPtrType ClientConfigurationArgs
+
+func ClientConfigurationPtr(v *ClientConfigurationArgs) ClientConfigurationPtrInput {
+	return (*clientConfigurationPtrType)(v)
+}
+
+func (*clientConfigurationPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**ClientConfiguration)(nil)).Elem()
+}
+
+func (i *clientConfigurationPtrType) ToClientConfigurationPtrOutput() ClientConfigurationPtrOutput {
+	return i.ToClientConfigurationPtrOutputWithContext(context.Background())
+}
+
+func (i *clientConfigurationPtrType) ToClientConfigurationPtrOutputWithContext(ctx context.Context) ClientConfigurationPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ClientConfigurationPtrOutput)
+}
+
 type ClientConfigurationOutput struct{ *pulumi.OutputState }
 
 func (ClientConfigurationOutput) ElementType() reflect.Type {
@@ -129,6 +290,16 @@ func (o ClientConfigurationOutput) ToClientConfigurationOutputWithContext(ctx co
 	return o
 }
 
+func (o ClientConfigurationOutput) ToClientConfigurationPtrOutput() ClientConfigurationPtrOutput {
+	return o.ToClientConfigurationPtrOutputWithContext(context.Background())
+}
+
+func (o ClientConfigurationOutput) ToClientConfigurationPtrOutputWithContext(ctx context.Context) ClientConfigurationPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v ClientConfiguration) *ClientConfiguration {
+		return &v
+	}).(ClientConfigurationPtrOutput)
+}
+
 // The Certificate Authority (CA) certificate used to verify connections to the Talos API server.
 func (o ClientConfigurationOutput) CaCertificate() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v ClientConfiguration) *string { return v.CaCertificate }).(pulumi.StringPtrOutput)
@@ -144,7 +315,64 @@ func (o ClientConfigurationOutput) ClientKey() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v ClientConfiguration) *string { return v.ClientKey }).(pulumi.StringPtrOutput)
 }
 
+type ClientConfigurationPtrOutput struct{ *pulumi.OutputState }
+
+func (ClientConfigurationPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**ClientConfiguration)(nil)).Elem()
+}
+
+func (o ClientConfigurationPtrOutput) ToClientConfigurationPtrOutput() ClientConfigurationPtrOutput {
+	return o
+}
+
+func (o ClientConfigurationPtrOutput) ToClientConfigurationPtrOutputWithContext(ctx context.Context) ClientConfigurationPtrOutput {
+	return o
+}
+
+func (o ClientConfigurationPtrOutput) Elem() ClientConfigurationOutput {
+	return o.ApplyT(func(v *ClientConfiguration) ClientConfiguration {
+		if v != nil {
+			return *v
+		}
+		var ret ClientConfiguration
+		return ret
+	}).(ClientConfigurationOutput)
+}
+
+// The Certificate Authority (CA) certificate used to verify connections to the Talos API server.
+func (o ClientConfigurationPtrOutput) CaCertificate() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *ClientConfiguration) *string {
+		if v == nil {
+			return nil
+		}
+		return v.CaCertificate
+	}).(pulumi.StringPtrOutput)
+}
+
+// The client certificate used to authenticate to the Talos API server.
+func (o ClientConfigurationPtrOutput) ClientCertificate() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *ClientConfiguration) *string {
+		if v == nil {
+			return nil
+		}
+		return v.ClientCertificate
+	}).(pulumi.StringPtrOutput)
+}
+
+// The private key for the client certificate, used for authenticating the client to the Talos API server.
+func (o ClientConfigurationPtrOutput) ClientKey() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *ClientConfiguration) *string {
+		if v == nil {
+			return nil
+		}
+		return v.ClientKey
+	}).(pulumi.StringPtrOutput)
+}
+
 type ClusterMachines struct {
+	// Mode of `talosctl apply-config` for the machine.
+	// Overrides the applyMode of the Apply resource.
+	ApplyMode *ApplyMode `pulumi:"applyMode"`
 	// User-provided machine configuration to apply.
 	// Must be a valid array of YAML strings.
 	// For structure, see https://www.talos.dev/latest/reference/configuration/v1alpha1/config/
@@ -159,6 +387,9 @@ type ClusterMachines struct {
 	// Used in the `install` configuration and set via CLI.
 	// The default is generated based on the Talos machinery version, current: ghcr.io/siderolabs/installer:v1.12.0.
 	TalosImage *string `pulumi:"talosImage"`
+	// Duration after which a configuration applied in try mode is rolled back.
+	// Overrides the tryTimeout of the Apply resource.
+	TryTimeout *string `pulumi:"tryTimeout"`
 }
 
 // Defaults sets the appropriate defaults for ClusterMachines
@@ -186,6 +417,9 @@ type ClusterMachinesInput interface {
 }
 
 type ClusterMachinesArgs struct {
+	// Mode of `talosctl apply-config` for the machine.
+	// Overrides the applyMode of the Apply resource.
+	ApplyMode ApplyModePtrInput `pulumi:"applyMode"`
 	// User-provided machine configuration to apply.
 	// Must be a valid array of YAML strings.
 	// For structure, see https://www.talos.dev/latest/reference/configuration/v1alpha1/config/
@@ -200,6 +434,9 @@ type ClusterMachinesArgs struct {
 	// Used in the `install` configuration and set via CLI.
 	// The default is generated based on the Talos machinery version, current: ghcr.io/siderolabs/installer:v1.12.0.
 	TalosImage pulumi.StringPtrInput `pulumi:"talosImage"`
+	// Duration after which a configuration applied in try mode is rolled back.
+	// Overrides the tryTimeout of the Apply resource.
+	TryTimeout pulumi.StringPtrInput `pulumi:"tryTimeout"`
 }
 
 // Defaults sets the appropriate defaults for ClusterMachinesArgs
@@ -264,6 +501,12 @@ func (o ClusterMachinesOutput) ToClusterMachinesOutputWithContext(ctx context.Co
 	return o
 }
 
+// Mode of `talosctl apply-config` for the machine.
+// Overrides the applyMode of the Apply resource.
+func (o ClusterMachinesOutput) ApplyMode() ApplyModePtrOutput {
+	return o.ApplyT(func(v ClusterMachines) *ApplyMode { return v.ApplyMode }).(ApplyModePtrOutput)
+}
+
 // User-provided machine configuration to apply.
 // Must be a valid array of YAML strings.
 // For structure, see https://www.talos.dev/latest/reference/configuration/v1alpha1/config/
@@ -293,6 +536,12 @@ func (o ClusterMachinesOutput) TalosImage() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v ClusterMachines) *string { return v.TalosImage }).(pulumi.StringPtrOutput)
 }
 
+// Duration after which a configuration applied in try mode is rolled back.
+// Overrides the tryTimeout of the Apply resource.
+func (o ClusterMachinesOutput) TryTimeout() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v ClusterMachines) *string { return v.TryTimeout }).(pulumi.StringPtrOutput)
+}
+
 type ClusterMachinesArrayOutput struct{ *pulumi.OutputState }
 
 func (ClusterMachinesArrayOutput) ElementType() reflect.Type {
@@ -344,23 +593,402 @@ func (o CredentialsOutput) Talosconfig() pulumi.StringOutput {
 	return o.ApplyT(func(v Credentials) string { return v.Talosconfig }).(pulumi.StringOutput)
 }
 
+type KubeconfigOptions struct {
+	// Lifetime of the admin client certificate, e.g. 24h.
+	// The certificate is renewed in the middle of its lifetime.
+	// Default is cluster.adminKubeconfig.certLifetime of the init node configuration, one year unless it is patched.
+	CertLifetime *string `pulumi:"certLifetime"`
+	// Name of the cluster entry. Default is the cluster name.
+	ClusterName *string `pulumi:"clusterName"`
+	// Name of the context, which is also the current context. Default is admin@<cluster name>.
+	ContextName *string `pulumi:"contextName"`
+	// URL of the Kubernetes API server, e.g. https://lb.example.com:6443.
+	// Default is the cluster endpoint from the configuration of the init node.
+	Server *string `pulumi:"server"`
+	// Name of the user entry. Default is admin@<cluster name>.
+	UserName *string `pulumi:"userName"`
+}
+
+// KubeconfigOptionsInput is an input type that accepts KubeconfigOptionsArgs and KubeconfigOptionsOutput values.
+// You can construct a concrete instance of `KubeconfigOptionsInput` via:
+//
+//	KubeconfigOptionsArgs{...}
+type KubeconfigOptionsInput interface {
+	pulumi.Input
+
+	ToKubeconfigOptionsOutput() KubeconfigOptionsOutput
+	ToKubeconfigOptionsOutputWithContext(context.Context) KubeconfigOptionsOutput
+}
+
+type KubeconfigOptionsArgs struct {
+	// Lifetime of the admin client certificate, e.g. 24h.
+	// The certificate is renewed in the middle of its lifetime.
+	// Default is cluster.adminKubeconfig.certLifetime of the init node configuration, one year unless it is patched.
+	CertLifetime pulumi.StringPtrInput `pulumi:"certLifetime"`
+	// Name of the cluster entry. Default is the cluster name.
+	ClusterName pulumi.StringPtrInput `pulumi:"clusterName"`
+	// Name of the context, which is also the current context. Default is admin@<cluster name>.
+	ContextName pulumi.StringPtrInput `pulumi:"contextName"`
+	// URL of the Kubernetes API server, e.g. https://lb.example.com:6443.
+	// Default is the cluster endpoint from the configuration of the init node.
+	Server pulumi.StringPtrInput `pulumi:"server"`
+	// Name of the user entry. Default is admin@<cluster name>.
+	UserName pulumi.StringPtrInput `pulumi:"userName"`
+}
+
+func (KubeconfigOptionsArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*KubeconfigOptions)(nil)).Elem()
+}
+
+func (i KubeconfigOptionsArgs) ToKubeconfigOptionsOutput() KubeconfigOptionsOutput {
+	return i.ToKubeconfigOptionsOutputWithContext(context.Background())
+}
+
+func (i KubeconfigOptionsArgs) ToKubeconfigOptionsOutputWithContext(ctx context.Context) KubeconfigOptionsOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(KubeconfigOptionsOutput)
+}
+
+func (i KubeconfigOptionsArgs) ToKubeconfigOptionsPtrOutput() KubeconfigOptionsPtrOutput {
+	return i.ToKubeconfigOptionsPtrOutputWithContext(context.Background())
+}
+
+func (i KubeconfigOptionsArgs) ToKubeconfigOptionsPtrOutputWithContext(ctx context.Context) KubeconfigOptionsPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(KubeconfigOptionsOutput).ToKubeconfigOptionsPtrOutputWithContext(ctx)
+}
+
+// KubeconfigOptionsPtrInput is an input type that accepts KubeconfigOptionsArgs, KubeconfigOptionsPtr and KubeconfigOptionsPtrOutput values.
+// You can construct a concrete instance of `KubeconfigOptionsPtrInput` via:
+//
+//	        KubeconfigOptionsArgs{...}
+//
+//	or:
+//
+//	        nil
+type KubeconfigOptionsPtrInput interface {
+	pulumi.Input
+
+	ToKubeconfigOptionsPtrOutput() KubeconfigOptionsPtrOutput
+	ToKubeconfigOptionsPtrOutputWithContext(context.Context) KubeconfigOptionsPtrOutput
+}
+
+type kubeconfigOptionsPtrType KubeconfigOptionsArgs
+
+func KubeconfigOptionsPtr(v *KubeconfigOptionsArgs) KubeconfigOptionsPtrInput {
+	return (*kubeconfigOptionsPtrType)(v)
+}
+
+func (*kubeconfigOptionsPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**KubeconfigOptions)(nil)).Elem()
+}
+
+func (i *kubeconfigOptionsPtrType) ToKubeconfigOptionsPtrOutput() KubeconfigOptionsPtrOutput {
+	return i.ToKubeconfigOptionsPtrOutputWithContext(context.Background())
+}
+
+func (i *kubeconfigOptionsPtrType) ToKubeconfigOptionsPtrOutputWithContext(ctx context.Context) KubeconfigOptionsPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(KubeconfigOptionsPtrOutput)
+}
+
+type KubeconfigOptionsOutput struct{ *pulumi.OutputState }
+
+func (KubeconfigOptionsOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*KubeconfigOptions)(nil)).Elem()
+}
+
+func (o KubeconfigOptionsOutput) ToKubeconfigOptionsOutput() KubeconfigOptionsOutput {
+	return o
+}
+
+func (o KubeconfigOptionsOutput) ToKubeconfigOptionsOutputWithContext(ctx context.Context) KubeconfigOptionsOutput {
+	return o
+}
+
+func (o KubeconfigOptionsOutput) ToKubeconfigOptionsPtrOutput() KubeconfigOptionsPtrOutput {
+	return o.ToKubeconfigOptionsPtrOutputWithContext(context.Background())
+}
+
+func (o KubeconfigOptionsOutput) ToKubeconfigOptionsPtrOutputWithContext(ctx context.Context) KubeconfigOptionsPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v KubeconfigOptions) *KubeconfigOptions {
+		return &v
+	}).(KubeconfigOptionsPtrOutput)
+}
+
+// Lifetime of the admin client certificate, e.g. 24h.
+// The certificate is renewed in the middle of its lifetime.
+// Default is cluster.adminKubeconfig.certLifetime of the init node configuration, one year unless it is patched.
+func (o KubeconfigOptionsOutput) CertLifetime() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v KubeconfigOptions) *string { return v.CertLifetime }).(pulumi.StringPtrOutput)
+}
+
+// Name of the cluster entry. Default is the cluster name.
+func (o KubeconfigOptionsOutput) ClusterName() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v KubeconfigOptions) *string { return v.ClusterName }).(pulumi.StringPtrOutput)
+}
+
+// Name of the context, which is also the current context. Default is admin@<cluster name>.
+func (o KubeconfigOptionsOutput) ContextName() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v KubeconfigOptions) *string { return v.ContextName }).(pulumi.StringPtrOutput)
+}
+
+// URL of the Kubernetes API server, e.g. https://lb.example.com:6443.
+// Default is the cluster endpoint from the configuration of the init node.
+func (o KubeconfigOptionsOutput) Server() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v KubeconfigOptions) *string { return v.Server }).(pulumi.StringPtrOutput)
+}
+
+// Name of the user entry. Default is admin@<cluster name>.
+func (o KubeconfigOptionsOutput) UserName() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v KubeconfigOptions) *string { return v.UserName }).(pulumi.StringPtrOutput)
+}
+
+type KubeconfigOptionsPtrOutput struct{ *pulumi.OutputState }
+
+func (KubeconfigOptionsPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**KubeconfigOptions)(nil)).Elem()
+}
+
+func (o KubeconfigOptionsPtrOutput) ToKubeconfigOptionsPtrOutput() KubeconfigOptionsPtrOutput {
+	return o
+}
+
+func (o KubeconfigOptionsPtrOutput) ToKubeconfigOptionsPtrOutputWithContext(ctx context.Context) KubeconfigOptionsPtrOutput {
+	return o
+}
+
+func (o KubeconfigOptionsPtrOutput) Elem() KubeconfigOptionsOutput {
+	return o.ApplyT(func(v *KubeconfigOptions) KubeconfigOptions {
+		if v != nil {
+			return *v
+		}
+		var ret KubeconfigOptions
+		return ret
+	}).(KubeconfigOptionsOutput)
+}
+
+// Lifetime of the admin client certificate, e.g. 24h.
+// The certificate is renewed in the middle of its lifetime.
+// Default is cluster.adminKubeconfig.certLifetime of the init node configuration, one year unless it is patched.
+func (o KubeconfigOptionsPtrOutput) CertLifetime() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *KubeconfigOptions) *string {
+		if v == nil {
+			return nil
+		}
+		return v.CertLifetime
+	}).(pulumi.StringPtrOutput)
+}
+
+// Name of the cluster entry. Default is the cluster name.
+func (o KubeconfigOptionsPtrOutput) ClusterName() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *KubeconfigOptions) *string {
+		if v == nil {
+			return nil
+		}
+		return v.ClusterName
+	}).(pulumi.StringPtrOutput)
+}
+
+// Name of the context, which is also the current context. Default is admin@<cluster name>.
+func (o KubeconfigOptionsPtrOutput) ContextName() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *KubeconfigOptions) *string {
+		if v == nil {
+			return nil
+		}
+		return v.ContextName
+	}).(pulumi.StringPtrOutput)
+}
+
+// URL of the Kubernetes API server, e.g. https://lb.example.com:6443.
+// Default is the cluster endpoint from the configuration of the init node.
+func (o KubeconfigOptionsPtrOutput) Server() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *KubeconfigOptions) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Server
+	}).(pulumi.StringPtrOutput)
+}
+
+// Name of the user entry. Default is admin@<cluster name>.
+func (o KubeconfigOptionsPtrOutput) UserName() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *KubeconfigOptions) *string {
+		if v == nil {
+			return nil
+		}
+		return v.UserName
+	}).(pulumi.StringPtrOutput)
+}
+
+type MachineConfiguration struct {
+	// Mode of `talosctl apply-config` for the machine.
+	// Overrides the applyMode of the Apply resource.
+	ApplyMode *ApplyMode `pulumi:"applyMode"`
+	// Complete machine configuration of the node (YAML).
+	Configuration string `pulumi:"configuration"`
+	// ID or name of the machine.
+	MachineId string `pulumi:"machineId"`
+	// The IP address of the node where configuration will be applied.
+	NodeIp string `pulumi:"nodeIp"`
+	// Duration after which a configuration applied in try mode is rolled back.
+	// Overrides the tryTimeout of the Apply resource.
+	TryTimeout *string `pulumi:"tryTimeout"`
+}
+
+// MachineConfigurationInput is an input type that accepts MachineConfigurationArgs and MachineConfigurationOutput values.
+// You can construct a concrete instance of `MachineConfigurationInput` via:
+//
+//	MachineConfigurationArgs{...}
+type MachineConfigurationInput interface {
+	pulumi.Input
+
+	ToMachineConfigurationOutput() MachineConfigurationOutput
+	ToMachineConfigurationOutputWithContext(context.Context) MachineConfigurationOutput
+}
+
+type MachineConfigurationArgs struct {
+	// Mode of `talosctl apply-config` for the machine.
+	// Overrides the applyMode of the Apply resource.
+	ApplyMode ApplyModePtrInput `pulumi:"applyMode"`
+	// Complete machine configuration of the node (YAML).
+	Configuration pulumi.StringInput `pulumi:"configuration"`
+	// ID or name of the machine.
+	MachineId pulumi.StringInput `pulumi:"machineId"`
+	// The IP address of the node where configuration will be applied.
+	NodeIp pulumi.StringInput `pulumi:"nodeIp"`
+	// Duration after which a configuration applied in try mode is rolled back.
+	// Overrides the tryTimeout of the Apply resource.
+	TryTimeout pulumi.StringPtrInput `pulumi:"tryTimeout"`
+}
+
+func (MachineConfigurationArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*MachineConfiguration)(nil)).Elem()
+}
+
+func (i MachineConfigurationArgs) ToMachineConfigurationOutput() MachineConfigurationOutput {
+	return i.ToMachineConfigurationOutputWithContext(context.Background())
+}
+
+func (i MachineConfigurationArgs) ToMachineConfigurationOutputWithContext(ctx context.Context) MachineConfigurationOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(MachineConfigurationOutput)
+}
+
+// MachineConfigurationArrayInput is an input type that accepts MachineConfigurationArray and MachineConfigurationArrayOutput values.
+// You can construct a concrete instance of `MachineConfigurationArrayInput` via:
+//
+//	MachineConfigurationArray{ MachineConfigurationArgs{...} }
+type MachineConfigurationArrayInput interface {
+	pulumi.Input
+
+	ToMachineConfigurationArrayOutput() MachineConfigurationArrayOutput
+	ToMachineConfigurationArrayOutputWithContext(context.Context) MachineConfigurationArrayOutput
+}
+
+type MachineConfigurationArray []MachineConfigurationInput
+
+func (MachineConfigurationArray) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]MachineConfiguration)(nil)).Elem()
+}
+
+func (i MachineConfigurationArray) ToMachineConfigurationArrayOutput() MachineConfigurationArrayOutput {
+	return i.ToMachineConfigurationArrayOutputWithContext(context.Background())
+}
+
+func (i MachineConfigurationArray) ToMachineConfigurationArrayOutputWithContext(ctx context.Context) MachineConfigurationArrayOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(MachineConfigurationArrayOutput)
+}
+
+type MachineConfigurationOutput struct{ *pulumi.OutputState }
+
+func (MachineConfigurationOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*MachineConfiguration)(nil)).Elem()
+}
+
+func (o MachineConfigurationOutput) ToMachineConfigurationOutput() MachineConfigurationOutput {
+	return o
+}
+
+func (o MachineConfigurationOutput) ToMachineConfigurationOutputWithContext(ctx context.Context) MachineConfigurationOutput {
+	return o
+}
+
+// Mode of `talosctl apply-config` for the machine.
+// Overrides the applyMode of the Apply resource.
+func (o MachineConfigurationOutput) ApplyMode() ApplyModePtrOutput {
+	return o.ApplyT(func(v MachineConfiguration) *ApplyMode { return v.ApplyMode }).(ApplyModePtrOutput)
+}
+
+// Complete machine configuration of the node (YAML).
+func (o MachineConfigurationOutput) Configuration() pulumi.StringOutput {
+	return o.ApplyT(func(v MachineConfiguration) string { return v.Configuration }).(pulumi.StringOutput)
+}
+
+// ID or name of the machine.
+func (o MachineConfigurationOutput) MachineId() pulumi.StringOutput {
+	return o.ApplyT(func(v MachineConfiguration) string { return v.MachineId }).(pulumi.StringOutput)
+}
+
+// The IP address of the node where configuration will be applied.
+func (o MachineConfigurationOutput) NodeIp() pulumi.StringOutput {
+	return o.ApplyT(func(v MachineConfiguration) string { return v.NodeIp }).(pulumi.StringOutput)
+}
+
+// Duration after which a configuration applied in try mode is rolled back.
+// Overrides the tryTimeout of the Apply resource.
+func (o MachineConfigurationOutput) TryTimeout() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v MachineConfiguration) *string { return v.TryTimeout }).(pulumi.StringPtrOutput)
+}
+
+type MachineConfigurationArrayOutput struct{ *pulumi.OutputState }
+
+func (MachineConfigurationArrayOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]MachineConfiguration)(nil)).Elem()
+}
+
+func (o MachineConfigurationArrayOutput) ToMachineConfigurationArrayOutput() MachineConfigurationArrayOutput {
+	return o
+}
+
+func (o MachineConfigurationArrayOutput) ToMachineConfigurationArrayOutputWithContext(ctx context.Context) MachineConfigurationArrayOutput {
+	return o
+}
+
+func (o MachineConfigurationArrayOutput) Index(i pulumi.IntInput) MachineConfigurationOutput {
+	return pulumi.All(o, i).ApplyT(func(vs []interface{}) MachineConfiguration {
+		return vs[0].([]MachineConfiguration)[vs[1].(int)]
+	}).(MachineConfigurationOutput)
+}
+
 type MachineInfo struct {
+	// Mode of `talosctl apply-config` for the machine.
+	// Overrides the applyMode of the Apply resource.
+	ApplyMode *ApplyMode `pulumi:"applyMode"`
 	// cluster endpoint applied to node
 	ClusterEndpoint *string `pulumi:"clusterEndpoint"`
 	// Configuration settings for machines to apply.
 	// This can be retrieved from the cluster resource.
 	Configuration string `pulumi:"configuration"`
 	// Kubernetes version to install or upgrade on the node.
+	// The version of the kubelet image of the configuration is used if it is not set.
 	KubernetesVersion *string `pulumi:"kubernetesVersion"`
 	// ID or name of the machine.
 	MachineId string `pulumi:"machineId"`
+	// Type of the machine.
+	// Set by the Cluster resource. The machine is applied according to its group in applyMachines.
+	MachineType *MachineTypes `pulumi:"machineType"`
 	// The IP address of the node where configuration will be applied.
 	NodeIp string `pulumi:"nodeIp"`
 	// Talos OS image to install or upgrade on the node.
+	// The install image of the configuration is used if it is not set.
 	TalosImage *string `pulumi:"talosImage"`
+	// Duration after which a configuration applied in try mode is rolled back.
+	// Overrides the tryTimeout of the Apply resource.
+	TryTimeout *string `pulumi:"tryTimeout"`
 	// User-provided machine configuration to apply.
 	// This can be retrieved from the cluster resource.
 	UserConfigPatches *string `pulumi:"userConfigPatches"`
+	// Version of the machine info format.
+	// Set by the Cluster resource, the current version is 1.
+	// Machines without it are parsed as version 0.
+	Version *int `pulumi:"version"`
 }
 
 // MachineInfoInput is an input type that accepts MachineInfoArgs and MachineInfoOutput values.
@@ -375,22 +1003,37 @@ type MachineInfoInput interface {
 }
 
 type MachineInfoArgs struct {
+	// Mode of `talosctl apply-config` for the machine.
+	// Overrides the applyMode of the Apply resource.
+	ApplyMode ApplyModePtrInput `pulumi:"applyMode"`
 	// cluster endpoint applied to node
 	ClusterEndpoint pulumi.StringPtrInput `pulumi:"clusterEndpoint"`
 	// Configuration settings for machines to apply.
 	// This can be retrieved from the cluster resource.
 	Configuration pulumi.StringInput `pulumi:"configuration"`
 	// Kubernetes version to install or upgrade on the node.
+	// The version of the kubelet image of the configuration is used if it is not set.
 	KubernetesVersion pulumi.StringPtrInput `pulumi:"kubernetesVersion"`
 	// ID or name of the machine.
 	MachineId pulumi.StringInput `pulumi:"machineId"`
+	// Type of the machine.
+	// Set by the Cluster resource. The machine is applied according to its group in applyMachines.
+	MachineType MachineTypesPtrInput `pulumi:"machineType"`
 	// The IP address of the node where configuration will be applied.
 	NodeIp pulumi.StringInput `pulumi:"nodeIp"`
 	// Talos OS image to install or upgrade on the node.
+	// The install image of the configuration is used if it is not set.
 	TalosImage pulumi.StringPtrInput `pulumi:"talosImage"`
+	// Duration after which a configuration applied in try mode is rolled back.
+	// Overrides the tryTimeout of the Apply resource.
+	TryTimeout pulumi.StringPtrInput `pulumi:"tryTimeout"`
 	// User-provided machine configuration to apply.
 	// This can be retrieved from the cluster resource.
 	UserConfigPatches pulumi.StringPtrInput `pulumi:"userConfigPatches"`
+	// Version of the machine info format.
+	// Set by the Cluster resource, the current version is 1.
+	// Machines without it are parsed as version 0.
+	Version pulumi.IntPtrInput `pulumi:"version"`
 }
 
 func (MachineInfoArgs) ElementType() reflect.Type {
@@ -444,6 +1087,12 @@ func (o MachineInfoOutput) ToMachineInfoOutputWithContext(ctx context.Context) M
 	return o
 }
 
+// Mode of `talosctl apply-config` for the machine.
+// Overrides the applyMode of the Apply resource.
+func (o MachineInfoOutput) ApplyMode() ApplyModePtrOutput {
+	return o.ApplyT(func(v MachineInfo) *ApplyMode { return v.ApplyMode }).(ApplyModePtrOutput)
+}
+
 // cluster endpoint applied to node
 func (o MachineInfoOutput) ClusterEndpoint() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v MachineInfo) *string { return v.ClusterEndpoint }).(pulumi.StringPtrOutput)
@@ -456,6 +1105,7 @@ func (o MachineInfoOutput) Configuration() pulumi.StringOutput {
 }
 
 // Kubernetes version to install or upgrade on the node.
+// The version of the kubelet image of the configuration is used if it is not set.
 func (o MachineInfoOutput) KubernetesVersion() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v MachineInfo) *string { return v.KubernetesVersion }).(pulumi.StringPtrOutput)
 }
@@ -465,22 +1115,42 @@ func (o MachineInfoOutput) MachineId() pulumi.StringOutput {
 	return o.ApplyT(func(v MachineInfo) string { return v.MachineId }).(pulumi.StringOutput)
 }
 
+// Type of the machine.
+// Set by the Cluster resource. The machine is applied according to its group in applyMachines.
+func (o MachineInfoOutput) MachineType() MachineTypesPtrOutput {
+	return o.ApplyT(func(v MachineInfo) *MachineTypes { return v.MachineType }).(MachineTypesPtrOutput)
+}
+
 // The IP address of the node where configuration will be applied.
 func (o MachineInfoOutput) NodeIp() pulumi.StringOutput {
 	return o.ApplyT(func(v MachineInfo) string { return v.NodeIp }).(pulumi.StringOutput)
 }
 
 // Talos OS image to install or upgrade on the node.
+// The install image of the configuration is used if it is not set.
 func (o MachineInfoOutput) TalosImage() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v MachineInfo) *string { return v.TalosImage }).(pulumi.StringPtrOutput)
 }
 
+// Duration after which a configuration applied in try mode is rolled back.
+// Overrides the tryTimeout of the Apply resource.
+func (o MachineInfoOutput) TryTimeout() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v MachineInfo) *string { return v.TryTimeout }).(pulumi.StringPtrOutput)
+}
+
 // User-provided machine configuration to apply.
 // This can be retrieved from the cluster resource.
 func (o MachineInfoOutput) UserConfigPatches() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v MachineInfo) *string { return v.UserConfigPatches }).(pulumi.StringPtrOutput)
 }
 
+// Version of the machine info format.
+// Set by the Cluster resource, the current version is 1.
+// Machines without it are parsed as version 0.
+func (o MachineInfoOutput) Version() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v MachineInfo) *int { return v.Version }).(pulumi.IntPtrOutput)
+}
+
 type MachineInfoArrayOutput struct{ *pulumi.OutputState }
 
 func (MachineInfoArrayOutput) ElementType() reflect.Type {
@@ -501,18 +1171,413 @@ func (o MachineInfoArrayOutput) Index(i pulumi.IntInput) MachineInfoOutput {
 	}).(MachineInfoOutput)
 }
 
+type MachineStatus struct {
+	// SHA-256 hash of the last applied machine configuration.
+	ConfigHash string `pulumi:"configHash"`
+	// Kubernetes version of the machine.
+	KubernetesVersion string `pulumi:"kubernetesVersion"`
+	// The last operation which ran on the machine. It is unknown for machines of migrated stacks until an operation runs on them.
+	LastOperation Operation `pulumi:"lastOperation"`
+	// Time of the last operation in RFC 3339 format. Empty if the operation is unknown.
+	LastOperationTime string `pulumi:"lastOperationTime"`
+	// Talos version reported by the node after apply.
+	ObservedTalosVersion string `pulumi:"observedTalosVersion"`
+	// Talos version of the machine image. Empty if the image tag is not a version.
+	TalosVersion string `pulumi:"talosVersion"`
+}
+
+type MachineStatusOutput struct{ *pulumi.OutputState }
+
+func (MachineStatusOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*MachineStatus)(nil)).Elem()
+}
+
+func (o MachineStatusOutput) ToMachineStatusOutput() MachineStatusOutput {
+	return o
+}
+
+func (o MachineStatusOutput) ToMachineStatusOutputWithContext(ctx context.Context) MachineStatusOutput {
+	return o
+}
+
+// SHA-256 hash of the last applied machine configuration.
+func (o MachineStatusOutput) ConfigHash() pulumi.StringOutput {
+	return o.ApplyT(func(v MachineStatus) string { return v.ConfigHash }).(pulumi.StringOutput)
+}
+
+// Kubernetes version of the machine.
+func (o MachineStatusOutput) KubernetesVersion() pulumi.StringOutput {
+	return o.ApplyT(func(v MachineStatus) string { return v.KubernetesVersion }).(pulumi.StringOutput)
+}
+
+// The last operation which ran on the machine. It is unknown for machines of migrated stacks until an operation runs on them.
+func (o MachineStatusOutput) LastOperation() OperationOutput {
+	return o.ApplyT(func(v MachineStatus) Operation { return v.LastOperation }).(OperationOutput)
+}
+
+// Time of the last operation in RFC 3339 format. Empty if the operation is unknown.
+func (o MachineStatusOutput) LastOperationTime() pulumi.StringOutput {
+	return o.ApplyT(func(v MachineStatus) string { return v.LastOperationTime }).(pulumi.StringOutput)
+}
+
+// Talos version reported by the node after apply.
+func (o MachineStatusOutput) ObservedTalosVersion() pulumi.StringOutput {
+	return o.ApplyT(func(v MachineStatus) string { return v.ObservedTalosVersion }).(pulumi.StringOutput)
+}
+
+// Talos version of the machine image. Empty if the image tag is not a version.
+func (o MachineStatusOutput) TalosVersion() pulumi.StringOutput {
+	return o.ApplyT(func(v MachineStatus) string { return v.TalosVersion }).(pulumi.StringOutput)
+}
+
+type MachineStatusMapOutput struct{ *pulumi.OutputState }
+
+func (MachineStatusMapOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*map[string]MachineStatus)(nil)).Elem()
+}
+
+func (o MachineStatusMapOutput) ToMachineStatusMapOutput() MachineStatusMapOutput {
+	return o
+}
+
+func (o MachineStatusMapOutput) ToMachineStatusMapOutputWithContext(ctx context.Context) MachineStatusMapOutput {
+	return o
+}
+
+func (o MachineStatusMapOutput) MapIndex(k pulumi.StringInput) MachineStatusOutput {
+	return pulumi.All(o, k).ApplyT(func(vs []interface{}) MachineStatus {
+		return vs[0].(map[string]MachineStatus)[vs[1].(string)]
+	}).(MachineStatusOutput)
+}
+
+// Machines of the Cluster resource grouped by machine type.
+// It is passed to applyMachines of the Apply resource.
+type MachinesByType struct {
+	// Machines of the controlplane type.
+	Controlplane []MachineInfo `pulumi:"controlplane"`
+	// Machines of the init type.
+	Init []MachineInfo `pulumi:"init"`
+	// Machines of the worker type.
+	Worker []MachineInfo `pulumi:"worker"`
+}
+
+// Machines of the Cluster resource grouped by machine type.
+// It is passed to applyMachines of the Apply resource.
+type MachinesByTypeOutput struct{ *pulumi.OutputState }
+
+func (MachinesByTypeOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*MachinesByType)(nil)).Elem()
+}
+
+func (o MachinesByTypeOutput) ToMachinesByTypeOutput() MachinesByTypeOutput {
+	return o
+}
+
+func (o MachinesByTypeOutput) ToMachinesByTypeOutputWithContext(ctx context.Context) MachinesByTypeOutput {
+	return o
+}
+
+// Machines of the controlplane type.
+func (o MachinesByTypeOutput) Controlplane() MachineInfoArrayOutput {
+	return o.ApplyT(func(v MachinesByType) []MachineInfo { return v.Controlplane }).(MachineInfoArrayOutput)
+}
+
+// Machines of the init type.
+func (o MachinesByTypeOutput) Init() MachineInfoArrayOutput {
+	return o.ApplyT(func(v MachinesByType) []MachineInfo { return v.Init }).(MachineInfoArrayOutput)
+}
+
+// Machines of the worker type.
+func (o MachinesByTypeOutput) Worker() MachineInfoArrayOutput {
+	return o.ApplyT(func(v MachinesByType) []MachineInfo { return v.Worker }).(MachineInfoArrayOutput)
+}
+
+type NodeStatus struct {
+	// Errors occurred while the status was collected.
+	Error *string `pulumi:"error"`
+	// The etcd member is a learner and does not vote yet.
+	EtcdLearner *bool `pulumi:"etcdLearner"`
+	// The node is a member of the etcd cluster.
+	EtcdMember *bool `pulumi:"etcdMember"`
+	// ID of the etcd member.
+	EtcdMemberId *string `pulumi:"etcdMemberId"`
+	// Kubelet version from the kubelet image. Empty if kubelet is not configured yet.
+	KubeletVersion *string `pulumi:"kubeletVersion"`
+	// Machine type from the running configuration.
+	MachineType *string `pulumi:"machineType"`
+	// The IP address of the node.
+	Node string `pulumi:"node"`
+	// The node is running, all Talos conditions are met
+	// and, for controlplanes, the node is a voting etcd member.
+	Ready bool `pulumi:"ready"`
+	// Machine stage reported by Talos, e.g. maintenance, booting, running.
+	// It is unknown if the node can't be reached.
+	Stage string `pulumi:"stage"`
+	// Running Talos version.
+	TalosVersion *string `pulumi:"talosVersion"`
+}
+
+type NodeStatusOutput struct{ *pulumi.OutputState }
+
+func (NodeStatusOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*NodeStatus)(nil)).Elem()
+}
+
+func (o NodeStatusOutput) ToNodeStatusOutput() NodeStatusOutput {
+	return o
+}
+
+func (o NodeStatusOutput) ToNodeStatusOutputWithContext(ctx context.Context) NodeStatusOutput {
+	return o
+}
+
+// Errors occurred while the status was collected.
+func (o NodeStatusOutput) Error() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v NodeStatus) *string { return v.Error }).(pulumi.StringPtrOutput)
+}
+
+// The etcd member is a learner and does not vote yet.
+func (o NodeStatusOutput) EtcdLearner() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v NodeStatus) *bool { return v.EtcdLearner }).(pulumi.BoolPtrOutput)
+}
+
+// The node is a member of the etcd cluster.
+func (o NodeStatusOutput) EtcdMember() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v NodeStatus) *bool { return v.EtcdMember }).(pulumi.BoolPtrOutput)
+}
+
+// ID of the etcd member.
+func (o NodeStatusOutput) EtcdMemberId() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v NodeStatus) *string { return v.EtcdMemberId }).(pulumi.StringPtrOutput)
+}
+
+// Kubelet version from the kubelet image. Empty if kubelet is not configured yet.
+func (o NodeStatusOutput) KubeletVersion() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v NodeStatus) *string { return v.KubeletVersion }).(pulumi.StringPtrOutput)
+}
+
+// Machine type from the running configuration.
+func (o NodeStatusOutput) MachineType() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v NodeStatus) *string { return v.MachineType }).(pulumi.StringPtrOutput)
+}
+
+// The IP address of the node.
+func (o NodeStatusOutput) Node() pulumi.StringOutput {
+	return o.ApplyT(func(v NodeStatus) string { return v.Node }).(pulumi.StringOutput)
+}
+
+// The node is running, all Talos conditions are met
+// and, for controlplanes, the node is a voting etcd member.
+func (o NodeStatusOutput) Ready() pulumi.BoolOutput {
+	return o.ApplyT(func(v NodeStatus) bool { return v.Ready }).(pulumi.BoolOutput)
+}
+
+// Machine stage reported by Talos, e.g. maintenance, booting, running.
+// It is unknown if the node can't be reached.
+func (o NodeStatusOutput) Stage() pulumi.StringOutput {
+	return o.ApplyT(func(v NodeStatus) string { return v.Stage }).(pulumi.StringOutput)
+}
+
+// Running Talos version.
+func (o NodeStatusOutput) TalosVersion() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v NodeStatus) *string { return v.TalosVersion }).(pulumi.StringPtrOutput)
+}
+
+type NodeStatusArrayOutput struct{ *pulumi.OutputState }
+
+func (NodeStatusArrayOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]NodeStatus)(nil)).Elem()
+}
+
+func (o NodeStatusArrayOutput) ToNodeStatusArrayOutput() NodeStatusArrayOutput {
+	return o
+}
+
+func (o NodeStatusArrayOutput) ToNodeStatusArrayOutputWithContext(ctx context.Context) NodeStatusArrayOutput {
+	return o
+}
+
+func (o NodeStatusArrayOutput) Index(i pulumi.IntInput) NodeStatusOutput {
+	return pulumi.All(o, i).ApplyT(func(vs []interface{}) NodeStatus {
+		return vs[0].([]NodeStatus)[vs[1].(int)]
+	}).(NodeStatusOutput)
+}
+
+type TalosconfigSpec struct {
+	// Endpoints of the talosconfig. Default is all controlplanes.
+	Endpoints []string `pulumi:"endpoints"`
+	// Name of the talosconfig and its context.
+	Name string `pulumi:"name"`
+	// Nodes of the talosconfig. Default is all machines.
+	Nodes []string `pulumi:"nodes"`
+	// Talos API roles of the client certificate.
+	// Supported roles: os:admin, os:operator, os:reader, os:etcd:backup.
+	Roles []string `pulumi:"roles"`
+	// Lifetime of the client certificate, e.g. 720h.
+	// The certificate is renewed in the middle of its lifetime.
+	// Default is 8760h0m0s.
+	Ttl *string `pulumi:"ttl"`
+}
+
+// TalosconfigSpecInput is an input type that accepts TalosconfigSpecArgs and TalosconfigSpecOutput values.
+// You can construct a concrete instance of `TalosconfigSpecInput` via:
+//
+//	TalosconfigSpecArgs{...}
+type TalosconfigSpecInput interface {
+	pulumi.Input
+
+	ToTalosconfigSpecOutput() TalosconfigSpecOutput
+	ToTalosconfigSpecOutputWithContext(context.Context) TalosconfigSpecOutput
+}
+
+type TalosconfigSpecArgs struct {
+	// Endpoints of the talosconfig. Default is all controlplanes.
+	Endpoints pulumi.StringArrayInput `pulumi:"endpoints"`
+	// Name of the talosconfig and its context.
+	Name pulumi.StringInput `pulumi:"name"`
+	// Nodes of the talosconfig. Default is all machines.
+	Nodes pulumi.StringArrayInput `pulumi:"nodes"`
+	// Talos API roles of the client certificate.
+	// Supported roles: os:admin, os:operator, os:reader, os:etcd:backup.
+	Roles pulumi.StringArrayInput `pulumi:"roles"`
+	// Lifetime of the client certificate, e.g. 720h.
+	// The certificate is renewed in the middle of its lifetime.
+	// Default is 8760h0m0s.
+	Ttl pulumi.StringPtrInput `pulumi:"ttl"`
+}
+
+func (TalosconfigSpecArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*TalosconfigSpec)(nil)).Elem()
+}
+
+func (i TalosconfigSpecArgs) ToTalosconfigSpecOutput() TalosconfigSpecOutput {
+	return i.ToTalosconfigSpecOutputWithContext(context.Background())
+}
+
+func (i TalosconfigSpecArgs) ToTalosconfigSpecOutputWithContext(ctx context.Context) TalosconfigSpecOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(TalosconfigSpecOutput)
+}
+
+// TalosconfigSpecArrayInput is an input type that accepts TalosconfigSpecArray and TalosconfigSpecArrayOutput values.
+// You can construct a concrete instance of `TalosconfigSpecArrayInput` via:
+//
+//	TalosconfigSpecArray{ TalosconfigSpecArgs{...} }
+type TalosconfigSpecArrayInput interface {
+	pulumi.Input
+
+	ToTalosconfigSpecArrayOutput() TalosconfigSpecArrayOutput
+	ToTalosconfigSpecArrayOutputWithContext(context.Context) TalosconfigSpecArrayOutput
+}
+
+type TalosconfigSpecArray []TalosconfigSpecInput
+
+func (TalosconfigSpecArray) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]TalosconfigSpec)(nil)).Elem()
+}
+
+func (i TalosconfigSpecArray) ToTalosconfigSpecArrayOutput() TalosconfigSpecArrayOutput {
+	return i.ToTalosconfigSpecArrayOutputWithContext(context.Background())
+}
+
+func (i TalosconfigSpecArray) ToTalosconfigSpecArrayOutputWithContext(ctx context.Context) TalosconfigSpecArrayOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(TalosconfigSpecArrayOutput)
+}
+
+type TalosconfigSpecOutput struct{ *pulumi.OutputState }
+
+func (TalosconfigSpecOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*TalosconfigSpec)(nil)).Elem()
+}
+
+func (o TalosconfigSpecOutput) ToTalosconfigSpecOutput() TalosconfigSpecOutput {
+	return o
+}
+
+func (o TalosconfigSpecOutput) ToTalosconfigSpecOutputWithContext(ctx context.Context) TalosconfigSpecOutput {
+	return o
+}
+
+// Endpoints of the talosconfig. Default is all controlplanes.
+func (o TalosconfigSpecOutput) Endpoints() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v TalosconfigSpec) []string { return v.Endpoints }).(pulumi.StringArrayOutput)
+}
+
+// Name of the talosconfig and its context.
+func (o TalosconfigSpecOutput) Name() pulumi.StringOutput {
+	return o.ApplyT(func(v TalosconfigSpec) string { return v.Name }).(pulumi.StringOutput)
+}
+
+// Nodes of the talosconfig. Default is all machines.
+func (o TalosconfigSpecOutput) Nodes() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v TalosconfigSpec) []string { return v.Nodes }).(pulumi.StringArrayOutput)
+}
+
+// Talos API roles of the client certificate.
+// Supported roles: os:admin, os:operator, os:reader, os:etcd:backup.
+func (o TalosconfigSpecOutput) Roles() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v TalosconfigSpec) []string { return v.Roles }).(pulumi.StringArrayOutput)
+}
+
+// Lifetime of the client certificate, e.g. 720h.
+// The certificate is renewed in the middle of its lifetime.
+// Default is 8760h0m0s.
+func (o TalosconfigSpecOutput) Ttl() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v TalosconfigSpec) *string { return v.Ttl }).(pulumi.StringPtrOutput)
+}
+
+type TalosconfigSpecArrayOutput struct{ *pulumi.OutputState }
+
+func (TalosconfigSpecArrayOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]TalosconfigSpec)(nil)).Elem()
+}
+
+func (o TalosconfigSpecArrayOutput) ToTalosconfigSpecArrayOutput() TalosconfigSpecArrayOutput {
+	return o
+}
+
+func (o TalosconfigSpecArrayOutput) ToTalosconfigSpecArrayOutputWithContext(ctx context.Context) TalosconfigSpecArrayOutput {
+	return o
+}
+
+func (o TalosconfigSpecArrayOutput) Index(i pulumi.IntInput) TalosconfigSpecOutput {
+	return pulumi.All(o, i).ApplyT(func(vs []interface{}) TalosconfigSpec {
+		return vs[0].([]TalosconfigSpec)[vs[1].(int)]
+	}).(TalosconfigSpecOutput)
+}
+
 func init() {
 	pulumi.RegisterInputType(reflect.TypeOf((*ApplyMachinesInput)(nil)).Elem(), ApplyMachinesArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*ApplyMachinesPtrInput)(nil)).Elem(), ApplyMachinesArgs{})
 	pulumi.RegisterInputType(reflect.TypeOf((*ClientConfigurationInput)(nil)).Elem(), ClientConfigurationArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*ClientConfigurationPtrInput)(nil)).Elem(), ClientConfigurationArgs{})
 	pulumi.RegisterInputType(reflect.TypeOf((*ClusterMachinesInput)(nil)).Elem(), ClusterMachinesArgs{})
 	pulumi.RegisterInputType(reflect.TypeOf((*ClusterMachinesArrayInput)(nil)).Elem(), ClusterMachinesArray{})
+	pulumi.RegisterInputType(reflect.TypeOf((*KubeconfigOptionsInput)(nil)).Elem(), KubeconfigOptionsArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*KubeconfigOptionsPtrInput)(nil)).Elem(), KubeconfigOptionsArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*MachineConfigurationInput)(nil)).Elem(), MachineConfigurationArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*MachineConfigurationArrayInput)(nil)).Elem(), MachineConfigurationArray{})
 	pulumi.RegisterInputType(reflect.TypeOf((*MachineInfoInput)(nil)).Elem(), MachineInfoArgs{})
 	pulumi.RegisterInputType(reflect.TypeOf((*MachineInfoArrayInput)(nil)).Elem(), MachineInfoArray{})
+	pulumi.RegisterInputType(reflect.TypeOf((*TalosconfigSpecInput)(nil)).Elem(), TalosconfigSpecArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*TalosconfigSpecArrayInput)(nil)).Elem(), TalosconfigSpecArray{})
 	pulumi.RegisterOutputType(ApplyMachinesOutput{})
+	pulumi.RegisterOutputType(ApplyMachinesPtrOutput{})
 	pulumi.RegisterOutputType(ClientConfigurationOutput{})
+	pulumi.RegisterOutputType(ClientConfigurationPtrOutput{})
 	pulumi.RegisterOutputType(ClusterMachinesOutput{})
 	pulumi.RegisterOutputType(ClusterMachinesArrayOutput{})
 	pulumi.RegisterOutputType(CredentialsOutput{})
+	pulumi.RegisterOutputType(KubeconfigOptionsOutput{})
+	pulumi.RegisterOutputType(KubeconfigOptionsPtrOutput{})
+	pulumi.RegisterOutputType(MachineConfigurationOutput{})
+	pulumi.RegisterOutputType(MachineConfigurationArrayOutput{})
 	pulumi.RegisterOutputType(MachineInfoOutput{})
 	pulumi.RegisterOutputType(MachineInfoArrayOutput{})
+	pulumi.RegisterOutputType(MachineStatusOutput{})
+	pulumi.RegisterOutputType(MachineStatusMapOutput{})
+	pulumi.RegisterOutputType(MachinesByTypeOutput{})
+	pulumi.RegisterOutputType(NodeStatusOutput{})
+	pulumi.RegisterOutputType(NodeStatusArrayOutput{})
+	pulumi.RegisterOutputType(TalosconfigSpecOutput{})
+	pulumi.RegisterOutputType(TalosconfigSpecArrayOutput{})
 }
@@ -0,0 +1,91 @@
+// Code generated by pulumi-language-go DO NOT EDIT.
+// *** WARNING: Do not edit by hand unless you're certain you know what you are doing! ***
+
+package taloscluster
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/spigell/pulumi-talos-cluster/sdk/go/talos-cluster/internal"
+)
+
+// Render the machine configuration exactly as the Apply resource sends it to a node.
+// The same merge pipeline is used: user patches are merged into the generated configuration
+// and images of Kubernetes components are kept from the running configuration if it is provided.
+// No node is contacted.
+func RenderMachineConfig(ctx *pulumi.Context, args *RenderMachineConfigArgs, opts ...pulumi.InvokeOption) (*RenderMachineConfigResult, error) {
+	opts = internal.PkgInvokeDefaultOpts(opts)
+	var rv RenderMachineConfigResult
+	err := ctx.Invoke("talos-cluster:index:renderMachineConfig", args, &rv, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &rv, nil
+}
+
+type RenderMachineConfigArgs struct {
+	// Generated machine configuration.
+	// This can be retrieved from the cluster resource.
+	Configuration string `pulumi:"configuration"`
+	// Configuration currently running on the node, e.g. spec of `talosctl get machineconfig`.
+	// If set, images of Kubernetes components are taken from it to prevent downgrades.
+	CurrentConfiguration *string `pulumi:"currentConfiguration"`
+	// User-provided machine configuration patches as a multi-document YAML.
+	// This can be retrieved from the cluster resource.
+	UserConfigPatches *string `pulumi:"userConfigPatches"`
+}
+
+type RenderMachineConfigResult struct {
+	// The final machine configuration YAML.
+	MachineConfiguration string `pulumi:"machineConfiguration"`
+}
+
+func RenderMachineConfigOutput(ctx *pulumi.Context, args RenderMachineConfigOutputArgs, opts ...pulumi.InvokeOption) RenderMachineConfigResultOutput {
+	return pulumi.ToOutputWithContext(ctx.Context(), args).
+		ApplyT(func(v interface{}) (RenderMachineConfigResultOutput, error) {
+			args := v.(RenderMachineConfigArgs)
+			options := pulumi.InvokeOutputOptions{InvokeOptions: internal.PkgInvokeDefaultOpts(opts)}
+			return ctx.InvokeOutput("talos-cluster:index:renderMachineConfig", args, RenderMachineConfigResultOutput{}, options).(RenderMachineConfigResultOutput), nil
+		}).(RenderMachineConfigResultOutput)
+}
+
+type RenderMachineConfigOutputArgs struct {
+	// Generated machine configuration.
+	// This can be retrieved from the cluster resource.
+	Configuration pulumi.StringInput `pulumi:"configuration"`
+	// Configuration currently running on the node, e.g. spec of `talosctl get machineconfig`.
+	// If set, images of Kubernetes components are taken from it to prevent downgrades.
+	CurrentConfiguration pulumi.StringPtrInput `pulumi:"currentConfiguration"`
+	// User-provided machine configuration patches as a multi-document YAML.
+	// This can be retrieved from the cluster resource.
+	UserConfigPatches pulumi.StringPtrInput `pulumi:"userConfigPatches"`
+}
+
+func (RenderMachineConfigOutputArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*RenderMachineConfigArgs)(nil)).Elem()
+}
+
+type RenderMachineConfigResultOutput struct{ *pulumi.OutputState }
+
+func (RenderMachineConfigResultOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*RenderMachineConfigResult)(nil)).Elem()
+}
+
+func (o RenderMachineConfigResultOutput) ToRenderMachineConfigResultOutput() RenderMachineConfigResultOutput {
+	return o
+}
+
+func (o RenderMachineConfigResultOutput) ToRenderMachineConfigResultOutputWithContext(ctx context.Context) RenderMachineConfigResultOutput {
+	return o
+}
+
+// The final machine configuration YAML.
+func (o RenderMachineConfigResultOutput) MachineConfiguration() pulumi.StringOutput {
+	return o.ApplyT(func(v RenderMachineConfigResult) string { return v.MachineConfiguration }).(pulumi.StringOutput)
+}
+
+func init() {
+	pulumi.RegisterOutputType(RenderMachineConfigResultOutput{})
+}
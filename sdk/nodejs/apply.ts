@@ -25,7 +25,32 @@ export class Apply extends pulumi.ComponentResource {
         return obj['__pulumiType'] === Apply.__pulumiType;
     }
 
+    /**
+     * Expiry time of the admin kubeconfig client certificate in RFC 3339 format.
+     */
+    declare public /*out*/ readonly adminCertificateExpiry: pulumi.Output<string | undefined>;
+    /**
+     * Kubeconfig and talosconfig of the cluster.
+     */
     declare public /*out*/ readonly credentials: pulumi.Output<outputs.Credentials>;
+    /**
+     * Configuration fields which differ between the desired and the running configuration, keyed by machine ID. 
+     * Populated only if detectDrift is enabled.
+     */
+    declare public /*out*/ readonly drift: pulumi.Output<{[key: string]: string[]} | undefined>;
+    /**
+     * Applied state of every machine keyed by machine ID.
+     */
+    declare public /*out*/ readonly machines: pulumi.Output<{[key: string]: outputs.MachineStatus} | undefined>;
+    /**
+     * Output of `talosctl apply-config --dry-run` for machines with changed configuration, keyed by machine ID. 
+     * Populated only during preview. Values of keys, certificates and tokens are redacted.
+     */
+    declare public /*out*/ readonly plannedConfigDiff: pulumi.Output<{[key: string]: string} | undefined>;
+    /**
+     * Talosconfigs from additionalTalosconfigs keyed by name.
+     */
+    declare public /*out*/ readonly talosconfigs: pulumi.Output<{[key: string]: string} | undefined>;
 
     /**
      * Create a Apply resource with the given unique name, arguments, and options.
@@ -34,24 +59,39 @@ export class Apply extends pulumi.ComponentResource {
      * @param args The arguments to use to populate this resource's properties.
      * @param opts A bag of options that control this resource's behavior.
      */
-    constructor(name: string, args: ApplyArgs, opts?: pulumi.ComponentResourceOptions) {
+    constructor(name: string, args?: ApplyArgs, opts?: pulumi.ComponentResourceOptions) {
         let resourceInputs: pulumi.Inputs = {};
         opts = opts || {};
         if (!opts.id) {
-            if (args?.applyMachines === undefined && !opts.urn) {
-                throw new Error("Missing required property 'applyMachines'");
-            }
-            if (args?.clientConfiguration === undefined && !opts.urn) {
-                throw new Error("Missing required property 'clientConfiguration'");
-            }
+            resourceInputs["additionalTalosconfigs"] = args?.additionalTalosconfigs;
             resourceInputs["applyMachines"] = args?.applyMachines;
+            resourceInputs["applyMode"] = (args?.applyMode) ?? "auto";
             resourceInputs["clientConfiguration"] = args?.clientConfiguration;
+            resourceInputs["clientTalosconfig"] = args?.clientTalosconfig ? pulumi.secret(args.clientTalosconfig) : undefined;
+            resourceInputs["detectDrift"] = (args?.detectDrift) ?? false;
+            resourceInputs["kubeconfig"] = args?.kubeconfig;
+            resourceInputs["machineConfigurations"] = args?.machineConfigurations;
+            resourceInputs["reapplyOnDrift"] = (args?.reapplyOnDrift) ?? false;
+            resourceInputs["rebootTimeout"] = (args?.rebootTimeout) ?? "10m0s";
             resourceInputs["skipInitApply"] = (args?.skipInitApply) ?? false;
+            resourceInputs["tryTimeout"] = (args?.tryTimeout) ?? "1m";
+            resourceInputs["adminCertificateExpiry"] = undefined /*out*/;
             resourceInputs["credentials"] = undefined /*out*/;
+            resourceInputs["drift"] = undefined /*out*/;
+            resourceInputs["machines"] = undefined /*out*/;
+            resourceInputs["plannedConfigDiff"] = undefined /*out*/;
+            resourceInputs["talosconfigs"] = undefined /*out*/;
         } else {
+            resourceInputs["adminCertificateExpiry"] = undefined /*out*/;
             resourceInputs["credentials"] = undefined /*out*/;
+            resourceInputs["drift"] = undefined /*out*/;
+            resourceInputs["machines"] = undefined /*out*/;
+            resourceInputs["plannedConfigDiff"] = undefined /*out*/;
+            resourceInputs["talosconfigs"] = undefined /*out*/;
         }
         opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts);
+        const secretOpts = { additionalSecretOutputs: ["credentials", "plannedConfigDiff", "talosconfigs"] };
+        opts = pulumi.mergeOptions(opts, secretOpts);
         super(Apply.__pulumiType, name, resourceInputs, opts, true /*remote*/);
     }
 }
@@ -61,13 +101,62 @@ export class Apply extends pulumi.ComponentResource {
  */
 export interface ApplyArgs {
     /**
-     * The machine configurations to apply.
+     * Talosconfigs with their own roles, e.g. os:reader for on-call engineers. 
+     * Client certificates are signed by the Talos CA from the configuration of the init node.
+     */
+    additionalTalosconfigs?: pulumi.Input<pulumi.Input<inputs.TalosconfigSpecArgs>[]>;
+    /**
+     * The machine configurations to apply, usually the machines output of the Cluster resource. 
+     * Either applyMachines or machineConfigurations is required.
+     */
+    applyMachines?: pulumi.Input<inputs.ApplyMachinesArgs>;
+    /**
+     * Default mode of `talosctl apply-config` for machines without their own applyMode. 
+     * Changes requiring a reboot fail in no-reboot and try modes before reaching the node. 
+     * Default is auto.
      */
-    applyMachines: pulumi.Input<inputs.ApplyMachinesArgs>;
+    applyMode?: pulumi.Input<enums.ApplyMode>;
     /**
-     * Client configuration for bootstrapping and applying resources.
+     * Client configuration for bootstrapping and applying resources. 
+     * Either clientConfiguration or clientTalosconfig is required.
      */
-    clientConfiguration: pulumi.Input<inputs.ClientConfigurationArgs>;
+    clientConfiguration?: pulumi.Input<inputs.ClientConfigurationArgs>;
+    /**
+     * Talosconfig whose current context gives the client credentials to access the nodes. 
+     * Either clientConfiguration or clientTalosconfig is required.
+     */
+    clientTalosconfig?: pulumi.Input<string>;
+    /**
+     * detectDrift fetches the running configuration from every machine after apply 
+     * and compares it with the desired one to find changes made out of band (e.g. via `talosctl edit mc`). 
+     * Images of Kubernetes components are ignored since they are managed by upgrade-k8s. 
+     * Default is false.
+     */
+    detectDrift?: pulumi.Input<boolean>;
+    /**
+     * Options of the admin kubeconfig in credentials. 
+     * The kubeconfig is signed locally by the Kubernetes CA from the configuration of the init node.
+     */
+    kubeconfig?: pulumi.Input<inputs.KubeconfigOptionsArgs>;
+    /**
+     * Machine configurations generated by other tools, e.g. `talosctl gen config` or talhelper. 
+     * The machine type, Talos image, Kubernetes version and cluster endpoint are taken from every configuration. 
+     * etcd is bootstrapped on the machine of the init type or on the first controlplane. 
+     * Either applyMachines or machineConfigurations is required.
+     */
+    machineConfigurations?: pulumi.Input<pulumi.Input<inputs.MachineConfigurationArgs>[]>;
+    /**
+     * reapplyOnDrift applies the desired configuration again if a drift is detected. 
+     * Requires detectDrift. 
+     * Default is false.
+     */
+    reapplyOnDrift?: pulumi.Input<boolean>;
+    /**
+     * How long to wait for a node to come back after a reboot. 
+     * The node is back when it reports a new boot ID and reaches the running or maintenance stage. 
+     * Default is 10m0s.
+     */
+    rebootTimeout?: pulumi.Input<string>;
     /**
      * skipInitApply indicates that machines will be managed or configured by external tools. 
      * For example, it can serve as a source for userdata in cloud provider setups. 
@@ -76,4 +165,9 @@ export interface ApplyArgs {
      * Default is false.
      */
     skipInitApply?: pulumi.Input<boolean>;
+    /**
+     * Default duration after which a configuration applied in try mode is rolled back. 
+     * Default is 1m.
+     */
+    tryTimeout?: pulumi.Input<string>;
 }
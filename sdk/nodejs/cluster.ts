@@ -35,10 +35,15 @@ export class Cluster extends pulumi.ComponentResource {
      * Generated machine configuration YAML keyed by machine ID.
      */
     declare public /*out*/ readonly generatedConfigurations: pulumi.Output<{[key: string]: string}>;
+    /**
+     * Cluster secrets bundle and admin client certificate generated on creation (YAML). 
+     * It is kept in the state and never regenerated.
+     */
+    declare public /*out*/ readonly machineSecrets: pulumi.Output<string>;
     /**
      * Machine information grouped by machine type.
      */
-    declare public /*out*/ readonly machines: pulumi.Output<outputs.ApplyMachines>;
+    declare public /*out*/ readonly machines: pulumi.Output<outputs.MachinesByType>;
 
     /**
      * Create a Cluster resource with the given unique name, arguments, and options.
@@ -63,19 +68,60 @@ export class Cluster extends pulumi.ComponentResource {
             resourceInputs["clusterEndpoint"] = args?.clusterEndpoint;
             resourceInputs["clusterMachines"] = args?.clusterMachines;
             resourceInputs["clusterName"] = args?.clusterName;
+            resourceInputs["existingSecrets"] = args?.existingSecrets ? pulumi.secret(args.existingSecrets) : undefined;
             resourceInputs["kubernetesVersion"] = (args?.kubernetesVersion) ?? "v1.33.0";
             resourceInputs["talosVersionContract"] = (args?.talosVersionContract) ?? "v1.12.0";
             resourceInputs["clientConfiguration"] = undefined /*out*/;
             resourceInputs["generatedConfigurations"] = undefined /*out*/;
+            resourceInputs["machineSecrets"] = undefined /*out*/;
             resourceInputs["machines"] = undefined /*out*/;
         } else {
             resourceInputs["clientConfiguration"] = undefined /*out*/;
             resourceInputs["generatedConfigurations"] = undefined /*out*/;
+            resourceInputs["machineSecrets"] = undefined /*out*/;
             resourceInputs["machines"] = undefined /*out*/;
         }
         opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts);
+        const secretOpts = { additionalSecretOutputs: ["generatedConfigurations", "machineSecrets"] };
+        opts = pulumi.mergeOptions(opts, secretOpts);
         super(Cluster.__pulumiType, name, resourceInputs, opts, true /*remote*/);
     }
+
+    /**
+     * Generate a configuration for a new machine of the role, which joins the cluster.
+     * It uses the cluster secrets and the default installation image.
+     */
+    getJoinConfig(args: Cluster.GetJoinConfigArgs): pulumi.Output<Cluster.GetJoinConfigResult> {
+        return pulumi.runtime.call("talos-cluster:index:Cluster/getJoinConfig", {
+            "__self__": this,
+            "role": args.role,
+        }, this);
+    }
+
+    /**
+     * Get the generated configuration of a machine merged with extra patches. No node is contacted.
+     */
+    getMachineConfig(args: Cluster.GetMachineConfigArgs): pulumi.Output<Cluster.GetMachineConfigResult> {
+        return pulumi.runtime.call("talos-cluster:index:Cluster/getMachineConfig", {
+            "__self__": this,
+            "extraPatches": args.extraPatches,
+            "machineId": args.machineId,
+        }, this);
+    }
+
+    /**
+     * Issue a talosconfig with a new client certificate signed by the Talos CA of the cluster.
+     * A new certificate is issued on every call.
+     */
+    getTalosconfig(args?: Cluster.GetTalosconfigArgs): pulumi.Output<Cluster.GetTalosconfigResult> {
+        args = args || {};
+        return pulumi.runtime.call("talos-cluster:index:Cluster/getTalosconfig", {
+            "__self__": this,
+            "endpoints": args.endpoints,
+            "nodes": args.nodes,
+            "roles": args.roles,
+        }, this);
+    }
 }
 
 /**
@@ -94,6 +140,12 @@ export interface ClusterArgs {
      * Name of the cluster
      */
     clusterName: string;
+    /**
+     * Existing secrets bundle (YAML) to use instead of a generated one. 
+     * Accepts the output of `talosctl gen secrets` or the machineSecrets of the pulumiverse talos Secrets resource. 
+     * It is only read on creation of machineSecrets, e.g. to adopt a cluster created outside of the stack.
+     */
+    existingSecrets?: pulumi.Input<string>;
     /**
      * Kubernetes version to install. 
      * Default is v1.33.0.
@@ -102,10 +154,85 @@ export interface ClusterArgs {
     /**
      * Version of Talos features used for configuration generation. 
      * Do not confuse this with the talosImage property. 
-     * Used in NewSecrets() and GetConfigurationOutput() resources. 
-     * This property is immutable to prevent version conflicts across provider updates. 
+     * Used to generate the secrets bundle and machine configurations. 
+     * This property is immutable: the value used on creation is kept in machineSecrets. 
      * See issue: https://github.com/siderolabs/terraform-provider-talos/issues/168 
      * The default value is based on gendata.VersionTag, current: v1.12.0.
      */
     talosVersionContract?: pulumi.Input<string>;
 }
+
+export namespace Cluster {
+    /**
+     * The set of arguments for the Cluster.getJoinConfig method.
+     */
+    export interface GetJoinConfigArgs {
+        /**
+         * Machine type of the new machine: controlplane or worker.
+         */
+        role: pulumi.Input<string>;
+    }
+
+    /**
+     * The results of the Cluster.getJoinConfig method.
+     */
+    export interface GetJoinConfigResult {
+        /**
+         * Machine configuration YAML.
+         */
+        readonly configuration: string;
+    }
+
+    /**
+     * The set of arguments for the Cluster.getMachineConfig method.
+     */
+    export interface GetMachineConfigArgs {
+        /**
+         * Patches to merge into the configuration in the same way as configPatches.
+         */
+        extraPatches?: pulumi.Input<pulumi.Input<string>[]>;
+        /**
+         * ID of the machine.
+         */
+        machineId: pulumi.Input<string>;
+    }
+
+    /**
+     * The results of the Cluster.getMachineConfig method.
+     */
+    export interface GetMachineConfigResult {
+        /**
+         * Machine configuration YAML.
+         */
+        readonly configuration: string;
+    }
+
+    /**
+     * The set of arguments for the Cluster.getTalosconfig method.
+     */
+    export interface GetTalosconfigArgs {
+        /**
+         * Endpoints of the talosconfig context.
+         */
+        endpoints?: pulumi.Input<pulumi.Input<string>[]>;
+        /**
+         * Nodes of the talosconfig context.
+         */
+        nodes?: pulumi.Input<pulumi.Input<string>[]>;
+        /**
+         * Talos API roles of the certificate, e.g. os:reader. Default is os:admin.
+         */
+        roles?: pulumi.Input<pulumi.Input<string>[]>;
+    }
+
+    /**
+     * The results of the Cluster.getTalosconfig method.
+     */
+    export interface GetTalosconfigResult {
+        /**
+         * The talosconfig.
+         */
+        readonly talosconfig: string;
+    }
+
+}
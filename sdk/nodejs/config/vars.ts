@@ -0,0 +1,97 @@
+// *** WARNING: this file was generated by pulumi-language-nodejs. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import * as pulumi from "@pulumi/pulumi";
+import * as inputs from "./types/input";
+import * as outputs from "./types/output";
+import * as enums from "./types/enums";
+import * as utilities from "./utilities";
+
+declare var exports: any;
+const __config = new pulumi.Config("talos-cluster");
+
+/**
+ * Diagnostics collected from a node when `talosctl upgrade` or `talosctl apply-config` fails. 
+ * `basic` collects dmesg, the service list, logs of machined (and etcd on controlplanes during upgrade) and the machine status. 
+ * `support` adds a `talosctl support` archive, which takes a few minutes. 
+ * The path of the bundle is a part of the error message. 
+ * Default is basic.
+ */
+export declare const debugBundle: enums.DebugBundle | undefined;
+Object.defineProperty(exports, "debugBundle", {
+    get() {
+        return __config.getObject<enums.DebugBundle>("debugBundle");
+    },
+    enumerable: true,
+});
+
+/**
+ * Root directory for debug bundles. 
+ * Bundles are written to `<debugBundleDir>/<stack>/<cluster>/<stage>-<machine>/<time>` and are not removed. 
+ * Default is the debug directory inside the working directories of workDir.
+ */
+export declare const debugBundleDir: string | undefined;
+Object.defineProperty(exports, "debugBundleDir", {
+    get() {
+        return __config.get("debugBundleDir");
+    },
+    enumerable: true,
+});
+
+/**
+ * Migration of stacks created with pulumiverse/talos resources. 
+ * `aliases` registers the initial apply and bootstrap with aliases to the former resources, they are rerun, but skip configured nodes. 
+ * `import` takes the initial apply and bootstrap as done without contacting nodes. 
+ * In both modes the preview fails if a node is not configured, its etcd is not bootstrapped 
+ * or it runs other cluster secrets than the generated configuration. 
+ * Unset it after the migration.
+ */
+export declare const migrationMode: enums.MigrationMode | undefined;
+Object.defineProperty(exports, "migrationMode", {
+    get() {
+        return __config.getObject<enums.MigrationMode>("migrationMode");
+    },
+    enumerable: true,
+});
+
+/**
+ * Path to the talosctl binary or to a directory with talosctl binaries for different Talos versions. 
+ * If a directory is set, the binary matching the Talos version of a machine image is used for the machine, 
+ * and the newest one otherwise. 
+ * Default is talosctl from PATH.
+ */
+export declare const talosctlPath: string | undefined;
+Object.defineProperty(exports, "talosctlPath", {
+    get() {
+        return __config.get("talosctlPath");
+    },
+    enumerable: true,
+});
+
+/**
+ * Reaction on skew between the talosctl version and the Talos version of a machine image. 
+ * The version of every binary is checked via `talosctl version --client` on provider start. 
+ * Default is warn.
+ */
+export declare const talosctlVersionCheck: enums.TalosctlVersionCheck | undefined;
+Object.defineProperty(exports, "talosctlVersionCheck", {
+    get() {
+        return __config.getObject<enums.TalosctlVersionCheck>("talosctlVersionCheck");
+    },
+    enumerable: true,
+});
+
+/**
+ * Root directory for talosctl working directories. 
+ * Files with credentials and machine configurations are written to `<workDir>/talos-cluster-<uid>`, 
+ * which is accessible only by the current user. 
+ * Default is the system temporary directory.
+ */
+export declare const workDir: string | undefined;
+Object.defineProperty(exports, "workDir", {
+    get() {
+        return __config.get("workDir");
+    },
+    enumerable: true,
+});
+
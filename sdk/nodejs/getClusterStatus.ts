@@ -0,0 +1,66 @@
+// *** WARNING: this file was generated by pulumi-language-nodejs. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import * as pulumi from "@pulumi/pulumi";
+import * as inputs from "./types/input";
+import * as outputs from "./types/output";
+import * as enums from "./types/enums";
+import * as utilities from "./utilities";
+
+/**
+ * Get the observed status of Talos nodes:
+ * - Talos and kubelet versions
+ * - Machine type and stage
+ * - Etcd membership
+ *   Nodes which can't be reached are reported with an error instead of failing the whole call.
+ */
+export function getClusterStatus(args: GetClusterStatusArgs, opts?: pulumi.InvokeOptions): Promise<GetClusterStatusResult> {
+    opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts || {});
+    return pulumi.runtime.invoke("talos-cluster:index:getClusterStatus", {
+        "clientConfiguration": args.clientConfiguration,
+        "nodes": args.nodes,
+    }, opts);
+}
+
+export interface GetClusterStatusArgs {
+    /**
+     * Client configuration for bootstrapping and applying resources.
+     */
+    clientConfiguration: inputs.ClientConfiguration;
+    /**
+     * IP addresses of nodes to query. Every node is used as its own endpoint.
+     */
+    nodes: string[];
+}
+
+export interface GetClusterStatusResult {
+    /**
+     * Status of every requested node in the same order.
+     */
+    readonly nodes: outputs.NodeStatus[];
+}
+/**
+ * Get the observed status of Talos nodes:
+ * - Talos and kubelet versions
+ * - Machine type and stage
+ * - Etcd membership
+ *   Nodes which can't be reached are reported with an error instead of failing the whole call.
+ */
+export function getClusterStatusOutput(args: GetClusterStatusOutputArgs, opts?: pulumi.InvokeOutputOptions): pulumi.Output<GetClusterStatusResult> {
+    opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts || {});
+    return pulumi.runtime.invokeOutput("talos-cluster:index:getClusterStatus", {
+        "clientConfiguration": args.clientConfiguration,
+        "nodes": args.nodes,
+    }, opts);
+}
+
+export interface GetClusterStatusOutputArgs {
+    /**
+     * Client configuration for bootstrapping and applying resources.
+     */
+    clientConfiguration: pulumi.Input<inputs.ClientConfigurationArgs>;
+    /**
+     * IP addresses of nodes to query. Every node is used as its own endpoint.
+     */
+    nodes: pulumi.Input<pulumi.Input<string>[]>;
+}
@@ -10,24 +10,34 @@ export type Apply = import("./apply").Apply;
 export const Apply: typeof import("./apply").Apply = null as any;
 utilities.lazyLoad(exports, ["Apply"], () => require("./apply"));
 
-export { ClusterArgs } from "./cluster";
-export type Cluster = import("./cluster").Cluster;
-export const Cluster: typeof import("./cluster").Cluster = null as any;
-utilities.lazyLoad(exports, ["Cluster"], () => require("./cluster"));
+export * from "./cluster";
+import { Cluster } from "./cluster";
+
+export { GetClusterStatusArgs, GetClusterStatusResult, GetClusterStatusOutputArgs } from "./getClusterStatus";
+export const getClusterStatus: typeof import("./getClusterStatus").getClusterStatus = null as any;
+export const getClusterStatusOutput: typeof import("./getClusterStatus").getClusterStatusOutput = null as any;
+utilities.lazyLoad(exports, ["getClusterStatus","getClusterStatusOutput"], () => require("./getClusterStatus"));
 
 export { ProviderArgs } from "./provider";
 export type Provider = import("./provider").Provider;
 export const Provider: typeof import("./provider").Provider = null as any;
 utilities.lazyLoad(exports, ["Provider"], () => require("./provider"));
 
+export { RenderMachineConfigArgs, RenderMachineConfigResult, RenderMachineConfigOutputArgs } from "./renderMachineConfig";
+export const renderMachineConfig: typeof import("./renderMachineConfig").renderMachineConfig = null as any;
+export const renderMachineConfigOutput: typeof import("./renderMachineConfig").renderMachineConfigOutput = null as any;
+utilities.lazyLoad(exports, ["renderMachineConfig","renderMachineConfigOutput"], () => require("./renderMachineConfig"));
+
 
 // Export enums:
 export * from "./types/enums";
 
 // Export sub-modules:
+import * as config from "./config";
 import * as types from "./types";
 
 export {
+    config,
     types,
 };
 
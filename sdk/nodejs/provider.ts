@@ -2,8 +2,14 @@
 // *** Do not edit by hand unless you're certain you know what you are doing! ***
 
 import * as pulumi from "@pulumi/pulumi";
+import * as inputs from "./types/input";
+import * as outputs from "./types/output";
+import * as enums from "./types/enums";
 import * as utilities from "./utilities";
 
+/**
+ * The provider type for the talos-cluster package.
+ */
 export class Provider extends pulumi.ProviderResource {
     /** @internal */
     public static readonly __pulumiType = 'talos-cluster';
@@ -31,6 +37,12 @@ export class Provider extends pulumi.ProviderResource {
         let resourceInputs: pulumi.Inputs = {};
         opts = opts || {};
         {
+            resourceInputs["debugBundle"] = args?.debugBundle;
+            resourceInputs["debugBundleDir"] = args?.debugBundleDir;
+            resourceInputs["migrationMode"] = args?.migrationMode;
+            resourceInputs["talosctlPath"] = args?.talosctlPath;
+            resourceInputs["talosctlVersionCheck"] = args?.talosctlVersionCheck;
+            resourceInputs["workDir"] = args?.workDir;
         }
         opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts);
         super(Provider.__pulumiType, name, resourceInputs, opts);
@@ -41,4 +53,47 @@ export class Provider extends pulumi.ProviderResource {
  * The set of arguments for constructing a Provider resource.
  */
 export interface ProviderArgs {
+    /**
+     * Diagnostics collected from a node when `talosctl upgrade` or `talosctl apply-config` fails. 
+     * `basic` collects dmesg, the service list, logs of machined (and etcd on controlplanes during upgrade) and the machine status. 
+     * `support` adds a `talosctl support` archive, which takes a few minutes. 
+     * The path of the bundle is a part of the error message. 
+     * Default is basic.
+     */
+    debugBundle?: pulumi.Input<enums.DebugBundle>;
+    /**
+     * Root directory for debug bundles. 
+     * Bundles are written to `<debugBundleDir>/<stack>/<cluster>/<stage>-<machine>/<time>` and are not removed. 
+     * Default is the debug directory inside the working directories of workDir.
+     */
+    debugBundleDir?: pulumi.Input<string>;
+    /**
+     * Migration of stacks created with pulumiverse/talos resources. 
+     * `aliases` registers the initial apply and bootstrap with aliases to the former resources, they are rerun, but skip configured nodes. 
+     * `import` takes the initial apply and bootstrap as done without contacting nodes. 
+     * In both modes the preview fails if a node is not configured, its etcd is not bootstrapped 
+     * or it runs other cluster secrets than the generated configuration. 
+     * Unset it after the migration.
+     */
+    migrationMode?: pulumi.Input<enums.MigrationMode>;
+    /**
+     * Path to the talosctl binary or to a directory with talosctl binaries for different Talos versions. 
+     * If a directory is set, the binary matching the Talos version of a machine image is used for the machine, 
+     * and the newest one otherwise. 
+     * Default is talosctl from PATH.
+     */
+    talosctlPath?: pulumi.Input<string>;
+    /**
+     * Reaction on skew between the talosctl version and the Talos version of a machine image. 
+     * The version of every binary is checked via `talosctl version --client` on provider start. 
+     * Default is warn.
+     */
+    talosctlVersionCheck?: pulumi.Input<enums.TalosctlVersionCheck>;
+    /**
+     * Root directory for talosctl working directories. 
+     * Files with credentials and machine configurations are written to `<workDir>/talos-cluster-<uid>`, 
+     * which is accessible only by the current user. 
+     * Default is the system temporary directory.
+     */
+    workDir?: pulumi.Input<string>;
 }
@@ -0,0 +1,77 @@
+// *** WARNING: this file was generated by pulumi-language-nodejs. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import * as pulumi from "@pulumi/pulumi";
+import * as utilities from "./utilities";
+
+/**
+ * Render the machine configuration exactly as the Apply resource sends it to a node.
+ * The same merge pipeline is used: user patches are merged into the generated configuration
+ * and images of Kubernetes components are kept from the running configuration if it is provided.
+ * No node is contacted.
+ */
+export function renderMachineConfig(args: RenderMachineConfigArgs, opts?: pulumi.InvokeOptions): Promise<RenderMachineConfigResult> {
+    opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts || {});
+    return pulumi.runtime.invoke("talos-cluster:index:renderMachineConfig", {
+        "configuration": args.configuration,
+        "currentConfiguration": args.currentConfiguration,
+        "userConfigPatches": args.userConfigPatches,
+    }, opts);
+}
+
+export interface RenderMachineConfigArgs {
+    /**
+     * Generated machine configuration. 
+     * This can be retrieved from the cluster resource.
+     */
+    configuration: string;
+    /**
+     * Configuration currently running on the node, e.g. spec of `talosctl get machineconfig`. 
+     * If set, images of Kubernetes components are taken from it to prevent downgrades.
+     */
+    currentConfiguration?: string;
+    /**
+     * User-provided machine configuration patches as a multi-document YAML. 
+     * This can be retrieved from the cluster resource.
+     */
+    userConfigPatches?: string;
+}
+
+export interface RenderMachineConfigResult {
+    /**
+     * The final machine configuration YAML.
+     */
+    readonly machineConfiguration: string;
+}
+/**
+ * Render the machine configuration exactly as the Apply resource sends it to a node.
+ * The same merge pipeline is used: user patches are merged into the generated configuration
+ * and images of Kubernetes components are kept from the running configuration if it is provided.
+ * No node is contacted.
+ */
+export function renderMachineConfigOutput(args: RenderMachineConfigOutputArgs, opts?: pulumi.InvokeOutputOptions): pulumi.Output<RenderMachineConfigResult> {
+    opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts || {});
+    return pulumi.runtime.invokeOutput("talos-cluster:index:renderMachineConfig", {
+        "configuration": args.configuration,
+        "currentConfiguration": args.currentConfiguration,
+        "userConfigPatches": args.userConfigPatches,
+    }, opts);
+}
+
+export interface RenderMachineConfigOutputArgs {
+    /**
+     * Generated machine configuration. 
+     * This can be retrieved from the cluster resource.
+     */
+    configuration: pulumi.Input<string>;
+    /**
+     * Configuration currently running on the node, e.g. spec of `talosctl get machineconfig`. 
+     * If set, images of Kubernetes components are taken from it to prevent downgrades.
+     */
+    currentConfiguration?: pulumi.Input<string>;
+    /**
+     * User-provided machine configuration patches as a multi-document YAML. 
+     * This can be retrieved from the cluster resource.
+     */
+    userConfigPatches?: pulumi.Input<string>;
+}
@@ -2,6 +2,30 @@
 // *** Do not edit by hand unless you're certain you know what you are doing! ***
 
 
+export const ApplyMode = {
+    Auto: "auto",
+    No_reboot: "no-reboot",
+    Reboot: "reboot",
+    Staged: "staged",
+    Try: "try",
+} as const;
+
+/**
+ * Modes of talosctl apply-config
+ */
+export type ApplyMode = (typeof ApplyMode)[keyof typeof ApplyMode];
+
+export const DebugBundle = {
+    None: "none",
+    Basic: "basic",
+    Support: "support",
+} as const;
+
+/**
+ * Diagnostics collected from a node after a failed operation
+ */
+export type DebugBundle = (typeof DebugBundle)[keyof typeof DebugBundle];
+
 export const MachineTypes = {
     Controlplane: "controlplane",
     Worker: "worker",
@@ -12,3 +36,37 @@ export const MachineTypes = {
  * Allowed machine types
  */
 export type MachineTypes = (typeof MachineTypes)[keyof typeof MachineTypes];
+
+export const MigrationMode = {
+    Aliases: "aliases",
+    Import: "import",
+} as const;
+
+/**
+ * Modes of migration from pulumiverse/talos resources
+ */
+export type MigrationMode = (typeof MigrationMode)[keyof typeof MigrationMode];
+
+export const Operation = {
+    Unknown: "unknown",
+    Initial_apply: "initial-apply",
+    Upgrade: "upgrade",
+    Upgrade_k8s: "upgrade-k8s",
+    Apply_config: "apply-config",
+} as const;
+
+/**
+ * Operations which change a machine
+ */
+export type Operation = (typeof Operation)[keyof typeof Operation];
+
+export const TalosctlVersionCheck = {
+    Warn: "warn",
+    Fail: "fail",
+    Skip: "skip",
+} as const;
+
+/**
+ * Reactions on talosctl version skew
+ */
+export type TalosctlVersionCheck = (typeof TalosctlVersionCheck)[keyof typeof TalosctlVersionCheck];
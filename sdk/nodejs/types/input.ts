@@ -8,12 +8,40 @@ import * as enums from "../types/enums";
 
 import * as utilities from "../utilities";
 
+/**
+ * Machines to apply grouped by machine type. 
+ * It is usually the machines output of the Cluster resource.
+ */
 export interface ApplyMachinesArgs {
+    /**
+     * Machines of the controlplane type.
+     */
     controlplane?: pulumi.Input<pulumi.Input<inputs.MachineInfoArgs>[]>;
+    /**
+     * Machines of the init type.
+     */
     init: pulumi.Input<pulumi.Input<inputs.MachineInfoArgs>[]>;
+    /**
+     * Machines of the worker type.
+     */
     worker?: pulumi.Input<pulumi.Input<inputs.MachineInfoArgs>[]>;
 }
 
+export interface ClientConfiguration {
+    /**
+     * The Certificate Authority (CA) certificate used to verify connections to the Talos API server.
+     */
+    caCertificate?: string;
+    /**
+     * The client certificate used to authenticate to the Talos API server.
+     */
+    clientCertificate?: string;
+    /**
+     * The private key for the client certificate, used for authenticating the client to the Talos API server.
+     */
+    clientKey?: string;
+}
+
 export interface ClientConfigurationArgs {
     /**
      * The Certificate Authority (CA) certificate used to verify connections to the Talos API server.
@@ -30,6 +58,11 @@ export interface ClientConfigurationArgs {
 }
 
 export interface ClusterMachinesArgs {
+    /**
+     * Mode of `talosctl apply-config` for the machine. 
+     * Overrides the applyMode of the Apply resource.
+     */
+    applyMode?: pulumi.Input<enums.ApplyMode>;
     /**
      * User-provided machine configuration to apply. 
      * Must be a valid array of YAML strings. 
@@ -54,6 +87,11 @@ export interface ClusterMachinesArgs {
      * The default is generated based on the Talos machinery version, current: ghcr.io/siderolabs/installer:v1.12.0.
      */
     talosImage?: pulumi.Input<string>;
+    /**
+     * Duration after which a configuration applied in try mode is rolled back. 
+     * Overrides the tryTimeout of the Apply resource.
+     */
+    tryTimeout?: pulumi.Input<string>;
 }
 /**
  * clusterMachinesArgsProvideDefaults sets the appropriate defaults for ClusterMachinesArgs
@@ -65,7 +103,63 @@ export function clusterMachinesArgsProvideDefaults(val: ClusterMachinesArgs): Cl
     };
 }
 
+export interface KubeconfigOptionsArgs {
+    /**
+     * Lifetime of the admin client certificate, e.g. 24h. 
+     * The certificate is renewed in the middle of its lifetime. 
+     * Default is cluster.adminKubeconfig.certLifetime of the init node configuration, one year unless it is patched.
+     */
+    certLifetime?: pulumi.Input<string>;
+    /**
+     * Name of the cluster entry. Default is the cluster name.
+     */
+    clusterName?: pulumi.Input<string>;
+    /**
+     * Name of the context, which is also the current context. Default is admin@<cluster name>.
+     */
+    contextName?: pulumi.Input<string>;
+    /**
+     * URL of the Kubernetes API server, e.g. https://lb.example.com:6443. 
+     * Default is the cluster endpoint from the configuration of the init node.
+     */
+    server?: pulumi.Input<string>;
+    /**
+     * Name of the user entry. Default is admin@<cluster name>.
+     */
+    userName?: pulumi.Input<string>;
+}
+
+export interface MachineConfigurationArgs {
+    /**
+     * Mode of `talosctl apply-config` for the machine. 
+     * Overrides the applyMode of the Apply resource.
+     */
+    applyMode?: pulumi.Input<enums.ApplyMode>;
+    /**
+     * Complete machine configuration of the node (YAML).
+     */
+    configuration: pulumi.Input<string>;
+    /**
+     * ID or name of the machine.
+     */
+    machineId: pulumi.Input<string>;
+    /**
+     * The IP address of the node where configuration will be applied.
+     */
+    nodeIp: pulumi.Input<string>;
+    /**
+     * Duration after which a configuration applied in try mode is rolled back. 
+     * Overrides the tryTimeout of the Apply resource.
+     */
+    tryTimeout?: pulumi.Input<string>;
+}
+
 export interface MachineInfoArgs {
+    /**
+     * Mode of `talosctl apply-config` for the machine. 
+     * Overrides the applyMode of the Apply resource.
+     */
+    applyMode?: pulumi.Input<enums.ApplyMode>;
     /**
      * cluster endpoint applied to node
      */
@@ -76,24 +170,68 @@ export interface MachineInfoArgs {
      */
     configuration: pulumi.Input<string>;
     /**
-     * Kubernetes version to install or upgrade on the node.
+     * Kubernetes version to install or upgrade on the node. 
+     * The version of the kubelet image of the configuration is used if it is not set.
      */
     kubernetesVersion?: pulumi.Input<string>;
     /**
      * ID or name of the machine.
      */
     machineId: pulumi.Input<string>;
+    /**
+     * Type of the machine. 
+     * Set by the Cluster resource. The machine is applied according to its group in applyMachines.
+     */
+    machineType?: pulumi.Input<enums.MachineTypes>;
     /**
      * The IP address of the node where configuration will be applied.
      */
     nodeIp: pulumi.Input<string>;
     /**
-     * Talos OS image to install or upgrade on the node.
+     * Talos OS image to install or upgrade on the node. 
+     * The install image of the configuration is used if it is not set.
      */
     talosImage?: pulumi.Input<string>;
+    /**
+     * Duration after which a configuration applied in try mode is rolled back. 
+     * Overrides the tryTimeout of the Apply resource.
+     */
+    tryTimeout?: pulumi.Input<string>;
     /**
      * User-provided machine configuration to apply. 
      * This can be retrieved from the cluster resource.
      */
     userConfigPatches?: pulumi.Input<string>;
+    /**
+     * Version of the machine info format. 
+     * Set by the Cluster resource, the current version is 1. 
+     * Machines without it are parsed as version 0.
+     */
+    version?: pulumi.Input<number>;
+}
+
+export interface TalosconfigSpecArgs {
+    /**
+     * Endpoints of the talosconfig. Default is all controlplanes.
+     */
+    endpoints?: pulumi.Input<pulumi.Input<string>[]>;
+    /**
+     * Name of the talosconfig and its context.
+     */
+    name: pulumi.Input<string>;
+    /**
+     * Nodes of the talosconfig. Default is all machines.
+     */
+    nodes?: pulumi.Input<pulumi.Input<string>[]>;
+    /**
+     * Talos API roles of the client certificate. 
+     * Supported roles: os:admin, os:operator, os:reader, os:etcd:backup.
+     */
+    roles: pulumi.Input<pulumi.Input<string>[]>;
+    /**
+     * Lifetime of the client certificate, e.g. 720h. 
+     * The certificate is renewed in the middle of its lifetime. 
+     * Default is 8760h0m0s.
+     */
+    ttl?: pulumi.Input<string>;
 }
@@ -8,12 +8,6 @@ import * as enums from "../types/enums";
 
 import * as utilities from "../utilities";
 
-export interface ApplyMachines {
-    controlplane?: outputs.MachineInfo[];
-    init: outputs.MachineInfo[];
-    worker?: outputs.MachineInfo[];
-}
-
 export interface ClientConfiguration {
     /**
      * The Certificate Authority (CA) certificate used to verify connections to the Talos API server.
@@ -41,6 +35,11 @@ export interface Credentials {
 }
 
 export interface MachineInfo {
+    /**
+     * Mode of `talosctl apply-config` for the machine. 
+     * Overrides the applyMode of the Apply resource.
+     */
+    applyMode?: enums.ApplyMode;
     /**
      * cluster endpoint applied to node
      */
@@ -51,25 +50,134 @@ export interface MachineInfo {
      */
     configuration: string;
     /**
-     * Kubernetes version to install or upgrade on the node.
+     * Kubernetes version to install or upgrade on the node. 
+     * The version of the kubelet image of the configuration is used if it is not set.
      */
     kubernetesVersion?: string;
     /**
      * ID or name of the machine.
      */
     machineId: string;
+    /**
+     * Type of the machine. 
+     * Set by the Cluster resource. The machine is applied according to its group in applyMachines.
+     */
+    machineType?: enums.MachineTypes;
     /**
      * The IP address of the node where configuration will be applied.
      */
     nodeIp: string;
     /**
-     * Talos OS image to install or upgrade on the node.
+     * Talos OS image to install or upgrade on the node. 
+     * The install image of the configuration is used if it is not set.
      */
     talosImage?: string;
+    /**
+     * Duration after which a configuration applied in try mode is rolled back. 
+     * Overrides the tryTimeout of the Apply resource.
+     */
+    tryTimeout?: string;
     /**
      * User-provided machine configuration to apply. 
      * This can be retrieved from the cluster resource.
      */
     userConfigPatches?: string;
+    /**
+     * Version of the machine info format. 
+     * Set by the Cluster resource, the current version is 1. 
+     * Machines without it are parsed as version 0.
+     */
+    version?: number;
+}
+
+export interface MachineStatus {
+    /**
+     * SHA-256 hash of the last applied machine configuration.
+     */
+    configHash: string;
+    /**
+     * Kubernetes version of the machine.
+     */
+    kubernetesVersion: string;
+    /**
+     * The last operation which ran on the machine. It is unknown for machines of migrated stacks until an operation runs on them.
+     */
+    lastOperation: enums.Operation;
+    /**
+     * Time of the last operation in RFC 3339 format. Empty if the operation is unknown.
+     */
+    lastOperationTime: string;
+    /**
+     * Talos version reported by the node after apply.
+     */
+    observedTalosVersion: string;
+    /**
+     * Talos version of the machine image. Empty if the image tag is not a version.
+     */
+    talosVersion: string;
+}
+
+/**
+ * Machines of the Cluster resource grouped by machine type. 
+ * It is passed to applyMachines of the Apply resource.
+ */
+export interface MachinesByType {
+    /**
+     * Machines of the controlplane type.
+     */
+    controlplane?: outputs.MachineInfo[];
+    /**
+     * Machines of the init type.
+     */
+    init: outputs.MachineInfo[];
+    /**
+     * Machines of the worker type.
+     */
+    worker?: outputs.MachineInfo[];
+}
+
+export interface NodeStatus {
+    /**
+     * Errors occurred while the status was collected.
+     */
+    error?: string;
+    /**
+     * The etcd member is a learner and does not vote yet.
+     */
+    etcdLearner?: boolean;
+    /**
+     * The node is a member of the etcd cluster.
+     */
+    etcdMember?: boolean;
+    /**
+     * ID of the etcd member.
+     */
+    etcdMemberId?: string;
+    /**
+     * Kubelet version from the kubelet image. Empty if kubelet is not configured yet.
+     */
+    kubeletVersion?: string;
+    /**
+     * Machine type from the running configuration.
+     */
+    machineType?: string;
+    /**
+     * The IP address of the node.
+     */
+    node: string;
+    /**
+     * The node is running, all Talos conditions are met 
+     * and, for controlplanes, the node is a voting etcd member.
+     */
+    ready: boolean;
+    /**
+     * Machine stage reported by Talos, e.g. maintenance, booting, running. 
+     * It is unknown if the node can't be reached.
+     */
+    stage: string;
+    /**
+     * Running Talos version.
+     */
+    talosVersion?: string;
 }
 
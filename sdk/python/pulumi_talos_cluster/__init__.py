@@ -9,9 +9,19 @@ import typing
 from ._enums import *
 from .apply import *
 from .cluster import *
+from .get_cluster_status import *
 from .provider import *
+from .render_machine_config import *
 from ._inputs import *
 from . import outputs
+
+# Make subpackages available:
+if typing.TYPE_CHECKING:
+    import pulumi_talos_cluster.config as __config
+    config = __config
+else:
+    config = _utilities.lazy_import('pulumi_talos_cluster.config')
+
 _utilities.register(
     resource_modules="""
 [
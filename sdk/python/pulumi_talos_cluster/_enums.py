@@ -7,10 +7,37 @@ import pulumi
 from enum import Enum
 
 __all__ = [
+    'ApplyMode',
+    'DebugBundle',
     'MachineTypes',
+    'MigrationMode',
+    'Operation',
+    'TalosctlVersionCheck',
 ]
 
 
+@pulumi.type_token("talos-cluster:index:applyMode")
+class ApplyMode(_builtins.str, Enum):
+    """
+    Modes of talosctl apply-config
+    """
+    AUTO = "auto"
+    NO_REBOOT = "no-reboot"
+    REBOOT = "reboot"
+    STAGED = "staged"
+    TRY_ = "try"
+
+
+@pulumi.type_token("talos-cluster:index:debugBundle")
+class DebugBundle(_builtins.str, Enum):
+    """
+    Diagnostics collected from a node after a failed operation
+    """
+    NONE = "none"
+    BASIC = "basic"
+    SUPPORT = "support"
+
+
 @pulumi.type_token("talos-cluster:index:machineTypes")
 class MachineTypes(_builtins.str, Enum):
     """
@@ -19,3 +46,34 @@ class MachineTypes(_builtins.str, Enum):
     CONTROLPLANE = "controlplane"
     WORKER = "worker"
     INIT = "init"
+
+
+@pulumi.type_token("talos-cluster:index:migrationMode")
+class MigrationMode(_builtins.str, Enum):
+    """
+    Modes of migration from pulumiverse/talos resources
+    """
+    ALIASES = "aliases"
+    IMPORT_ = "import"
+
+
+@pulumi.type_token("talos-cluster:index:operation")
+class Operation(_builtins.str, Enum):
+    """
+    Operations which change a machine
+    """
+    UNKNOWN = "unknown"
+    INITIAL_APPLY = "initial-apply"
+    UPGRADE = "upgrade"
+    UPGRADE_K8S = "upgrade-k8s"
+    APPLY_CONFIG = "apply-config"
+
+
+@pulumi.type_token("talos-cluster:index:talosctlVersionCheck")
+class TalosctlVersionCheck(_builtins.str, Enum):
+    """
+    Reactions on talosctl version skew
+    """
+    WARN = "warn"
+    FAIL = "fail"
+    SKIP = "skip"
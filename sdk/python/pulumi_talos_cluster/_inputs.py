@@ -18,21 +18,42 @@ from ._enums import *
 __all__ = [
     'ApplyMachinesArgs',
     'ApplyMachinesArgsDict',
+    'ClientConfiguration',
+    'ClientConfigurationDict',
     'ClientConfigurationArgs',
     'ClientConfigurationArgsDict',
     'ClusterMachinesArgs',
     'ClusterMachinesArgsDict',
+    'KubeconfigOptionsArgs',
+    'KubeconfigOptionsArgsDict',
+    'MachineConfigurationArgs',
+    'MachineConfigurationArgsDict',
     'MachineInfoArgs',
     'MachineInfoArgsDict',
+    'TalosconfigSpecArgs',
+    'TalosconfigSpecArgsDict',
 ]
 
 MYPY = False
 
 if not MYPY:
     class ApplyMachinesArgsDict(TypedDict):
+        """
+        Machines to apply grouped by machine type. 
+        It is usually the machines output of the Cluster resource.
+        """
         init: pulumi.Input[Sequence[pulumi.Input['MachineInfoArgsDict']]]
+        """
+        Machines of the init type.
+        """
         controlplane: NotRequired[pulumi.Input[Sequence[pulumi.Input['MachineInfoArgsDict']]]]
+        """
+        Machines of the controlplane type.
+        """
         worker: NotRequired[pulumi.Input[Sequence[pulumi.Input['MachineInfoArgsDict']]]]
+        """
+        Machines of the worker type.
+        """
 elif False:
     ApplyMachinesArgsDict: TypeAlias = Mapping[str, Any]
 
@@ -42,6 +63,13 @@ class ApplyMachinesArgs:
                  init: pulumi.Input[Sequence[pulumi.Input['MachineInfoArgs']]],
                  controlplane: Optional[pulumi.Input[Sequence[pulumi.Input['MachineInfoArgs']]]] = None,
                  worker: Optional[pulumi.Input[Sequence[pulumi.Input['MachineInfoArgs']]]] = None):
+        """
+        Machines to apply grouped by machine type. 
+        It is usually the machines output of the Cluster resource.
+        :param pulumi.Input[Sequence[pulumi.Input['MachineInfoArgs']]] init: Machines of the init type.
+        :param pulumi.Input[Sequence[pulumi.Input['MachineInfoArgs']]] controlplane: Machines of the controlplane type.
+        :param pulumi.Input[Sequence[pulumi.Input['MachineInfoArgs']]] worker: Machines of the worker type.
+        """
         pulumi.set(__self__, "init", init)
         if controlplane is not None:
             pulumi.set(__self__, "controlplane", controlplane)
@@ -51,6 +79,9 @@ class ApplyMachinesArgs:
     @_builtins.property
     @pulumi.getter
     def init(self) -> pulumi.Input[Sequence[pulumi.Input['MachineInfoArgs']]]:
+        """
+        Machines of the init type.
+        """
         return pulumi.get(self, "init")
 
     @init.setter
@@ -60,6 +91,9 @@ class ApplyMachinesArgs:
     @_builtins.property
     @pulumi.getter
     def controlplane(self) -> Optional[pulumi.Input[Sequence[pulumi.Input['MachineInfoArgs']]]]:
+        """
+        Machines of the controlplane type.
+        """
         return pulumi.get(self, "controlplane")
 
     @controlplane.setter
@@ -69,6 +103,9 @@ class ApplyMachinesArgs:
     @_builtins.property
     @pulumi.getter
     def worker(self) -> Optional[pulumi.Input[Sequence[pulumi.Input['MachineInfoArgs']]]]:
+        """
+        Machines of the worker type.
+        """
         return pulumi.get(self, "worker")
 
     @worker.setter
@@ -76,6 +113,78 @@ class ApplyMachinesArgs:
         pulumi.set(self, "worker", value)
 
 
+if not MYPY:
+    class ClientConfigurationDict(TypedDict):
+        ca_certificate: NotRequired[_builtins.str]
+        """
+        The Certificate Authority (CA) certificate used to verify connections to the Talos API server.
+        """
+        client_certificate: NotRequired[_builtins.str]
+        """
+        The client certificate used to authenticate to the Talos API server.
+        """
+        client_key: NotRequired[_builtins.str]
+        """
+        The private key for the client certificate, used for authenticating the client to the Talos API server.
+        """
+elif False:
+    ClientConfigurationDict: TypeAlias = Mapping[str, Any]
+
+@pulumi.input_type
+class ClientConfiguration:
+    def __init__(__self__, *,
+                 ca_certificate: Optional[_builtins.str] = None,
+                 client_certificate: Optional[_builtins.str] = None,
+                 client_key: Optional[_builtins.str] = None):
+        """
+        :param _builtins.str ca_certificate: The Certificate Authority (CA) certificate used to verify connections to the Talos API server.
+        :param _builtins.str client_certificate: The client certificate used to authenticate to the Talos API server.
+        :param _builtins.str client_key: The private key for the client certificate, used for authenticating the client to the Talos API server.
+        """
+        if ca_certificate is not None:
+            pulumi.set(__self__, "ca_certificate", ca_certificate)
+        if client_certificate is not None:
+            pulumi.set(__self__, "client_certificate", client_certificate)
+        if client_key is not None:
+            pulumi.set(__self__, "client_key", client_key)
+
+    @_builtins.property
+    @pulumi.getter(name="caCertificate")
+    def ca_certificate(self) -> Optional[_builtins.str]:
+        """
+        The Certificate Authority (CA) certificate used to verify connections to the Talos API server.
+        """
+        return pulumi.get(self, "ca_certificate")
+
+    @ca_certificate.setter
+    def ca_certificate(self, value: Optional[_builtins.str]):
+        pulumi.set(self, "ca_certificate", value)
+
+    @_builtins.property
+    @pulumi.getter(name="clientCertificate")
+    def client_certificate(self) -> Optional[_builtins.str]:
+        """
+        The client certificate used to authenticate to the Talos API server.
+        """
+        return pulumi.get(self, "client_certificate")
+
+    @client_certificate.setter
+    def client_certificate(self, value: Optional[_builtins.str]):
+        pulumi.set(self, "client_certificate", value)
+
+    @_builtins.property
+    @pulumi.getter(name="clientKey")
+    def client_key(self) -> Optional[_builtins.str]:
+        """
+        The private key for the client certificate, used for authenticating the client to the Talos API server.
+        """
+        return pulumi.get(self, "client_key")
+
+    @client_key.setter
+    def client_key(self, value: Optional[_builtins.str]):
+        pulumi.set(self, "client_key", value)
+
+
 if not MYPY:
     class ClientConfigurationArgsDict(TypedDict):
         ca_certificate: NotRequired[pulumi.Input[_builtins.str]]
@@ -162,6 +271,11 @@ if not MYPY:
         """
         The IP address of the node where configuration will be applied.
         """
+        apply_mode: NotRequired[pulumi.Input['ApplyMode']]
+        """
+        Mode of `talosctl apply-config` for the machine. 
+        Overrides the applyMode of the Apply resource.
+        """
         config_patches: NotRequired[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]]
         """
         User-provided machine configuration to apply. 
@@ -174,6 +288,11 @@ if not MYPY:
         Used in the `install` configuration and set via CLI. 
         The default is generated based on the Talos machinery version, current: ghcr.io/siderolabs/installer:v1.12.0.
         """
+        try_timeout: NotRequired[pulumi.Input[_builtins.str]]
+        """
+        Duration after which a configuration applied in try mode is rolled back. 
+        Overrides the tryTimeout of the Apply resource.
+        """
 elif False:
     ClusterMachinesArgsDict: TypeAlias = Mapping[str, Any]
 
@@ -183,28 +302,38 @@ class ClusterMachinesArgs:
                  machine_id: _builtins.str,
                  machine_type: 'MachineTypes',
                  node_ip: pulumi.Input[_builtins.str],
+                 apply_mode: Optional[pulumi.Input['ApplyMode']] = None,
                  config_patches: Optional[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]] = None,
-                 talos_image: Optional[pulumi.Input[_builtins.str]] = None):
+                 talos_image: Optional[pulumi.Input[_builtins.str]] = None,
+                 try_timeout: Optional[pulumi.Input[_builtins.str]] = None):
         """
         :param _builtins.str machine_id: ID or name of the machine.
         :param 'MachineTypes' machine_type: Type of the machine.
         :param pulumi.Input[_builtins.str] node_ip: The IP address of the node where configuration will be applied.
+        :param pulumi.Input['ApplyMode'] apply_mode: Mode of `talosctl apply-config` for the machine. 
+               Overrides the applyMode of the Apply resource.
         :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] config_patches: User-provided machine configuration to apply. 
                Must be a valid array of YAML strings. 
                For structure, see https://www.talos.dev/latest/reference/configuration/v1alpha1/config/
         :param pulumi.Input[_builtins.str] talos_image: Talos OS installation image. 
                Used in the `install` configuration and set via CLI. 
                The default is generated based on the Talos machinery version, current: ghcr.io/siderolabs/installer:v1.12.0.
+        :param pulumi.Input[_builtins.str] try_timeout: Duration after which a configuration applied in try mode is rolled back. 
+               Overrides the tryTimeout of the Apply resource.
         """
         pulumi.set(__self__, "machine_id", machine_id)
         pulumi.set(__self__, "machine_type", machine_type)
         pulumi.set(__self__, "node_ip", node_ip)
+        if apply_mode is not None:
+            pulumi.set(__self__, "apply_mode", apply_mode)
         if config_patches is not None:
             pulumi.set(__self__, "config_patches", config_patches)
         if talos_image is None:
             talos_image = 'ghcr.io/siderolabs/installer:v1.12.0'
         if talos_image is not None:
             pulumi.set(__self__, "talos_image", talos_image)
+        if try_timeout is not None:
+            pulumi.set(__self__, "try_timeout", try_timeout)
 
     @_builtins.property
     @pulumi.getter(name="machineId")
@@ -242,6 +371,19 @@ class ClusterMachinesArgs:
     def node_ip(self, value: pulumi.Input[_builtins.str]):
         pulumi.set(self, "node_ip", value)
 
+    @_builtins.property
+    @pulumi.getter(name="applyMode")
+    def apply_mode(self) -> Optional[pulumi.Input['ApplyMode']]:
+        """
+        Mode of `talosctl apply-config` for the machine. 
+        Overrides the applyMode of the Apply resource.
+        """
+        return pulumi.get(self, "apply_mode")
+
+    @apply_mode.setter
+    def apply_mode(self, value: Optional[pulumi.Input['ApplyMode']]):
+        pulumi.set(self, "apply_mode", value)
+
     @_builtins.property
     @pulumi.getter(name="configPatches")
     def config_patches(self) -> Optional[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]]:
@@ -270,6 +412,255 @@ class ClusterMachinesArgs:
     def talos_image(self, value: Optional[pulumi.Input[_builtins.str]]):
         pulumi.set(self, "talos_image", value)
 
+    @_builtins.property
+    @pulumi.getter(name="tryTimeout")
+    def try_timeout(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Duration after which a configuration applied in try mode is rolled back. 
+        Overrides the tryTimeout of the Apply resource.
+        """
+        return pulumi.get(self, "try_timeout")
+
+    @try_timeout.setter
+    def try_timeout(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "try_timeout", value)
+
+
+if not MYPY:
+    class KubeconfigOptionsArgsDict(TypedDict):
+        cert_lifetime: NotRequired[pulumi.Input[_builtins.str]]
+        """
+        Lifetime of the admin client certificate, e.g. 24h. 
+        The certificate is renewed in the middle of its lifetime. 
+        Default is cluster.adminKubeconfig.certLifetime of the init node configuration, one year unless it is patched.
+        """
+        cluster_name: NotRequired[pulumi.Input[_builtins.str]]
+        """
+        Name of the cluster entry. Default is the cluster name.
+        """
+        context_name: NotRequired[pulumi.Input[_builtins.str]]
+        """
+        Name of the context, which is also the current context. Default is admin@<cluster name>.
+        """
+        server: NotRequired[pulumi.Input[_builtins.str]]
+        """
+        URL of the Kubernetes API server, e.g. https://lb.example.com:6443. 
+        Default is the cluster endpoint from the configuration of the init node.
+        """
+        user_name: NotRequired[pulumi.Input[_builtins.str]]
+        """
+        Name of the user entry. Default is admin@<cluster name>.
+        """
+elif False:
+    KubeconfigOptionsArgsDict: TypeAlias = Mapping[str, Any]
+
+@pulumi.input_type
+class KubeconfigOptionsArgs:
+    def __init__(__self__, *,
+                 cert_lifetime: Optional[pulumi.Input[_builtins.str]] = None,
+                 cluster_name: Optional[pulumi.Input[_builtins.str]] = None,
+                 context_name: Optional[pulumi.Input[_builtins.str]] = None,
+                 server: Optional[pulumi.Input[_builtins.str]] = None,
+                 user_name: Optional[pulumi.Input[_builtins.str]] = None):
+        """
+        :param pulumi.Input[_builtins.str] cert_lifetime: Lifetime of the admin client certificate, e.g. 24h. 
+               The certificate is renewed in the middle of its lifetime. 
+               Default is cluster.adminKubeconfig.certLifetime of the init node configuration, one year unless it is patched.
+        :param pulumi.Input[_builtins.str] cluster_name: Name of the cluster entry. Default is the cluster name.
+        :param pulumi.Input[_builtins.str] context_name: Name of the context, which is also the current context. Default is admin@<cluster name>.
+        :param pulumi.Input[_builtins.str] server: URL of the Kubernetes API server, e.g. https://lb.example.com:6443. 
+               Default is the cluster endpoint from the configuration of the init node.
+        :param pulumi.Input[_builtins.str] user_name: Name of the user entry. Default is admin@<cluster name>.
+        """
+        if cert_lifetime is not None:
+            pulumi.set(__self__, "cert_lifetime", cert_lifetime)
+        if cluster_name is not None:
+            pulumi.set(__self__, "cluster_name", cluster_name)
+        if context_name is not None:
+            pulumi.set(__self__, "context_name", context_name)
+        if server is not None:
+            pulumi.set(__self__, "server", server)
+        if user_name is not None:
+            pulumi.set(__self__, "user_name", user_name)
+
+    @_builtins.property
+    @pulumi.getter(name="certLifetime")
+    def cert_lifetime(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Lifetime of the admin client certificate, e.g. 24h. 
+        The certificate is renewed in the middle of its lifetime. 
+        Default is cluster.adminKubeconfig.certLifetime of the init node configuration, one year unless it is patched.
+        """
+        return pulumi.get(self, "cert_lifetime")
+
+    @cert_lifetime.setter
+    def cert_lifetime(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "cert_lifetime", value)
+
+    @_builtins.property
+    @pulumi.getter(name="clusterName")
+    def cluster_name(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Name of the cluster entry. Default is the cluster name.
+        """
+        return pulumi.get(self, "cluster_name")
+
+    @cluster_name.setter
+    def cluster_name(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "cluster_name", value)
+
+    @_builtins.property
+    @pulumi.getter(name="contextName")
+    def context_name(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Name of the context, which is also the current context. Default is admin@<cluster name>.
+        """
+        return pulumi.get(self, "context_name")
+
+    @context_name.setter
+    def context_name(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "context_name", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def server(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        URL of the Kubernetes API server, e.g. https://lb.example.com:6443. 
+        Default is the cluster endpoint from the configuration of the init node.
+        """
+        return pulumi.get(self, "server")
+
+    @server.setter
+    def server(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "server", value)
+
+    @_builtins.property
+    @pulumi.getter(name="userName")
+    def user_name(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Name of the user entry. Default is admin@<cluster name>.
+        """
+        return pulumi.get(self, "user_name")
+
+    @user_name.setter
+    def user_name(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "user_name", value)
+
+
+if not MYPY:
+    class MachineConfigurationArgsDict(TypedDict):
+        configuration: pulumi.Input[_builtins.str]
+        """
+        Complete machine configuration of the node (YAML).
+        """
+        machine_id: pulumi.Input[_builtins.str]
+        """
+        ID or name of the machine.
+        """
+        node_ip: pulumi.Input[_builtins.str]
+        """
+        The IP address of the node where configuration will be applied.
+        """
+        apply_mode: NotRequired[pulumi.Input['ApplyMode']]
+        """
+        Mode of `talosctl apply-config` for the machine. 
+        Overrides the applyMode of the Apply resource.
+        """
+        try_timeout: NotRequired[pulumi.Input[_builtins.str]]
+        """
+        Duration after which a configuration applied in try mode is rolled back. 
+        Overrides the tryTimeout of the Apply resource.
+        """
+elif False:
+    MachineConfigurationArgsDict: TypeAlias = Mapping[str, Any]
+
+@pulumi.input_type
+class MachineConfigurationArgs:
+    def __init__(__self__, *,
+                 configuration: pulumi.Input[_builtins.str],
+                 machine_id: pulumi.Input[_builtins.str],
+                 node_ip: pulumi.Input[_builtins.str],
+                 apply_mode: Optional[pulumi.Input['ApplyMode']] = None,
+                 try_timeout: Optional[pulumi.Input[_builtins.str]] = None):
+        """
+        :param pulumi.Input[_builtins.str] configuration: Complete machine configuration of the node (YAML).
+        :param pulumi.Input[_builtins.str] machine_id: ID or name of the machine.
+        :param pulumi.Input[_builtins.str] node_ip: The IP address of the node where configuration will be applied.
+        :param pulumi.Input['ApplyMode'] apply_mode: Mode of `talosctl apply-config` for the machine. 
+               Overrides the applyMode of the Apply resource.
+        :param pulumi.Input[_builtins.str] try_timeout: Duration after which a configuration applied in try mode is rolled back. 
+               Overrides the tryTimeout of the Apply resource.
+        """
+        pulumi.set(__self__, "configuration", configuration)
+        pulumi.set(__self__, "machine_id", machine_id)
+        pulumi.set(__self__, "node_ip", node_ip)
+        if apply_mode is not None:
+            pulumi.set(__self__, "apply_mode", apply_mode)
+        if try_timeout is not None:
+            pulumi.set(__self__, "try_timeout", try_timeout)
+
+    @_builtins.property
+    @pulumi.getter
+    def configuration(self) -> pulumi.Input[_builtins.str]:
+        """
+        Complete machine configuration of the node (YAML).
+        """
+        return pulumi.get(self, "configuration")
+
+    @configuration.setter
+    def configuration(self, value: pulumi.Input[_builtins.str]):
+        pulumi.set(self, "configuration", value)
+
+    @_builtins.property
+    @pulumi.getter(name="machineId")
+    def machine_id(self) -> pulumi.Input[_builtins.str]:
+        """
+        ID or name of the machine.
+        """
+        return pulumi.get(self, "machine_id")
+
+    @machine_id.setter
+    def machine_id(self, value: pulumi.Input[_builtins.str]):
+        pulumi.set(self, "machine_id", value)
+
+    @_builtins.property
+    @pulumi.getter(name="nodeIp")
+    def node_ip(self) -> pulumi.Input[_builtins.str]:
+        """
+        The IP address of the node where configuration will be applied.
+        """
+        return pulumi.get(self, "node_ip")
+
+    @node_ip.setter
+    def node_ip(self, value: pulumi.Input[_builtins.str]):
+        pulumi.set(self, "node_ip", value)
+
+    @_builtins.property
+    @pulumi.getter(name="applyMode")
+    def apply_mode(self) -> Optional[pulumi.Input['ApplyMode']]:
+        """
+        Mode of `talosctl apply-config` for the machine. 
+        Overrides the applyMode of the Apply resource.
+        """
+        return pulumi.get(self, "apply_mode")
+
+    @apply_mode.setter
+    def apply_mode(self, value: Optional[pulumi.Input['ApplyMode']]):
+        pulumi.set(self, "apply_mode", value)
+
+    @_builtins.property
+    @pulumi.getter(name="tryTimeout")
+    def try_timeout(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Duration after which a configuration applied in try mode is rolled back. 
+        Overrides the tryTimeout of the Apply resource.
+        """
+        return pulumi.get(self, "try_timeout")
+
+    @try_timeout.setter
+    def try_timeout(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "try_timeout", value)
+
 
 if not MYPY:
     class MachineInfoArgsDict(TypedDict):
@@ -286,23 +677,46 @@ if not MYPY:
         """
         The IP address of the node where configuration will be applied.
         """
+        apply_mode: NotRequired[pulumi.Input['ApplyMode']]
+        """
+        Mode of `talosctl apply-config` for the machine. 
+        Overrides the applyMode of the Apply resource.
+        """
         cluster_endpoint: NotRequired[pulumi.Input[_builtins.str]]
         """
         cluster endpoint applied to node
         """
         kubernetes_version: NotRequired[pulumi.Input[_builtins.str]]
         """
-        Kubernetes version to install or upgrade on the node.
+        Kubernetes version to install or upgrade on the node. 
+        The version of the kubelet image of the configuration is used if it is not set.
+        """
+        machine_type: NotRequired[pulumi.Input['MachineTypes']]
+        """
+        Type of the machine. 
+        Set by the Cluster resource. The machine is applied according to its group in applyMachines.
         """
         talos_image: NotRequired[pulumi.Input[_builtins.str]]
         """
-        Talos OS image to install or upgrade on the node.
+        Talos OS image to install or upgrade on the node. 
+        The install image of the configuration is used if it is not set.
+        """
+        try_timeout: NotRequired[pulumi.Input[_builtins.str]]
+        """
+        Duration after which a configuration applied in try mode is rolled back. 
+        Overrides the tryTimeout of the Apply resource.
         """
         user_config_patches: NotRequired[pulumi.Input[_builtins.str]]
         """
         User-provided machine configuration to apply. 
         This can be retrieved from the cluster resource.
         """
+        version: NotRequired[pulumi.Input[_builtins.int]]
+        """
+        Version of the machine info format. 
+        Set by the Cluster resource, the current version is 1. 
+        Machines without it are parsed as version 0.
+        """
 elif False:
     MachineInfoArgsDict: TypeAlias = Mapping[str, Any]
 
@@ -312,32 +726,55 @@ class MachineInfoArgs:
                  configuration: pulumi.Input[_builtins.str],
                  machine_id: pulumi.Input[_builtins.str],
                  node_ip: pulumi.Input[_builtins.str],
+                 apply_mode: Optional[pulumi.Input['ApplyMode']] = None,
                  cluster_endpoint: Optional[pulumi.Input[_builtins.str]] = None,
                  kubernetes_version: Optional[pulumi.Input[_builtins.str]] = None,
+                 machine_type: Optional[pulumi.Input['MachineTypes']] = None,
                  talos_image: Optional[pulumi.Input[_builtins.str]] = None,
-                 user_config_patches: Optional[pulumi.Input[_builtins.str]] = None):
+                 try_timeout: Optional[pulumi.Input[_builtins.str]] = None,
+                 user_config_patches: Optional[pulumi.Input[_builtins.str]] = None,
+                 version: Optional[pulumi.Input[_builtins.int]] = None):
         """
         :param pulumi.Input[_builtins.str] configuration: Configuration settings for machines to apply. 
                This can be retrieved from the cluster resource.
         :param pulumi.Input[_builtins.str] machine_id: ID or name of the machine.
         :param pulumi.Input[_builtins.str] node_ip: The IP address of the node where configuration will be applied.
+        :param pulumi.Input['ApplyMode'] apply_mode: Mode of `talosctl apply-config` for the machine. 
+               Overrides the applyMode of the Apply resource.
         :param pulumi.Input[_builtins.str] cluster_endpoint: cluster endpoint applied to node
-        :param pulumi.Input[_builtins.str] kubernetes_version: Kubernetes version to install or upgrade on the node.
-        :param pulumi.Input[_builtins.str] talos_image: Talos OS image to install or upgrade on the node.
+        :param pulumi.Input[_builtins.str] kubernetes_version: Kubernetes version to install or upgrade on the node. 
+               The version of the kubelet image of the configuration is used if it is not set.
+        :param pulumi.Input['MachineTypes'] machine_type: Type of the machine. 
+               Set by the Cluster resource. The machine is applied according to its group in applyMachines.
+        :param pulumi.Input[_builtins.str] talos_image: Talos OS image to install or upgrade on the node. 
+               The install image of the configuration is used if it is not set.
+        :param pulumi.Input[_builtins.str] try_timeout: Duration after which a configuration applied in try mode is rolled back. 
+               Overrides the tryTimeout of the Apply resource.
         :param pulumi.Input[_builtins.str] user_config_patches: User-provided machine configuration to apply. 
                This can be retrieved from the cluster resource.
+        :param pulumi.Input[_builtins.int] version: Version of the machine info format. 
+               Set by the Cluster resource, the current version is 1. 
+               Machines without it are parsed as version 0.
         """
         pulumi.set(__self__, "configuration", configuration)
         pulumi.set(__self__, "machine_id", machine_id)
         pulumi.set(__self__, "node_ip", node_ip)
+        if apply_mode is not None:
+            pulumi.set(__self__, "apply_mode", apply_mode)
         if cluster_endpoint is not None:
             pulumi.set(__self__, "cluster_endpoint", cluster_endpoint)
         if kubernetes_version is not None:
             pulumi.set(__self__, "kubernetes_version", kubernetes_version)
+        if machine_type is not None:
+            pulumi.set(__self__, "machine_type", machine_type)
         if talos_image is not None:
             pulumi.set(__self__, "talos_image", talos_image)
+        if try_timeout is not None:
+            pulumi.set(__self__, "try_timeout", try_timeout)
         if user_config_patches is not None:
             pulumi.set(__self__, "user_config_patches", user_config_patches)
+        if version is not None:
+            pulumi.set(__self__, "version", version)
 
     @_builtins.property
     @pulumi.getter
@@ -376,6 +813,19 @@ class MachineInfoArgs:
     def node_ip(self, value: pulumi.Input[_builtins.str]):
         pulumi.set(self, "node_ip", value)
 
+    @_builtins.property
+    @pulumi.getter(name="applyMode")
+    def apply_mode(self) -> Optional[pulumi.Input['ApplyMode']]:
+        """
+        Mode of `talosctl apply-config` for the machine. 
+        Overrides the applyMode of the Apply resource.
+        """
+        return pulumi.get(self, "apply_mode")
+
+    @apply_mode.setter
+    def apply_mode(self, value: Optional[pulumi.Input['ApplyMode']]):
+        pulumi.set(self, "apply_mode", value)
+
     @_builtins.property
     @pulumi.getter(name="clusterEndpoint")
     def cluster_endpoint(self) -> Optional[pulumi.Input[_builtins.str]]:
@@ -392,7 +842,8 @@ class MachineInfoArgs:
     @pulumi.getter(name="kubernetesVersion")
     def kubernetes_version(self) -> Optional[pulumi.Input[_builtins.str]]:
         """
-        Kubernetes version to install or upgrade on the node.
+        Kubernetes version to install or upgrade on the node. 
+        The version of the kubelet image of the configuration is used if it is not set.
         """
         return pulumi.get(self, "kubernetes_version")
 
@@ -400,11 +851,25 @@ class MachineInfoArgs:
     def kubernetes_version(self, value: Optional[pulumi.Input[_builtins.str]]):
         pulumi.set(self, "kubernetes_version", value)
 
+    @_builtins.property
+    @pulumi.getter(name="machineType")
+    def machine_type(self) -> Optional[pulumi.Input['MachineTypes']]:
+        """
+        Type of the machine. 
+        Set by the Cluster resource. The machine is applied according to its group in applyMachines.
+        """
+        return pulumi.get(self, "machine_type")
+
+    @machine_type.setter
+    def machine_type(self, value: Optional[pulumi.Input['MachineTypes']]):
+        pulumi.set(self, "machine_type", value)
+
     @_builtins.property
     @pulumi.getter(name="talosImage")
     def talos_image(self) -> Optional[pulumi.Input[_builtins.str]]:
         """
-        Talos OS image to install or upgrade on the node.
+        Talos OS image to install or upgrade on the node. 
+        The install image of the configuration is used if it is not set.
         """
         return pulumi.get(self, "talos_image")
 
@@ -412,6 +877,19 @@ class MachineInfoArgs:
     def talos_image(self, value: Optional[pulumi.Input[_builtins.str]]):
         pulumi.set(self, "talos_image", value)
 
+    @_builtins.property
+    @pulumi.getter(name="tryTimeout")
+    def try_timeout(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Duration after which a configuration applied in try mode is rolled back. 
+        Overrides the tryTimeout of the Apply resource.
+        """
+        return pulumi.get(self, "try_timeout")
+
+    @try_timeout.setter
+    def try_timeout(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "try_timeout", value)
+
     @_builtins.property
     @pulumi.getter(name="userConfigPatches")
     def user_config_patches(self) -> Optional[pulumi.Input[_builtins.str]]:
@@ -425,4 +903,137 @@ class MachineInfoArgs:
     def user_config_patches(self, value: Optional[pulumi.Input[_builtins.str]]):
         pulumi.set(self, "user_config_patches", value)
 
+    @_builtins.property
+    @pulumi.getter
+    def version(self) -> Optional[pulumi.Input[_builtins.int]]:
+        """
+        Version of the machine info format. 
+        Set by the Cluster resource, the current version is 1. 
+        Machines without it are parsed as version 0.
+        """
+        return pulumi.get(self, "version")
+
+    @version.setter
+    def version(self, value: Optional[pulumi.Input[_builtins.int]]):
+        pulumi.set(self, "version", value)
+
+
+if not MYPY:
+    class TalosconfigSpecArgsDict(TypedDict):
+        name: pulumi.Input[_builtins.str]
+        """
+        Name of the talosconfig and its context.
+        """
+        roles: pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]
+        """
+        Talos API roles of the client certificate. 
+        Supported roles: os:admin, os:operator, os:reader, os:etcd:backup.
+        """
+        endpoints: NotRequired[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]]
+        """
+        Endpoints of the talosconfig. Default is all controlplanes.
+        """
+        nodes: NotRequired[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]]
+        """
+        Nodes of the talosconfig. Default is all machines.
+        """
+        ttl: NotRequired[pulumi.Input[_builtins.str]]
+        """
+        Lifetime of the client certificate, e.g. 720h. 
+        The certificate is renewed in the middle of its lifetime. 
+        Default is 8760h0m0s.
+        """
+elif False:
+    TalosconfigSpecArgsDict: TypeAlias = Mapping[str, Any]
+
+@pulumi.input_type
+class TalosconfigSpecArgs:
+    def __init__(__self__, *,
+                 name: pulumi.Input[_builtins.str],
+                 roles: pulumi.Input[Sequence[pulumi.Input[_builtins.str]]],
+                 endpoints: Optional[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]] = None,
+                 nodes: Optional[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]] = None,
+                 ttl: Optional[pulumi.Input[_builtins.str]] = None):
+        """
+        :param pulumi.Input[_builtins.str] name: Name of the talosconfig and its context.
+        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] roles: Talos API roles of the client certificate. 
+               Supported roles: os:admin, os:operator, os:reader, os:etcd:backup.
+        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] endpoints: Endpoints of the talosconfig. Default is all controlplanes.
+        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] nodes: Nodes of the talosconfig. Default is all machines.
+        :param pulumi.Input[_builtins.str] ttl: Lifetime of the client certificate, e.g. 720h. 
+               The certificate is renewed in the middle of its lifetime. 
+               Default is 8760h0m0s.
+        """
+        pulumi.set(__self__, "name", name)
+        pulumi.set(__self__, "roles", roles)
+        if endpoints is not None:
+            pulumi.set(__self__, "endpoints", endpoints)
+        if nodes is not None:
+            pulumi.set(__self__, "nodes", nodes)
+        if ttl is not None:
+            pulumi.set(__self__, "ttl", ttl)
+
+    @_builtins.property
+    @pulumi.getter
+    def name(self) -> pulumi.Input[_builtins.str]:
+        """
+        Name of the talosconfig and its context.
+        """
+        return pulumi.get(self, "name")
+
+    @name.setter
+    def name(self, value: pulumi.Input[_builtins.str]):
+        pulumi.set(self, "name", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def roles(self) -> pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]:
+        """
+        Talos API roles of the client certificate. 
+        Supported roles: os:admin, os:operator, os:reader, os:etcd:backup.
+        """
+        return pulumi.get(self, "roles")
+
+    @roles.setter
+    def roles(self, value: pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]):
+        pulumi.set(self, "roles", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def endpoints(self) -> Optional[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]]:
+        """
+        Endpoints of the talosconfig. Default is all controlplanes.
+        """
+        return pulumi.get(self, "endpoints")
+
+    @endpoints.setter
+    def endpoints(self, value: Optional[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]]):
+        pulumi.set(self, "endpoints", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def nodes(self) -> Optional[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]]:
+        """
+        Nodes of the talosconfig. Default is all machines.
+        """
+        return pulumi.get(self, "nodes")
+
+    @nodes.setter
+    def nodes(self, value: Optional[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]]):
+        pulumi.set(self, "nodes", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def ttl(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Lifetime of the client certificate, e.g. 720h. 
+        The certificate is renewed in the middle of its lifetime. 
+        Default is 8760h0m0s.
+        """
+        return pulumi.get(self, "ttl")
+
+    @ttl.setter
+    def ttl(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "ttl", value)
+
 
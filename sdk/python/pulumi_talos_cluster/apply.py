@@ -14,6 +14,7 @@ else:
     from typing_extensions import NotRequired, TypedDict, TypeAlias
 from . import _utilities
 from . import outputs
+from ._enums import *
 from ._inputs import *
 
 __all__ = ['ApplyArgs', 'Apply']
@@ -21,50 +22,229 @@ __all__ = ['ApplyArgs', 'Apply']
 @pulumi.input_type
 class ApplyArgs:
     def __init__(__self__, *,
-                 apply_machines: pulumi.Input['ApplyMachinesArgs'],
-                 client_configuration: pulumi.Input['ClientConfigurationArgs'],
-                 skip_init_apply: Optional[pulumi.Input[_builtins.bool]] = None):
+                 additional_talosconfigs: Optional[pulumi.Input[Sequence[pulumi.Input['TalosconfigSpecArgs']]]] = None,
+                 apply_machines: Optional[pulumi.Input['ApplyMachinesArgs']] = None,
+                 apply_mode: Optional[pulumi.Input['ApplyMode']] = None,
+                 client_configuration: Optional[pulumi.Input['ClientConfigurationArgs']] = None,
+                 client_talosconfig: Optional[pulumi.Input[_builtins.str]] = None,
+                 detect_drift: Optional[pulumi.Input[_builtins.bool]] = None,
+                 kubeconfig: Optional[pulumi.Input['KubeconfigOptionsArgs']] = None,
+                 machine_configurations: Optional[pulumi.Input[Sequence[pulumi.Input['MachineConfigurationArgs']]]] = None,
+                 reapply_on_drift: Optional[pulumi.Input[_builtins.bool]] = None,
+                 reboot_timeout: Optional[pulumi.Input[_builtins.str]] = None,
+                 skip_init_apply: Optional[pulumi.Input[_builtins.bool]] = None,
+                 try_timeout: Optional[pulumi.Input[_builtins.str]] = None):
         """
         The set of arguments for constructing a Apply resource.
-        :param pulumi.Input['ApplyMachinesArgs'] apply_machines: The machine configurations to apply.
-        :param pulumi.Input['ClientConfigurationArgs'] client_configuration: Client configuration for bootstrapping and applying resources.
+        :param pulumi.Input[Sequence[pulumi.Input['TalosconfigSpecArgs']]] additional_talosconfigs: Talosconfigs with their own roles, e.g. os:reader for on-call engineers. 
+               Client certificates are signed by the Talos CA from the configuration of the init node.
+        :param pulumi.Input['ApplyMachinesArgs'] apply_machines: The machine configurations to apply, usually the machines output of the Cluster resource. 
+               Either applyMachines or machineConfigurations is required.
+        :param pulumi.Input['ApplyMode'] apply_mode: Default mode of `talosctl apply-config` for machines without their own applyMode. 
+               Changes requiring a reboot fail in no-reboot and try modes before reaching the node. 
+               Default is auto.
+        :param pulumi.Input['ClientConfigurationArgs'] client_configuration: Client configuration for bootstrapping and applying resources. 
+               Either clientConfiguration or clientTalosconfig is required.
+        :param pulumi.Input[_builtins.str] client_talosconfig: Talosconfig whose current context gives the client credentials to access the nodes. 
+               Either clientConfiguration or clientTalosconfig is required.
+        :param pulumi.Input[_builtins.bool] detect_drift: detectDrift fetches the running configuration from every machine after apply 
+               and compares it with the desired one to find changes made out of band (e.g. via `talosctl edit mc`). 
+               Images of Kubernetes components are ignored since they are managed by upgrade-k8s. 
+               Default is false.
+        :param pulumi.Input['KubeconfigOptionsArgs'] kubeconfig: Options of the admin kubeconfig in credentials. 
+               The kubeconfig is signed locally by the Kubernetes CA from the configuration of the init node.
+        :param pulumi.Input[Sequence[pulumi.Input['MachineConfigurationArgs']]] machine_configurations: Machine configurations generated by other tools, e.g. `talosctl gen config` or talhelper. 
+               The machine type, Talos image, Kubernetes version and cluster endpoint are taken from every configuration. 
+               etcd is bootstrapped on the machine of the init type or on the first controlplane. 
+               Either applyMachines or machineConfigurations is required.
+        :param pulumi.Input[_builtins.bool] reapply_on_drift: reapplyOnDrift applies the desired configuration again if a drift is detected. 
+               Requires detectDrift. 
+               Default is false.
+        :param pulumi.Input[_builtins.str] reboot_timeout: How long to wait for a node to come back after a reboot. 
+               The node is back when it reports a new boot ID and reaches the running or maintenance stage. 
+               Default is 10m0s.
         :param pulumi.Input[_builtins.bool] skip_init_apply: skipInitApply indicates that machines will be managed or configured by external tools. 
                For example, it can serve as a source for userdata in cloud provider setups. 
                This option helps accelerate node provisioning. 
                Note: init node is always applied. 
                Default is false.
+        :param pulumi.Input[_builtins.str] try_timeout: Default duration after which a configuration applied in try mode is rolled back. 
+               Default is 1m.
         """
-        pulumi.set(__self__, "apply_machines", apply_machines)
-        pulumi.set(__self__, "client_configuration", client_configuration)
+        if additional_talosconfigs is not None:
+            pulumi.set(__self__, "additional_talosconfigs", additional_talosconfigs)
+        if apply_machines is not None:
+            pulumi.set(__self__, "apply_machines", apply_machines)
+        if apply_mode is None:
+            apply_mode = 'auto'
+        if apply_mode is not None:
+            pulumi.set(__self__, "apply_mode", apply_mode)
+        if client_configuration is not None:
+            pulumi.set(__self__, "client_configuration", client_configuration)
+        if client_talosconfig is not None:
+            pulumi.set(__self__, "client_talosconfig", client_talosconfig)
+        if detect_drift is None:
+            detect_drift = False
+        if detect_drift is not None:
+            pulumi.set(__self__, "detect_drift", detect_drift)
+        if kubeconfig is not None:
+            pulumi.set(__self__, "kubeconfig", kubeconfig)
+        if machine_configurations is not None:
+            pulumi.set(__self__, "machine_configurations", machine_configurations)
+        if reapply_on_drift is None:
+            reapply_on_drift = False
+        if reapply_on_drift is not None:
+            pulumi.set(__self__, "reapply_on_drift", reapply_on_drift)
+        if reboot_timeout is None:
+            reboot_timeout = '10m0s'
+        if reboot_timeout is not None:
+            pulumi.set(__self__, "reboot_timeout", reboot_timeout)
         if skip_init_apply is None:
             skip_init_apply = False
         if skip_init_apply is not None:
             pulumi.set(__self__, "skip_init_apply", skip_init_apply)
+        if try_timeout is None:
+            try_timeout = '1m'
+        if try_timeout is not None:
+            pulumi.set(__self__, "try_timeout", try_timeout)
+
+    @_builtins.property
+    @pulumi.getter(name="additionalTalosconfigs")
+    def additional_talosconfigs(self) -> Optional[pulumi.Input[Sequence[pulumi.Input['TalosconfigSpecArgs']]]]:
+        """
+        Talosconfigs with their own roles, e.g. os:reader for on-call engineers. 
+        Client certificates are signed by the Talos CA from the configuration of the init node.
+        """
+        return pulumi.get(self, "additional_talosconfigs")
+
+    @additional_talosconfigs.setter
+    def additional_talosconfigs(self, value: Optional[pulumi.Input[Sequence[pulumi.Input['TalosconfigSpecArgs']]]]):
+        pulumi.set(self, "additional_talosconfigs", value)
 
     @_builtins.property
     @pulumi.getter(name="applyMachines")
-    def apply_machines(self) -> pulumi.Input['ApplyMachinesArgs']:
+    def apply_machines(self) -> Optional[pulumi.Input['ApplyMachinesArgs']]:
         """
-        The machine configurations to apply.
+        The machine configurations to apply, usually the machines output of the Cluster resource. 
+        Either applyMachines or machineConfigurations is required.
         """
         return pulumi.get(self, "apply_machines")
 
     @apply_machines.setter
-    def apply_machines(self, value: pulumi.Input['ApplyMachinesArgs']):
+    def apply_machines(self, value: Optional[pulumi.Input['ApplyMachinesArgs']]):
         pulumi.set(self, "apply_machines", value)
 
+    @_builtins.property
+    @pulumi.getter(name="applyMode")
+    def apply_mode(self) -> Optional[pulumi.Input['ApplyMode']]:
+        """
+        Default mode of `talosctl apply-config` for machines without their own applyMode. 
+        Changes requiring a reboot fail in no-reboot and try modes before reaching the node. 
+        Default is auto.
+        """
+        return pulumi.get(self, "apply_mode")
+
+    @apply_mode.setter
+    def apply_mode(self, value: Optional[pulumi.Input['ApplyMode']]):
+        pulumi.set(self, "apply_mode", value)
+
     @_builtins.property
     @pulumi.getter(name="clientConfiguration")
-    def client_configuration(self) -> pulumi.Input['ClientConfigurationArgs']:
+    def client_configuration(self) -> Optional[pulumi.Input['ClientConfigurationArgs']]:
         """
-        Client configuration for bootstrapping and applying resources.
+        Client configuration for bootstrapping and applying resources. 
+        Either clientConfiguration or clientTalosconfig is required.
         """
         return pulumi.get(self, "client_configuration")
 
     @client_configuration.setter
-    def client_configuration(self, value: pulumi.Input['ClientConfigurationArgs']):
+    def client_configuration(self, value: Optional[pulumi.Input['ClientConfigurationArgs']]):
         pulumi.set(self, "client_configuration", value)
 
+    @_builtins.property
+    @pulumi.getter(name="clientTalosconfig")
+    def client_talosconfig(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Talosconfig whose current context gives the client credentials to access the nodes. 
+        Either clientConfiguration or clientTalosconfig is required.
+        """
+        return pulumi.get(self, "client_talosconfig")
+
+    @client_talosconfig.setter
+    def client_talosconfig(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "client_talosconfig", value)
+
+    @_builtins.property
+    @pulumi.getter(name="detectDrift")
+    def detect_drift(self) -> Optional[pulumi.Input[_builtins.bool]]:
+        """
+        detectDrift fetches the running configuration from every machine after apply 
+        and compares it with the desired one to find changes made out of band (e.g. via `talosctl edit mc`). 
+        Images of Kubernetes components are ignored since they are managed by upgrade-k8s. 
+        Default is false.
+        """
+        return pulumi.get(self, "detect_drift")
+
+    @detect_drift.setter
+    def detect_drift(self, value: Optional[pulumi.Input[_builtins.bool]]):
+        pulumi.set(self, "detect_drift", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def kubeconfig(self) -> Optional[pulumi.Input['KubeconfigOptionsArgs']]:
+        """
+        Options of the admin kubeconfig in credentials. 
+        The kubeconfig is signed locally by the Kubernetes CA from the configuration of the init node.
+        """
+        return pulumi.get(self, "kubeconfig")
+
+    @kubeconfig.setter
+    def kubeconfig(self, value: Optional[pulumi.Input['KubeconfigOptionsArgs']]):
+        pulumi.set(self, "kubeconfig", value)
+
+    @_builtins.property
+    @pulumi.getter(name="machineConfigurations")
+    def machine_configurations(self) -> Optional[pulumi.Input[Sequence[pulumi.Input['MachineConfigurationArgs']]]]:
+        """
+        Machine configurations generated by other tools, e.g. `talosctl gen config` or talhelper. 
+        The machine type, Talos image, Kubernetes version and cluster endpoint are taken from every configuration. 
+        etcd is bootstrapped on the machine of the init type or on the first controlplane. 
+        Either applyMachines or machineConfigurations is required.
+        """
+        return pulumi.get(self, "machine_configurations")
+
+    @machine_configurations.setter
+    def machine_configurations(self, value: Optional[pulumi.Input[Sequence[pulumi.Input['MachineConfigurationArgs']]]]):
+        pulumi.set(self, "machine_configurations", value)
+
+    @_builtins.property
+    @pulumi.getter(name="reapplyOnDrift")
+    def reapply_on_drift(self) -> Optional[pulumi.Input[_builtins.bool]]:
+        """
+        reapplyOnDrift applies the desired configuration again if a drift is detected. 
+        Requires detectDrift. 
+        Default is false.
+        """
+        return pulumi.get(self, "reapply_on_drift")
+
+    @reapply_on_drift.setter
+    def reapply_on_drift(self, value: Optional[pulumi.Input[_builtins.bool]]):
+        pulumi.set(self, "reapply_on_drift", value)
+
+    @_builtins.property
+    @pulumi.getter(name="rebootTimeout")
+    def reboot_timeout(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        How long to wait for a node to come back after a reboot. 
+        The node is back when it reports a new boot ID and reaches the running or maintenance stage. 
+        Default is 10m0s.
+        """
+        return pulumi.get(self, "reboot_timeout")
+
+    @reboot_timeout.setter
+    def reboot_timeout(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "reboot_timeout", value)
+
     @_builtins.property
     @pulumi.getter(name="skipInitApply")
     def skip_init_apply(self) -> Optional[pulumi.Input[_builtins.bool]]:
@@ -81,6 +261,19 @@ class ApplyArgs:
     def skip_init_apply(self, value: Optional[pulumi.Input[_builtins.bool]]):
         pulumi.set(self, "skip_init_apply", value)
 
+    @_builtins.property
+    @pulumi.getter(name="tryTimeout")
+    def try_timeout(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Default duration after which a configuration applied in try mode is rolled back. 
+        Default is 1m.
+        """
+        return pulumi.get(self, "try_timeout")
+
+    @try_timeout.setter
+    def try_timeout(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "try_timeout", value)
+
 
 @pulumi.type_token("talos-cluster:index:Apply")
 class Apply(pulumi.ComponentResource):
@@ -88,28 +281,64 @@ class Apply(pulumi.ComponentResource):
     def __init__(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
+                 additional_talosconfigs: Optional[pulumi.Input[Sequence[pulumi.Input[Union['TalosconfigSpecArgs', 'TalosconfigSpecArgsDict']]]]] = None,
                  apply_machines: Optional[pulumi.Input[Union['ApplyMachinesArgs', 'ApplyMachinesArgsDict']]] = None,
+                 apply_mode: Optional[pulumi.Input['ApplyMode']] = None,
                  client_configuration: Optional[pulumi.Input[Union['ClientConfigurationArgs', 'ClientConfigurationArgsDict']]] = None,
+                 client_talosconfig: Optional[pulumi.Input[_builtins.str]] = None,
+                 detect_drift: Optional[pulumi.Input[_builtins.bool]] = None,
+                 kubeconfig: Optional[pulumi.Input[Union['KubeconfigOptionsArgs', 'KubeconfigOptionsArgsDict']]] = None,
+                 machine_configurations: Optional[pulumi.Input[Sequence[pulumi.Input[Union['MachineConfigurationArgs', 'MachineConfigurationArgsDict']]]]] = None,
+                 reapply_on_drift: Optional[pulumi.Input[_builtins.bool]] = None,
+                 reboot_timeout: Optional[pulumi.Input[_builtins.str]] = None,
                  skip_init_apply: Optional[pulumi.Input[_builtins.bool]] = None,
+                 try_timeout: Optional[pulumi.Input[_builtins.str]] = None,
                  __props__=None):
         """
         Apply the configuration to nodes.
 
         :param str resource_name: The name of the resource.
         :param pulumi.ResourceOptions opts: Options for the resource.
-        :param pulumi.Input[Union['ApplyMachinesArgs', 'ApplyMachinesArgsDict']] apply_machines: The machine configurations to apply.
-        :param pulumi.Input[Union['ClientConfigurationArgs', 'ClientConfigurationArgsDict']] client_configuration: Client configuration for bootstrapping and applying resources.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['TalosconfigSpecArgs', 'TalosconfigSpecArgsDict']]]] additional_talosconfigs: Talosconfigs with their own roles, e.g. os:reader for on-call engineers. 
+               Client certificates are signed by the Talos CA from the configuration of the init node.
+        :param pulumi.Input[Union['ApplyMachinesArgs', 'ApplyMachinesArgsDict']] apply_machines: The machine configurations to apply, usually the machines output of the Cluster resource. 
+               Either applyMachines or machineConfigurations is required.
+        :param pulumi.Input['ApplyMode'] apply_mode: Default mode of `talosctl apply-config` for machines without their own applyMode. 
+               Changes requiring a reboot fail in no-reboot and try modes before reaching the node. 
+               Default is auto.
+        :param pulumi.Input[Union['ClientConfigurationArgs', 'ClientConfigurationArgsDict']] client_configuration: Client configuration for bootstrapping and applying resources. 
+               Either clientConfiguration or clientTalosconfig is required.
+        :param pulumi.Input[_builtins.str] client_talosconfig: Talosconfig whose current context gives the client credentials to access the nodes. 
+               Either clientConfiguration or clientTalosconfig is required.
+        :param pulumi.Input[_builtins.bool] detect_drift: detectDrift fetches the running configuration from every machine after apply 
+               and compares it with the desired one to find changes made out of band (e.g. via `talosctl edit mc`). 
+               Images of Kubernetes components are ignored since they are managed by upgrade-k8s. 
+               Default is false.
+        :param pulumi.Input[Union['KubeconfigOptionsArgs', 'KubeconfigOptionsArgsDict']] kubeconfig: Options of the admin kubeconfig in credentials. 
+               The kubeconfig is signed locally by the Kubernetes CA from the configuration of the init node.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['MachineConfigurationArgs', 'MachineConfigurationArgsDict']]]] machine_configurations: Machine configurations generated by other tools, e.g. `talosctl gen config` or talhelper. 
+               The machine type, Talos image, Kubernetes version and cluster endpoint are taken from every configuration. 
+               etcd is bootstrapped on the machine of the init type or on the first controlplane. 
+               Either applyMachines or machineConfigurations is required.
+        :param pulumi.Input[_builtins.bool] reapply_on_drift: reapplyOnDrift applies the desired configuration again if a drift is detected. 
+               Requires detectDrift. 
+               Default is false.
+        :param pulumi.Input[_builtins.str] reboot_timeout: How long to wait for a node to come back after a reboot. 
+               The node is back when it reports a new boot ID and reaches the running or maintenance stage. 
+               Default is 10m0s.
         :param pulumi.Input[_builtins.bool] skip_init_apply: skipInitApply indicates that machines will be managed or configured by external tools. 
                For example, it can serve as a source for userdata in cloud provider setups. 
                This option helps accelerate node provisioning. 
                Note: init node is always applied. 
                Default is false.
+        :param pulumi.Input[_builtins.str] try_timeout: Default duration after which a configuration applied in try mode is rolled back. 
+               Default is 1m.
         """
         ...
     @overload
     def __init__(__self__,
                  resource_name: str,
-                 args: ApplyArgs,
+                 args: Optional[ApplyArgs] = None,
                  opts: Optional[pulumi.ResourceOptions] = None):
         """
         Apply the configuration to nodes.
@@ -129,9 +358,18 @@ class Apply(pulumi.ComponentResource):
     def _internal_init(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
+                 additional_talosconfigs: Optional[pulumi.Input[Sequence[pulumi.Input[Union['TalosconfigSpecArgs', 'TalosconfigSpecArgsDict']]]]] = None,
                  apply_machines: Optional[pulumi.Input[Union['ApplyMachinesArgs', 'ApplyMachinesArgsDict']]] = None,
+                 apply_mode: Optional[pulumi.Input['ApplyMode']] = None,
                  client_configuration: Optional[pulumi.Input[Union['ClientConfigurationArgs', 'ClientConfigurationArgsDict']]] = None,
+                 client_talosconfig: Optional[pulumi.Input[_builtins.str]] = None,
+                 detect_drift: Optional[pulumi.Input[_builtins.bool]] = None,
+                 kubeconfig: Optional[pulumi.Input[Union['KubeconfigOptionsArgs', 'KubeconfigOptionsArgsDict']]] = None,
+                 machine_configurations: Optional[pulumi.Input[Sequence[pulumi.Input[Union['MachineConfigurationArgs', 'MachineConfigurationArgsDict']]]]] = None,
+                 reapply_on_drift: Optional[pulumi.Input[_builtins.bool]] = None,
+                 reboot_timeout: Optional[pulumi.Input[_builtins.str]] = None,
                  skip_init_apply: Optional[pulumi.Input[_builtins.bool]] = None,
+                 try_timeout: Optional[pulumi.Input[_builtins.str]] = None,
                  __props__=None):
         opts = pulumi.ResourceOptions.merge(_utilities.get_resource_opts_defaults(), opts)
         if not isinstance(opts, pulumi.ResourceOptions):
@@ -143,16 +381,38 @@ class Apply(pulumi.ComponentResource):
                 raise TypeError('__props__ is only valid when passed in combination with a valid opts.id to get an existing resource')
             __props__ = ApplyArgs.__new__(ApplyArgs)
 
-            if apply_machines is None and not opts.urn:
-                raise TypeError("Missing required property 'apply_machines'")
+            __props__.__dict__["additional_talosconfigs"] = additional_talosconfigs
             __props__.__dict__["apply_machines"] = apply_machines
-            if client_configuration is None and not opts.urn:
-                raise TypeError("Missing required property 'client_configuration'")
+            if apply_mode is None:
+                apply_mode = 'auto'
+            __props__.__dict__["apply_mode"] = apply_mode
             __props__.__dict__["client_configuration"] = client_configuration
+            __props__.__dict__["client_talosconfig"] = None if client_talosconfig is None else pulumi.Output.secret(client_talosconfig)
+            if detect_drift is None:
+                detect_drift = False
+            __props__.__dict__["detect_drift"] = detect_drift
+            __props__.__dict__["kubeconfig"] = kubeconfig
+            __props__.__dict__["machine_configurations"] = machine_configurations
+            if reapply_on_drift is None:
+                reapply_on_drift = False
+            __props__.__dict__["reapply_on_drift"] = reapply_on_drift
+            if reboot_timeout is None:
+                reboot_timeout = '10m0s'
+            __props__.__dict__["reboot_timeout"] = reboot_timeout
             if skip_init_apply is None:
                 skip_init_apply = False
             __props__.__dict__["skip_init_apply"] = skip_init_apply
+            if try_timeout is None:
+                try_timeout = '1m'
+            __props__.__dict__["try_timeout"] = try_timeout
+            __props__.__dict__["admin_certificate_expiry"] = None
             __props__.__dict__["credentials"] = None
+            __props__.__dict__["drift"] = None
+            __props__.__dict__["machines"] = None
+            __props__.__dict__["planned_config_diff"] = None
+            __props__.__dict__["talosconfigs"] = None
+        secret_opts = pulumi.ResourceOptions(additional_secret_outputs=["credentials", "plannedConfigDiff", "talosconfigs"])
+        opts = pulumi.ResourceOptions.merge(opts, secret_opts)
         super(Apply, __self__).__init__(
             'talos-cluster:index:Apply',
             resource_name,
@@ -160,8 +420,53 @@ class Apply(pulumi.ComponentResource):
             opts,
             remote=True)
 
+    @_builtins.property
+    @pulumi.getter(name="adminCertificateExpiry")
+    def admin_certificate_expiry(self) -> pulumi.Output[Optional[_builtins.str]]:
+        """
+        Expiry time of the admin kubeconfig client certificate in RFC 3339 format.
+        """
+        return pulumi.get(self, "admin_certificate_expiry")
+
     @_builtins.property
     @pulumi.getter
     def credentials(self) -> pulumi.Output['outputs.Credentials']:
+        """
+        Kubeconfig and talosconfig of the cluster.
+        """
         return pulumi.get(self, "credentials")
 
+    @_builtins.property
+    @pulumi.getter
+    def drift(self) -> pulumi.Output[Optional[Mapping[str, Sequence[_builtins.str]]]]:
+        """
+        Configuration fields which differ between the desired and the running configuration, keyed by machine ID. 
+        Populated only if detectDrift is enabled.
+        """
+        return pulumi.get(self, "drift")
+
+    @_builtins.property
+    @pulumi.getter
+    def machines(self) -> pulumi.Output[Optional[Mapping[str, 'outputs.MachineStatus']]]:
+        """
+        Applied state of every machine keyed by machine ID.
+        """
+        return pulumi.get(self, "machines")
+
+    @_builtins.property
+    @pulumi.getter(name="plannedConfigDiff")
+    def planned_config_diff(self) -> pulumi.Output[Optional[Mapping[str, _builtins.str]]]:
+        """
+        Output of `talosctl apply-config --dry-run` for machines with changed configuration, keyed by machine ID. 
+        Populated only during preview. Values of keys, certificates and tokens are redacted.
+        """
+        return pulumi.get(self, "planned_config_diff")
+
+    @_builtins.property
+    @pulumi.getter
+    def talosconfigs(self) -> pulumi.Output[Optional[Mapping[str, _builtins.str]]]:
+        """
+        Talosconfigs from additionalTalosconfigs keyed by name.
+        """
+        return pulumi.get(self, "talosconfigs")
+
@@ -25,6 +25,7 @@ class ClusterArgs:
                  cluster_endpoint: pulumi.Input[_builtins.str],
                  cluster_machines: pulumi.Input[Sequence[pulumi.Input['ClusterMachinesArgs']]],
                  cluster_name: _builtins.str,
+                 existing_secrets: Optional[pulumi.Input[_builtins.str]] = None,
                  kubernetes_version: Optional[pulumi.Input[_builtins.str]] = None,
                  talos_version_contract: Optional[pulumi.Input[_builtins.str]] = None):
         """
@@ -32,18 +33,23 @@ class ClusterArgs:
         :param pulumi.Input[_builtins.str] cluster_endpoint: Cluster endpoint, the Kubernetes API endpoint accessible by all nodes
         :param pulumi.Input[Sequence[pulumi.Input['ClusterMachinesArgs']]] cluster_machines: Configuration settings for machines
         :param _builtins.str cluster_name: Name of the cluster
+        :param pulumi.Input[_builtins.str] existing_secrets: Existing secrets bundle (YAML) to use instead of a generated one. 
+               Accepts the output of `talosctl gen secrets` or the machineSecrets of the pulumiverse talos Secrets resource. 
+               It is only read on creation of machineSecrets, e.g. to adopt a cluster created outside of the stack.
         :param pulumi.Input[_builtins.str] kubernetes_version: Kubernetes version to install. 
                Default is v1.33.0.
         :param pulumi.Input[_builtins.str] talos_version_contract: Version of Talos features used for configuration generation. 
                Do not confuse this with the talosImage property. 
-               Used in NewSecrets() and GetConfigurationOutput() resources. 
-               This property is immutable to prevent version conflicts across provider updates. 
+               Used to generate the secrets bundle and machine configurations. 
+               This property is immutable: the value used on creation is kept in machineSecrets. 
                See issue: https://github.com/siderolabs/terraform-provider-talos/issues/168 
                The default value is based on gendata.VersionTag, current: v1.12.0.
         """
         pulumi.set(__self__, "cluster_endpoint", cluster_endpoint)
         pulumi.set(__self__, "cluster_machines", cluster_machines)
         pulumi.set(__self__, "cluster_name", cluster_name)
+        if existing_secrets is not None:
+            pulumi.set(__self__, "existing_secrets", existing_secrets)
         if kubernetes_version is None:
             kubernetes_version = 'v1.33.0'
         if kubernetes_version is not None:
@@ -89,6 +95,20 @@ class ClusterArgs:
     def cluster_name(self, value: _builtins.str):
         pulumi.set(self, "cluster_name", value)
 
+    @_builtins.property
+    @pulumi.getter(name="existingSecrets")
+    def existing_secrets(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Existing secrets bundle (YAML) to use instead of a generated one. 
+        Accepts the output of `talosctl gen secrets` or the machineSecrets of the pulumiverse talos Secrets resource. 
+        It is only read on creation of machineSecrets, e.g. to adopt a cluster created outside of the stack.
+        """
+        return pulumi.get(self, "existing_secrets")
+
+    @existing_secrets.setter
+    def existing_secrets(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "existing_secrets", value)
+
     @_builtins.property
     @pulumi.getter(name="kubernetesVersion")
     def kubernetes_version(self) -> Optional[pulumi.Input[_builtins.str]]:
@@ -108,8 +128,8 @@ class ClusterArgs:
         """
         Version of Talos features used for configuration generation. 
         Do not confuse this with the talosImage property. 
-        Used in NewSecrets() and GetConfigurationOutput() resources. 
-        This property is immutable to prevent version conflicts across provider updates. 
+        Used to generate the secrets bundle and machine configurations. 
+        This property is immutable: the value used on creation is kept in machineSecrets. 
         See issue: https://github.com/siderolabs/terraform-provider-talos/issues/168 
         The default value is based on gendata.VersionTag, current: v1.12.0.
         """
@@ -129,6 +149,7 @@ class Cluster(pulumi.ComponentResource):
                  cluster_endpoint: Optional[pulumi.Input[_builtins.str]] = None,
                  cluster_machines: Optional[pulumi.Input[Sequence[pulumi.Input[Union['ClusterMachinesArgs', 'ClusterMachinesArgsDict']]]]] = None,
                  cluster_name: Optional[_builtins.str] = None,
+                 existing_secrets: Optional[pulumi.Input[_builtins.str]] = None,
                  kubernetes_version: Optional[pulumi.Input[_builtins.str]] = None,
                  talos_version_contract: Optional[pulumi.Input[_builtins.str]] = None,
                  __props__=None):
@@ -142,12 +163,15 @@ class Cluster(pulumi.ComponentResource):
         :param pulumi.Input[_builtins.str] cluster_endpoint: Cluster endpoint, the Kubernetes API endpoint accessible by all nodes
         :param pulumi.Input[Sequence[pulumi.Input[Union['ClusterMachinesArgs', 'ClusterMachinesArgsDict']]]] cluster_machines: Configuration settings for machines
         :param _builtins.str cluster_name: Name of the cluster
+        :param pulumi.Input[_builtins.str] existing_secrets: Existing secrets bundle (YAML) to use instead of a generated one. 
+               Accepts the output of `talosctl gen secrets` or the machineSecrets of the pulumiverse talos Secrets resource. 
+               It is only read on creation of machineSecrets, e.g. to adopt a cluster created outside of the stack.
         :param pulumi.Input[_builtins.str] kubernetes_version: Kubernetes version to install. 
                Default is v1.33.0.
         :param pulumi.Input[_builtins.str] talos_version_contract: Version of Talos features used for configuration generation. 
                Do not confuse this with the talosImage property. 
-               Used in NewSecrets() and GetConfigurationOutput() resources. 
-               This property is immutable to prevent version conflicts across provider updates. 
+               Used to generate the secrets bundle and machine configurations. 
+               This property is immutable: the value used on creation is kept in machineSecrets. 
                See issue: https://github.com/siderolabs/terraform-provider-talos/issues/168 
                The default value is based on gendata.VersionTag, current: v1.12.0.
         """
@@ -180,6 +204,7 @@ class Cluster(pulumi.ComponentResource):
                  cluster_endpoint: Optional[pulumi.Input[_builtins.str]] = None,
                  cluster_machines: Optional[pulumi.Input[Sequence[pulumi.Input[Union['ClusterMachinesArgs', 'ClusterMachinesArgsDict']]]]] = None,
                  cluster_name: Optional[_builtins.str] = None,
+                 existing_secrets: Optional[pulumi.Input[_builtins.str]] = None,
                  kubernetes_version: Optional[pulumi.Input[_builtins.str]] = None,
                  talos_version_contract: Optional[pulumi.Input[_builtins.str]] = None,
                  __props__=None):
@@ -202,6 +227,7 @@ class Cluster(pulumi.ComponentResource):
             if cluster_name is None and not opts.urn:
                 raise TypeError("Missing required property 'cluster_name'")
             __props__.__dict__["cluster_name"] = cluster_name
+            __props__.__dict__["existing_secrets"] = None if existing_secrets is None else pulumi.Output.secret(existing_secrets)
             if kubernetes_version is None:
                 kubernetes_version = 'v1.33.0'
             __props__.__dict__["kubernetes_version"] = kubernetes_version
@@ -210,7 +236,10 @@ class Cluster(pulumi.ComponentResource):
             __props__.__dict__["talos_version_contract"] = talos_version_contract
             __props__.__dict__["client_configuration"] = None
             __props__.__dict__["generated_configurations"] = None
+            __props__.__dict__["machine_secrets"] = None
             __props__.__dict__["machines"] = None
+        secret_opts = pulumi.ResourceOptions(additional_secret_outputs=["generatedConfigurations", "machineSecrets"])
+        opts = pulumi.ResourceOptions.merge(opts, secret_opts)
         super(Cluster, __self__).__init__(
             'talos-cluster:index:Cluster',
             resource_name,
@@ -234,11 +263,118 @@ class Cluster(pulumi.ComponentResource):
         """
         return pulumi.get(self, "generated_configurations")
 
+    @_builtins.property
+    @pulumi.getter(name="machineSecrets")
+    def machine_secrets(self) -> pulumi.Output[_builtins.str]:
+        """
+        Cluster secrets bundle and admin client certificate generated on creation (YAML). 
+        It is kept in the state and never regenerated.
+        """
+        return pulumi.get(self, "machine_secrets")
+
     @_builtins.property
     @pulumi.getter
-    def machines(self) -> pulumi.Output['outputs.ApplyMachines']:
+    def machines(self) -> pulumi.Output['outputs.MachinesByType']:
         """
         Machine information grouped by machine type.
         """
         return pulumi.get(self, "machines")
 
+    @pulumi.output_type
+    class GetJoinConfigResult:
+        def __init__(__self__, configuration=None):
+            if configuration and not isinstance(configuration, str):
+                raise TypeError("Expected argument 'configuration' to be a str")
+            pulumi.set(__self__, "configuration", configuration)
+
+        @_builtins.property
+        @pulumi.getter
+        def configuration(self) -> _builtins.str:
+            """
+            Machine configuration YAML.
+            """
+            return pulumi.get(self, "configuration")
+
+    def get_join_config(__self__, *,
+                        role: pulumi.Input[_builtins.str]) -> pulumi.Output['str']:
+        """
+        Generate a configuration for a new machine of the role, which joins the cluster.
+        It uses the cluster secrets and the default installation image.
+
+
+        :param pulumi.Input[_builtins.str] role: Machine type of the new machine: controlplane or worker.
+        """
+        __args__ = dict()
+        __args__['__self__'] = __self__
+        __args__['role'] = role
+        __result__ = pulumi.runtime.call('talos-cluster:index:Cluster/getJoinConfig', __args__, res=__self__, typ=Cluster.GetJoinConfigResult)
+        return __result__.configuration
+
+    @pulumi.output_type
+    class GetMachineConfigResult:
+        def __init__(__self__, configuration=None):
+            if configuration and not isinstance(configuration, str):
+                raise TypeError("Expected argument 'configuration' to be a str")
+            pulumi.set(__self__, "configuration", configuration)
+
+        @_builtins.property
+        @pulumi.getter
+        def configuration(self) -> _builtins.str:
+            """
+            Machine configuration YAML.
+            """
+            return pulumi.get(self, "configuration")
+
+    def get_machine_config(__self__, *,
+                           machine_id: pulumi.Input[_builtins.str],
+                           extra_patches: Optional[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]] = None) -> pulumi.Output['str']:
+        """
+        Get the generated configuration of a machine merged with extra patches. No node is contacted.
+
+
+        :param pulumi.Input[_builtins.str] machine_id: ID of the machine.
+        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] extra_patches: Patches to merge into the configuration in the same way as configPatches.
+        """
+        __args__ = dict()
+        __args__['__self__'] = __self__
+        __args__['machineId'] = machine_id
+        __args__['extraPatches'] = extra_patches
+        __result__ = pulumi.runtime.call('talos-cluster:index:Cluster/getMachineConfig', __args__, res=__self__, typ=Cluster.GetMachineConfigResult)
+        return __result__.configuration
+
+    @pulumi.output_type
+    class GetTalosconfigResult:
+        def __init__(__self__, talosconfig=None):
+            if talosconfig and not isinstance(talosconfig, str):
+                raise TypeError("Expected argument 'talosconfig' to be a str")
+            pulumi.set(__self__, "talosconfig", talosconfig)
+
+        @_builtins.property
+        @pulumi.getter
+        def talosconfig(self) -> _builtins.str:
+            """
+            The talosconfig.
+            """
+            return pulumi.get(self, "talosconfig")
+
+    def get_talosconfig(__self__, *,
+                        endpoints: Optional[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]] = None,
+                        nodes: Optional[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]] = None,
+                        roles: Optional[pulumi.Input[Sequence[pulumi.Input[_builtins.str]]]] = None) -> pulumi.Output['str']:
+        """
+        Issue a talosconfig with a new client certificate signed by the Talos CA of the cluster.
+        A new certificate is issued on every call.
+
+
+        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] endpoints: Endpoints of the talosconfig context.
+        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] nodes: Nodes of the talosconfig context.
+        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] roles: Talos API roles of the certificate, e.g. os:reader. Default is os:admin.
+        """
+        __args__ = dict()
+        __args__['__self__'] = __self__
+        __args__['endpoints'] = endpoints
+        __args__['nodes'] = nodes
+        __args__['roles'] = roles
+        __result__ = pulumi.runtime.call('talos-cluster:index:Cluster/getTalosconfig', __args__, res=__self__, typ=Cluster.GetTalosconfigResult)
+        return __result__.talosconfig
+
@@ -0,0 +1,9 @@
+# coding=utf-8
+# *** WARNING: this file was generated by pulumi-language-python. ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import builtins as _builtins
+import sys
+from .vars import _ExportableConfig
+
+sys.modules[__name__].__class__ = _ExportableConfig
@@ -0,0 +1,66 @@
+# coding=utf-8
+# *** WARNING: this file was generated by pulumi-language-python. ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import builtins as _builtins
+import warnings
+import sys
+import pulumi
+import pulumi.runtime
+from typing import Any, Mapping, Optional, Sequence, Union, overload
+if sys.version_info >= (3, 11):
+    from typing import NotRequired, TypedDict, TypeAlias
+else:
+    from typing_extensions import NotRequired, TypedDict, TypeAlias
+from . import _utilities
+from .. import _enums as _root_enums
+
+debugBundle: Optional[str]
+"""
+Diagnostics collected from a node when `talosctl upgrade` or `talosctl apply-config` fails. 
+`basic` collects dmesg, the service list, logs of machined (and etcd on controlplanes during upgrade) and the machine status. 
+`support` adds a `talosctl support` archive, which takes a few minutes. 
+The path of the bundle is a part of the error message. 
+Default is basic.
+"""
+
+debugBundleDir: Optional[str]
+"""
+Root directory for debug bundles. 
+Bundles are written to `<debugBundleDir>/<stack>/<cluster>/<stage>-<machine>/<time>` and are not removed. 
+Default is the debug directory inside the working directories of workDir.
+"""
+
+migrationMode: Optional[str]
+"""
+Migration of stacks created with pulumiverse/talos resources. 
+`aliases` registers the initial apply and bootstrap with aliases to the former resources, they are rerun, but skip configured nodes. 
+`import` takes the initial apply and bootstrap as done without contacting nodes. 
+In both modes the preview fails if a node is not configured, its etcd is not bootstrapped 
+or it runs other cluster secrets than the generated configuration. 
+Unset it after the migration.
+"""
+
+talosctlPath: Optional[str]
+"""
+Path to the talosctl binary or to a directory with talosctl binaries for different Talos versions. 
+If a directory is set, the binary matching the Talos version of a machine image is used for the machine, 
+and the newest one otherwise. 
+Default is talosctl from PATH.
+"""
+
+talosctlVersionCheck: Optional[str]
+"""
+Reaction on skew between the talosctl version and the Talos version of a machine image. 
+The version of every binary is checked via `talosctl version --client` on provider start. 
+Default is warn.
+"""
+
+workDir: Optional[str]
+"""
+Root directory for talosctl working directories. 
+Files with credentials and machine configurations are written to `<workDir>/talos-cluster-<uid>`, 
+which is accessible only by the current user. 
+Default is the system temporary directory.
+"""
+
@@ -0,0 +1,93 @@
+# coding=utf-8
+# *** WARNING: this file was generated by pulumi-language-python. ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import builtins as _builtins
+import warnings
+import sys
+import pulumi
+import pulumi.runtime
+from typing import Any, Mapping, Optional, Sequence, Union, overload
+if sys.version_info >= (3, 11):
+    from typing import NotRequired, TypedDict, TypeAlias
+else:
+    from typing_extensions import NotRequired, TypedDict, TypeAlias
+from . import _utilities
+from . import outputs
+from ._inputs import *
+
+__all__ = [
+    'GetClusterStatusResult',
+    'AwaitableGetClusterStatusResult',
+    'get_cluster_status',
+    'get_cluster_status_output',
+]
+
+@pulumi.output_type
+class GetClusterStatusResult:
+    def __init__(__self__, nodes=None):
+        if nodes and not isinstance(nodes, list):
+            raise TypeError("Expected argument 'nodes' to be a list")
+        pulumi.set(__self__, "nodes", nodes)
+
+    @_builtins.property
+    @pulumi.getter
+    def nodes(self) -> Sequence['outputs.NodeStatus']:
+        """
+        Status of every requested node in the same order.
+        """
+        return pulumi.get(self, "nodes")
+
+
+class AwaitableGetClusterStatusResult(GetClusterStatusResult):
+    # pylint: disable=using-constant-test
+    def __await__(self):
+        if False:
+            yield self
+        return GetClusterStatusResult(
+            nodes=self.nodes)
+
+
+def get_cluster_status(client_configuration: Optional[Union['ClientConfiguration', 'ClientConfigurationDict']] = None,
+                       nodes: Optional[Sequence[_builtins.str]] = None,
+                       opts: Optional[pulumi.InvokeOptions] = None) -> AwaitableGetClusterStatusResult:
+    """
+    Get the observed status of Talos nodes:
+    - Talos and kubelet versions
+    - Machine type and stage
+    - Etcd membership
+      Nodes which can't be reached are reported with an error instead of failing the whole call.
+
+
+    :param Union['ClientConfiguration', 'ClientConfigurationDict'] client_configuration: Client configuration for bootstrapping and applying resources.
+    :param Sequence[_builtins.str] nodes: IP addresses of nodes to query. Every node is used as its own endpoint.
+    """
+    __args__ = dict()
+    __args__['clientConfiguration'] = client_configuration
+    __args__['nodes'] = nodes
+    opts = pulumi.InvokeOptions.merge(_utilities.get_invoke_opts_defaults(), opts)
+    __ret__ = pulumi.runtime.invoke('talos-cluster:index:getClusterStatus', __args__, opts=opts, typ=GetClusterStatusResult).value
+
+    return AwaitableGetClusterStatusResult(
+        nodes=pulumi.get(__ret__, 'nodes'))
+def get_cluster_status_output(client_configuration: Optional[pulumi.Input[Union['ClientConfiguration', 'ClientConfigurationDict']]] = None,
+                              nodes: Optional[pulumi.Input[Sequence[_builtins.str]]] = None,
+                              opts: Optional[Union[pulumi.InvokeOptions, pulumi.InvokeOutputOptions]] = None) -> pulumi.Output[GetClusterStatusResult]:
+    """
+    Get the observed status of Talos nodes:
+    - Talos and kubelet versions
+    - Machine type and stage
+    - Etcd membership
+      Nodes which can't be reached are reported with an error instead of failing the whole call.
+
+
+    :param Union['ClientConfiguration', 'ClientConfigurationDict'] client_configuration: Client configuration for bootstrapping and applying resources.
+    :param Sequence[_builtins.str] nodes: IP addresses of nodes to query. Every node is used as its own endpoint.
+    """
+    __args__ = dict()
+    __args__['clientConfiguration'] = client_configuration
+    __args__['nodes'] = nodes
+    opts = pulumi.InvokeOutputOptions.merge(_utilities.get_invoke_opts_defaults(), opts)
+    __ret__ = pulumi.runtime.invoke_output('talos-cluster:index:getClusterStatus', __args__, opts=opts, typ=GetClusterStatusResult)
+    return __ret__.apply(lambda __response__: GetClusterStatusResult(
+        nodes=pulumi.get(__response__, 'nodes')))
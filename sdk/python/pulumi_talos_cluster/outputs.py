@@ -17,40 +17,14 @@ from . import outputs
 from ._enums import *
 
 __all__ = [
-    'ApplyMachines',
     'ClientConfiguration',
     'Credentials',
     'MachineInfo',
+    'MachineStatus',
+    'MachinesByType',
+    'NodeStatus',
 ]
 
-@pulumi.output_type
-class ApplyMachines(dict):
-    def __init__(__self__, *,
-                 init: Sequence['outputs.MachineInfo'],
-                 controlplane: Optional[Sequence['outputs.MachineInfo']] = None,
-                 worker: Optional[Sequence['outputs.MachineInfo']] = None):
-        pulumi.set(__self__, "init", init)
-        if controlplane is not None:
-            pulumi.set(__self__, "controlplane", controlplane)
-        if worker is not None:
-            pulumi.set(__self__, "worker", worker)
-
-    @_builtins.property
-    @pulumi.getter
-    def init(self) -> Sequence['outputs.MachineInfo']:
-        return pulumi.get(self, "init")
-
-    @_builtins.property
-    @pulumi.getter
-    def controlplane(self) -> Optional[Sequence['outputs.MachineInfo']]:
-        return pulumi.get(self, "controlplane")
-
-    @_builtins.property
-    @pulumi.getter
-    def worker(self) -> Optional[Sequence['outputs.MachineInfo']]:
-        return pulumi.get(self, "worker")
-
-
 @pulumi.output_type
 class ClientConfiguration(dict):
     @staticmethod
@@ -153,12 +127,18 @@ class MachineInfo(dict):
             suggest = "machine_id"
         elif key == "nodeIp":
             suggest = "node_ip"
+        elif key == "applyMode":
+            suggest = "apply_mode"
         elif key == "clusterEndpoint":
             suggest = "cluster_endpoint"
         elif key == "kubernetesVersion":
             suggest = "kubernetes_version"
+        elif key == "machineType":
+            suggest = "machine_type"
         elif key == "talosImage":
             suggest = "talos_image"
+        elif key == "tryTimeout":
+            suggest = "try_timeout"
         elif key == "userConfigPatches":
             suggest = "user_config_patches"
 
@@ -177,32 +157,55 @@ class MachineInfo(dict):
                  configuration: _builtins.str,
                  machine_id: _builtins.str,
                  node_ip: _builtins.str,
+                 apply_mode: Optional['ApplyMode'] = None,
                  cluster_endpoint: Optional[_builtins.str] = None,
                  kubernetes_version: Optional[_builtins.str] = None,
+                 machine_type: Optional['MachineTypes'] = None,
                  talos_image: Optional[_builtins.str] = None,
-                 user_config_patches: Optional[_builtins.str] = None):
+                 try_timeout: Optional[_builtins.str] = None,
+                 user_config_patches: Optional[_builtins.str] = None,
+                 version: Optional[_builtins.int] = None):
         """
         :param _builtins.str configuration: Configuration settings for machines to apply. 
                This can be retrieved from the cluster resource.
         :param _builtins.str machine_id: ID or name of the machine.
         :param _builtins.str node_ip: The IP address of the node where configuration will be applied.
+        :param 'ApplyMode' apply_mode: Mode of `talosctl apply-config` for the machine. 
+               Overrides the applyMode of the Apply resource.
         :param _builtins.str cluster_endpoint: cluster endpoint applied to node
-        :param _builtins.str kubernetes_version: Kubernetes version to install or upgrade on the node.
-        :param _builtins.str talos_image: Talos OS image to install or upgrade on the node.
+        :param _builtins.str kubernetes_version: Kubernetes version to install or upgrade on the node. 
+               The version of the kubelet image of the configuration is used if it is not set.
+        :param 'MachineTypes' machine_type: Type of the machine. 
+               Set by the Cluster resource. The machine is applied according to its group in applyMachines.
+        :param _builtins.str talos_image: Talos OS image to install or upgrade on the node. 
+               The install image of the configuration is used if it is not set.
+        :param _builtins.str try_timeout: Duration after which a configuration applied in try mode is rolled back. 
+               Overrides the tryTimeout of the Apply resource.
         :param _builtins.str user_config_patches: User-provided machine configuration to apply. 
                This can be retrieved from the cluster resource.
+        :param _builtins.int version: Version of the machine info format. 
+               Set by the Cluster resource, the current version is 1. 
+               Machines without it are parsed as version 0.
         """
         pulumi.set(__self__, "configuration", configuration)
         pulumi.set(__self__, "machine_id", machine_id)
         pulumi.set(__self__, "node_ip", node_ip)
+        if apply_mode is not None:
+            pulumi.set(__self__, "apply_mode", apply_mode)
         if cluster_endpoint is not None:
             pulumi.set(__self__, "cluster_endpoint", cluster_endpoint)
         if kubernetes_version is not None:
             pulumi.set(__self__, "kubernetes_version", kubernetes_version)
+        if machine_type is not None:
+            pulumi.set(__self__, "machine_type", machine_type)
         if talos_image is not None:
             pulumi.set(__self__, "talos_image", talos_image)
+        if try_timeout is not None:
+            pulumi.set(__self__, "try_timeout", try_timeout)
         if user_config_patches is not None:
             pulumi.set(__self__, "user_config_patches", user_config_patches)
+        if version is not None:
+            pulumi.set(__self__, "version", version)
 
     @_builtins.property
     @pulumi.getter
@@ -229,6 +232,15 @@ class MachineInfo(dict):
         """
         return pulumi.get(self, "node_ip")
 
+    @_builtins.property
+    @pulumi.getter(name="applyMode")
+    def apply_mode(self) -> Optional['ApplyMode']:
+        """
+        Mode of `talosctl apply-config` for the machine. 
+        Overrides the applyMode of the Apply resource.
+        """
+        return pulumi.get(self, "apply_mode")
+
     @_builtins.property
     @pulumi.getter(name="clusterEndpoint")
     def cluster_endpoint(self) -> Optional[_builtins.str]:
@@ -241,18 +253,38 @@ class MachineInfo(dict):
     @pulumi.getter(name="kubernetesVersion")
     def kubernetes_version(self) -> Optional[_builtins.str]:
         """
-        Kubernetes version to install or upgrade on the node.
+        Kubernetes version to install or upgrade on the node. 
+        The version of the kubelet image of the configuration is used if it is not set.
         """
         return pulumi.get(self, "kubernetes_version")
 
+    @_builtins.property
+    @pulumi.getter(name="machineType")
+    def machine_type(self) -> Optional['MachineTypes']:
+        """
+        Type of the machine. 
+        Set by the Cluster resource. The machine is applied according to its group in applyMachines.
+        """
+        return pulumi.get(self, "machine_type")
+
     @_builtins.property
     @pulumi.getter(name="talosImage")
     def talos_image(self) -> Optional[_builtins.str]:
         """
-        Talos OS image to install or upgrade on the node.
+        Talos OS image to install or upgrade on the node. 
+        The install image of the configuration is used if it is not set.
         """
         return pulumi.get(self, "talos_image")
 
+    @_builtins.property
+    @pulumi.getter(name="tryTimeout")
+    def try_timeout(self) -> Optional[_builtins.str]:
+        """
+        Duration after which a configuration applied in try mode is rolled back. 
+        Overrides the tryTimeout of the Apply resource.
+        """
+        return pulumi.get(self, "try_timeout")
+
     @_builtins.property
     @pulumi.getter(name="userConfigPatches")
     def user_config_patches(self) -> Optional[_builtins.str]:
@@ -262,4 +294,290 @@ class MachineInfo(dict):
         """
         return pulumi.get(self, "user_config_patches")
 
+    @_builtins.property
+    @pulumi.getter
+    def version(self) -> Optional[_builtins.int]:
+        """
+        Version of the machine info format. 
+        Set by the Cluster resource, the current version is 1. 
+        Machines without it are parsed as version 0.
+        """
+        return pulumi.get(self, "version")
+
+
+@pulumi.output_type
+class MachineStatus(dict):
+    @staticmethod
+    def __key_warning(key: str):
+        suggest = None
+        if key == "configHash":
+            suggest = "config_hash"
+        elif key == "kubernetesVersion":
+            suggest = "kubernetes_version"
+        elif key == "lastOperation":
+            suggest = "last_operation"
+        elif key == "lastOperationTime":
+            suggest = "last_operation_time"
+        elif key == "observedTalosVersion":
+            suggest = "observed_talos_version"
+        elif key == "talosVersion":
+            suggest = "talos_version"
+
+        if suggest:
+            pulumi.log.warn(f"Key '{key}' not found in MachineStatus. Access the value via the '{suggest}' property getter instead.")
+
+    def __getitem__(self, key: str) -> Any:
+        MachineStatus.__key_warning(key)
+        return super().__getitem__(key)
+
+    def get(self, key: str, default = None) -> Any:
+        MachineStatus.__key_warning(key)
+        return super().get(key, default)
+
+    def __init__(__self__, *,
+                 config_hash: _builtins.str,
+                 kubernetes_version: _builtins.str,
+                 last_operation: 'Operation',
+                 last_operation_time: _builtins.str,
+                 observed_talos_version: _builtins.str,
+                 talos_version: _builtins.str):
+        """
+        :param _builtins.str config_hash: SHA-256 hash of the last applied machine configuration.
+        :param _builtins.str kubernetes_version: Kubernetes version of the machine.
+        :param 'Operation' last_operation: The last operation which ran on the machine. It is unknown for machines of migrated stacks until an operation runs on them.
+        :param _builtins.str last_operation_time: Time of the last operation in RFC 3339 format. Empty if the operation is unknown.
+        :param _builtins.str observed_talos_version: Talos version reported by the node after apply.
+        :param _builtins.str talos_version: Talos version of the machine image. Empty if the image tag is not a version.
+        """
+        pulumi.set(__self__, "config_hash", config_hash)
+        pulumi.set(__self__, "kubernetes_version", kubernetes_version)
+        pulumi.set(__self__, "last_operation", last_operation)
+        pulumi.set(__self__, "last_operation_time", last_operation_time)
+        pulumi.set(__self__, "observed_talos_version", observed_talos_version)
+        pulumi.set(__self__, "talos_version", talos_version)
+
+    @_builtins.property
+    @pulumi.getter(name="configHash")
+    def config_hash(self) -> _builtins.str:
+        """
+        SHA-256 hash of the last applied machine configuration.
+        """
+        return pulumi.get(self, "config_hash")
+
+    @_builtins.property
+    @pulumi.getter(name="kubernetesVersion")
+    def kubernetes_version(self) -> _builtins.str:
+        """
+        Kubernetes version of the machine.
+        """
+        return pulumi.get(self, "kubernetes_version")
+
+    @_builtins.property
+    @pulumi.getter(name="lastOperation")
+    def last_operation(self) -> 'Operation':
+        """
+        The last operation which ran on the machine. It is unknown for machines of migrated stacks until an operation runs on them.
+        """
+        return pulumi.get(self, "last_operation")
+
+    @_builtins.property
+    @pulumi.getter(name="lastOperationTime")
+    def last_operation_time(self) -> _builtins.str:
+        """
+        Time of the last operation in RFC 3339 format. Empty if the operation is unknown.
+        """
+        return pulumi.get(self, "last_operation_time")
+
+    @_builtins.property
+    @pulumi.getter(name="observedTalosVersion")
+    def observed_talos_version(self) -> _builtins.str:
+        """
+        Talos version reported by the node after apply.
+        """
+        return pulumi.get(self, "observed_talos_version")
+
+    @_builtins.property
+    @pulumi.getter(name="talosVersion")
+    def talos_version(self) -> _builtins.str:
+        """
+        Talos version of the machine image. Empty if the image tag is not a version.
+        """
+        return pulumi.get(self, "talos_version")
+
+
+@pulumi.output_type
+class MachinesByType(dict):
+    """
+    Machines of the Cluster resource grouped by machine type. 
+    It is passed to applyMachines of the Apply resource.
+    """
+    def __init__(__self__, *,
+                 init: Sequence['outputs.MachineInfo'],
+                 controlplane: Optional[Sequence['outputs.MachineInfo']] = None,
+                 worker: Optional[Sequence['outputs.MachineInfo']] = None):
+        """
+        Machines of the Cluster resource grouped by machine type. 
+        It is passed to applyMachines of the Apply resource.
+        :param Sequence['MachineInfo'] init: Machines of the init type.
+        :param Sequence['MachineInfo'] controlplane: Machines of the controlplane type.
+        :param Sequence['MachineInfo'] worker: Machines of the worker type.
+        """
+        pulumi.set(__self__, "init", init)
+        if controlplane is not None:
+            pulumi.set(__self__, "controlplane", controlplane)
+        if worker is not None:
+            pulumi.set(__self__, "worker", worker)
+
+    @_builtins.property
+    @pulumi.getter
+    def init(self) -> Sequence['outputs.MachineInfo']:
+        """
+        Machines of the init type.
+        """
+        return pulumi.get(self, "init")
+
+    @_builtins.property
+    @pulumi.getter
+    def controlplane(self) -> Optional[Sequence['outputs.MachineInfo']]:
+        """
+        Machines of the controlplane type.
+        """
+        return pulumi.get(self, "controlplane")
+
+    @_builtins.property
+    @pulumi.getter
+    def worker(self) -> Optional[Sequence['outputs.MachineInfo']]:
+        """
+        Machines of the worker type.
+        """
+        return pulumi.get(self, "worker")
+
+
+@pulumi.output_type
+class NodeStatus(dict):
+    def __init__(__self__, *,
+                 node: _builtins.str,
+                 ready: _builtins.bool,
+                 stage: _builtins.str,
+                 error: Optional[_builtins.str] = None,
+                 etcd_learner: Optional[_builtins.bool] = None,
+                 etcd_member: Optional[_builtins.bool] = None,
+                 etcd_member_id: Optional[_builtins.str] = None,
+                 kubelet_version: Optional[_builtins.str] = None,
+                 machine_type: Optional[_builtins.str] = None,
+                 talos_version: Optional[_builtins.str] = None):
+        """
+        :param _builtins.str node: The IP address of the node.
+        :param _builtins.bool ready: The node is running, all Talos conditions are met 
+               and, for controlplanes, the node is a voting etcd member.
+        :param _builtins.str stage: Machine stage reported by Talos, e.g. maintenance, booting, running. 
+               It is unknown if the node can't be reached.
+        :param _builtins.str error: Errors occurred while the status was collected.
+        :param _builtins.bool etcd_learner: The etcd member is a learner and does not vote yet.
+        :param _builtins.bool etcd_member: The node is a member of the etcd cluster.
+        :param _builtins.str etcd_member_id: ID of the etcd member.
+        :param _builtins.str kubelet_version: Kubelet version from the kubelet image. Empty if kubelet is not configured yet.
+        :param _builtins.str machine_type: Machine type from the running configuration.
+        :param _builtins.str talos_version: Running Talos version.
+        """
+        pulumi.set(__self__, "node", node)
+        pulumi.set(__self__, "ready", ready)
+        pulumi.set(__self__, "stage", stage)
+        if error is not None:
+            pulumi.set(__self__, "error", error)
+        if etcd_learner is not None:
+            pulumi.set(__self__, "etcd_learner", etcd_learner)
+        if etcd_member is not None:
+            pulumi.set(__self__, "etcd_member", etcd_member)
+        if etcd_member_id is not None:
+            pulumi.set(__self__, "etcd_member_id", etcd_member_id)
+        if kubelet_version is not None:
+            pulumi.set(__self__, "kubelet_version", kubelet_version)
+        if machine_type is not None:
+            pulumi.set(__self__, "machine_type", machine_type)
+        if talos_version is not None:
+            pulumi.set(__self__, "talos_version", talos_version)
+
+    @_builtins.property
+    @pulumi.getter
+    def node(self) -> _builtins.str:
+        """
+        The IP address of the node.
+        """
+        return pulumi.get(self, "node")
+
+    @_builtins.property
+    @pulumi.getter
+    def ready(self) -> _builtins.bool:
+        """
+        The node is running, all Talos conditions are met 
+        and, for controlplanes, the node is a voting etcd member.
+        """
+        return pulumi.get(self, "ready")
+
+    @_builtins.property
+    @pulumi.getter
+    def stage(self) -> _builtins.str:
+        """
+        Machine stage reported by Talos, e.g. maintenance, booting, running. 
+        It is unknown if the node can't be reached.
+        """
+        return pulumi.get(self, "stage")
+
+    @_builtins.property
+    @pulumi.getter
+    def error(self) -> Optional[_builtins.str]:
+        """
+        Errors occurred while the status was collected.
+        """
+        return pulumi.get(self, "error")
+
+    @_builtins.property
+    @pulumi.getter(name="etcdLearner")
+    def etcd_learner(self) -> Optional[_builtins.bool]:
+        """
+        The etcd member is a learner and does not vote yet.
+        """
+        return pulumi.get(self, "etcd_learner")
+
+    @_builtins.property
+    @pulumi.getter(name="etcdMember")
+    def etcd_member(self) -> Optional[_builtins.bool]:
+        """
+        The node is a member of the etcd cluster.
+        """
+        return pulumi.get(self, "etcd_member")
+
+    @_builtins.property
+    @pulumi.getter(name="etcdMemberId")
+    def etcd_member_id(self) -> Optional[_builtins.str]:
+        """
+        ID of the etcd member.
+        """
+        return pulumi.get(self, "etcd_member_id")
+
+    @_builtins.property
+    @pulumi.getter(name="kubeletVersion")
+    def kubelet_version(self) -> Optional[_builtins.str]:
+        """
+        Kubelet version from the kubelet image. Empty if kubelet is not configured yet.
+        """
+        return pulumi.get(self, "kubelet_version")
+
+    @_builtins.property
+    @pulumi.getter(name="machineType")
+    def machine_type(self) -> Optional[_builtins.str]:
+        """
+        Machine type from the running configuration.
+        """
+        return pulumi.get(self, "machine_type")
+
+    @_builtins.property
+    @pulumi.getter(name="talosVersion")
+    def talos_version(self) -> Optional[_builtins.str]:
+        """
+        Running Talos version.
+        """
+        return pulumi.get(self, "talos_version")
+
 
@@ -13,16 +13,150 @@ if sys.version_info >= (3, 11):
 else:
     from typing_extensions import NotRequired, TypedDict, TypeAlias
 from . import _utilities
+from ._enums import *
 
 __all__ = ['ProviderArgs', 'Provider']
 
 @pulumi.input_type
 class ProviderArgs:
-    def __init__(__self__):
+    def __init__(__self__, *,
+                 debug_bundle: Optional[pulumi.Input['DebugBundle']] = None,
+                 debug_bundle_dir: Optional[pulumi.Input[_builtins.str]] = None,
+                 migration_mode: Optional[pulumi.Input['MigrationMode']] = None,
+                 talosctl_path: Optional[pulumi.Input[_builtins.str]] = None,
+                 talosctl_version_check: Optional[pulumi.Input['TalosctlVersionCheck']] = None,
+                 work_dir: Optional[pulumi.Input[_builtins.str]] = None):
         """
         The set of arguments for constructing a Provider resource.
+        :param pulumi.Input['DebugBundle'] debug_bundle: Diagnostics collected from a node when `talosctl upgrade` or `talosctl apply-config` fails. 
+               `basic` collects dmesg, the service list, logs of machined (and etcd on controlplanes during upgrade) and the machine status. 
+               `support` adds a `talosctl support` archive, which takes a few minutes. 
+               The path of the bundle is a part of the error message. 
+               Default is basic.
+        :param pulumi.Input[_builtins.str] debug_bundle_dir: Root directory for debug bundles. 
+               Bundles are written to `<debugBundleDir>/<stack>/<cluster>/<stage>-<machine>/<time>` and are not removed. 
+               Default is the debug directory inside the working directories of workDir.
+        :param pulumi.Input['MigrationMode'] migration_mode: Migration of stacks created with pulumiverse/talos resources. 
+               `aliases` registers the initial apply and bootstrap with aliases to the former resources, they are rerun, but skip configured nodes. 
+               `import` takes the initial apply and bootstrap as done without contacting nodes. 
+               In both modes the preview fails if a node is not configured, its etcd is not bootstrapped 
+               or it runs other cluster secrets than the generated configuration. 
+               Unset it after the migration.
+        :param pulumi.Input[_builtins.str] talosctl_path: Path to the talosctl binary or to a directory with talosctl binaries for different Talos versions. 
+               If a directory is set, the binary matching the Talos version of a machine image is used for the machine, 
+               and the newest one otherwise. 
+               Default is talosctl from PATH.
+        :param pulumi.Input['TalosctlVersionCheck'] talosctl_version_check: Reaction on skew between the talosctl version and the Talos version of a machine image. 
+               The version of every binary is checked via `talosctl version --client` on provider start. 
+               Default is warn.
+        :param pulumi.Input[_builtins.str] work_dir: Root directory for talosctl working directories. 
+               Files with credentials and machine configurations are written to `<workDir>/talos-cluster-<uid>`, 
+               which is accessible only by the current user. 
+               Default is the system temporary directory.
         """
-        pass
+        if debug_bundle is not None:
+            pulumi.set(__self__, "debug_bundle", debug_bundle)
+        if debug_bundle_dir is not None:
+            pulumi.set(__self__, "debug_bundle_dir", debug_bundle_dir)
+        if migration_mode is not None:
+            pulumi.set(__self__, "migration_mode", migration_mode)
+        if talosctl_path is not None:
+            pulumi.set(__self__, "talosctl_path", talosctl_path)
+        if talosctl_version_check is not None:
+            pulumi.set(__self__, "talosctl_version_check", talosctl_version_check)
+        if work_dir is not None:
+            pulumi.set(__self__, "work_dir", work_dir)
+
+    @_builtins.property
+    @pulumi.getter(name="debugBundle")
+    def debug_bundle(self) -> Optional[pulumi.Input['DebugBundle']]:
+        """
+        Diagnostics collected from a node when `talosctl upgrade` or `talosctl apply-config` fails. 
+        `basic` collects dmesg, the service list, logs of machined (and etcd on controlplanes during upgrade) and the machine status. 
+        `support` adds a `talosctl support` archive, which takes a few minutes. 
+        The path of the bundle is a part of the error message. 
+        Default is basic.
+        """
+        return pulumi.get(self, "debug_bundle")
+
+    @debug_bundle.setter
+    def debug_bundle(self, value: Optional[pulumi.Input['DebugBundle']]):
+        pulumi.set(self, "debug_bundle", value)
+
+    @_builtins.property
+    @pulumi.getter(name="debugBundleDir")
+    def debug_bundle_dir(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Root directory for debug bundles. 
+        Bundles are written to `<debugBundleDir>/<stack>/<cluster>/<stage>-<machine>/<time>` and are not removed. 
+        Default is the debug directory inside the working directories of workDir.
+        """
+        return pulumi.get(self, "debug_bundle_dir")
+
+    @debug_bundle_dir.setter
+    def debug_bundle_dir(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "debug_bundle_dir", value)
+
+    @_builtins.property
+    @pulumi.getter(name="migrationMode")
+    def migration_mode(self) -> Optional[pulumi.Input['MigrationMode']]:
+        """
+        Migration of stacks created with pulumiverse/talos resources. 
+        `aliases` registers the initial apply and bootstrap with aliases to the former resources, they are rerun, but skip configured nodes. 
+        `import` takes the initial apply and bootstrap as done without contacting nodes. 
+        In both modes the preview fails if a node is not configured, its etcd is not bootstrapped 
+        or it runs other cluster secrets than the generated configuration. 
+        Unset it after the migration.
+        """
+        return pulumi.get(self, "migration_mode")
+
+    @migration_mode.setter
+    def migration_mode(self, value: Optional[pulumi.Input['MigrationMode']]):
+        pulumi.set(self, "migration_mode", value)
+
+    @_builtins.property
+    @pulumi.getter(name="talosctlPath")
+    def talosctl_path(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Path to the talosctl binary or to a directory with talosctl binaries for different Talos versions. 
+        If a directory is set, the binary matching the Talos version of a machine image is used for the machine, 
+        and the newest one otherwise. 
+        Default is talosctl from PATH.
+        """
+        return pulumi.get(self, "talosctl_path")
+
+    @talosctl_path.setter
+    def talosctl_path(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "talosctl_path", value)
+
+    @_builtins.property
+    @pulumi.getter(name="talosctlVersionCheck")
+    def talosctl_version_check(self) -> Optional[pulumi.Input['TalosctlVersionCheck']]:
+        """
+        Reaction on skew between the talosctl version and the Talos version of a machine image. 
+        The version of every binary is checked via `talosctl version --client` on provider start. 
+        Default is warn.
+        """
+        return pulumi.get(self, "talosctl_version_check")
+
+    @talosctl_version_check.setter
+    def talosctl_version_check(self, value: Optional[pulumi.Input['TalosctlVersionCheck']]):
+        pulumi.set(self, "talosctl_version_check", value)
+
+    @_builtins.property
+    @pulumi.getter(name="workDir")
+    def work_dir(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Root directory for talosctl working directories. 
+        Files with credentials and machine configurations are written to `<workDir>/talos-cluster-<uid>`, 
+        which is accessible only by the current user. 
+        Default is the system temporary directory.
+        """
+        return pulumi.get(self, "work_dir")
+
+    @work_dir.setter
+    def work_dir(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "work_dir", value)
 
 
 @pulumi.type_token("pulumi:providers:talos-cluster")
@@ -31,11 +165,43 @@ class Provider(pulumi.ProviderResource):
     def __init__(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
+                 debug_bundle: Optional[pulumi.Input['DebugBundle']] = None,
+                 debug_bundle_dir: Optional[pulumi.Input[_builtins.str]] = None,
+                 migration_mode: Optional[pulumi.Input['MigrationMode']] = None,
+                 talosctl_path: Optional[pulumi.Input[_builtins.str]] = None,
+                 talosctl_version_check: Optional[pulumi.Input['TalosctlVersionCheck']] = None,
+                 work_dir: Optional[pulumi.Input[_builtins.str]] = None,
                  __props__=None):
         """
-        Create a Talos-cluster resource with the given unique name, props, and options.
+        The provider type for the talos-cluster package.
+
         :param str resource_name: The name of the resource.
         :param pulumi.ResourceOptions opts: Options for the resource.
+        :param pulumi.Input['DebugBundle'] debug_bundle: Diagnostics collected from a node when `talosctl upgrade` or `talosctl apply-config` fails. 
+               `basic` collects dmesg, the service list, logs of machined (and etcd on controlplanes during upgrade) and the machine status. 
+               `support` adds a `talosctl support` archive, which takes a few minutes. 
+               The path of the bundle is a part of the error message. 
+               Default is basic.
+        :param pulumi.Input[_builtins.str] debug_bundle_dir: Root directory for debug bundles. 
+               Bundles are written to `<debugBundleDir>/<stack>/<cluster>/<stage>-<machine>/<time>` and are not removed. 
+               Default is the debug directory inside the working directories of workDir.
+        :param pulumi.Input['MigrationMode'] migration_mode: Migration of stacks created with pulumiverse/talos resources. 
+               `aliases` registers the initial apply and bootstrap with aliases to the former resources, they are rerun, but skip configured nodes. 
+               `import` takes the initial apply and bootstrap as done without contacting nodes. 
+               In both modes the preview fails if a node is not configured, its etcd is not bootstrapped 
+               or it runs other cluster secrets than the generated configuration. 
+               Unset it after the migration.
+        :param pulumi.Input[_builtins.str] talosctl_path: Path to the talosctl binary or to a directory with talosctl binaries for different Talos versions. 
+               If a directory is set, the binary matching the Talos version of a machine image is used for the machine, 
+               and the newest one otherwise. 
+               Default is talosctl from PATH.
+        :param pulumi.Input['TalosctlVersionCheck'] talosctl_version_check: Reaction on skew between the talosctl version and the Talos version of a machine image. 
+               The version of every binary is checked via `talosctl version --client` on provider start. 
+               Default is warn.
+        :param pulumi.Input[_builtins.str] work_dir: Root directory for talosctl working directories. 
+               Files with credentials and machine configurations are written to `<workDir>/talos-cluster-<uid>`, 
+               which is accessible only by the current user. 
+               Default is the system temporary directory.
         """
         ...
     @overload
@@ -44,7 +210,8 @@ class Provider(pulumi.ProviderResource):
                  args: Optional[ProviderArgs] = None,
                  opts: Optional[pulumi.ResourceOptions] = None):
         """
-        Create a Talos-cluster resource with the given unique name, props, and options.
+        The provider type for the talos-cluster package.
+
         :param str resource_name: The name of the resource.
         :param ProviderArgs args: The arguments to use to populate this resource's properties.
         :param pulumi.ResourceOptions opts: Options for the resource.
@@ -60,6 +227,12 @@ class Provider(pulumi.ProviderResource):
     def _internal_init(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
+                 debug_bundle: Optional[pulumi.Input['DebugBundle']] = None,
+                 debug_bundle_dir: Optional[pulumi.Input[_builtins.str]] = None,
+                 migration_mode: Optional[pulumi.Input['MigrationMode']] = None,
+                 talosctl_path: Optional[pulumi.Input[_builtins.str]] = None,
+                 talosctl_version_check: Optional[pulumi.Input['TalosctlVersionCheck']] = None,
+                 work_dir: Optional[pulumi.Input[_builtins.str]] = None,
                  __props__=None):
         opts = pulumi.ResourceOptions.merge(_utilities.get_resource_opts_defaults(), opts)
         if not isinstance(opts, pulumi.ResourceOptions):
@@ -69,6 +242,12 @@ class Provider(pulumi.ProviderResource):
                 raise TypeError('__props__ is only valid when passed in combination with a valid opts.id to get an existing resource')
             __props__ = ProviderArgs.__new__(ProviderArgs)
 
+            __props__.__dict__["debug_bundle"] = pulumi.Output.from_input(debug_bundle).apply(pulumi.runtime.to_json) if debug_bundle is not None else None
+            __props__.__dict__["debug_bundle_dir"] = debug_bundle_dir
+            __props__.__dict__["migration_mode"] = pulumi.Output.from_input(migration_mode).apply(pulumi.runtime.to_json) if migration_mode is not None else None
+            __props__.__dict__["talosctl_path"] = talosctl_path
+            __props__.__dict__["talosctl_version_check"] = pulumi.Output.from_input(talosctl_version_check).apply(pulumi.runtime.to_json) if talosctl_version_check is not None else None
+            __props__.__dict__["work_dir"] = work_dir
         super(Provider, __self__).__init__(
             'talos-cluster',
             resource_name,
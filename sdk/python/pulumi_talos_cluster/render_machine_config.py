@@ -0,0 +1,101 @@
+# coding=utf-8
+# *** WARNING: this file was generated by pulumi-language-python. ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import builtins as _builtins
+import warnings
+import sys
+import pulumi
+import pulumi.runtime
+from typing import Any, Mapping, Optional, Sequence, Union, overload
+if sys.version_info >= (3, 11):
+    from typing import NotRequired, TypedDict, TypeAlias
+else:
+    from typing_extensions import NotRequired, TypedDict, TypeAlias
+from . import _utilities
+
+__all__ = [
+    'RenderMachineConfigResult',
+    'AwaitableRenderMachineConfigResult',
+    'render_machine_config',
+    'render_machine_config_output',
+]
+
+@pulumi.output_type
+class RenderMachineConfigResult:
+    def __init__(__self__, machine_configuration=None):
+        if machine_configuration and not isinstance(machine_configuration, str):
+            raise TypeError("Expected argument 'machine_configuration' to be a str")
+        pulumi.set(__self__, "machine_configuration", machine_configuration)
+
+    @_builtins.property
+    @pulumi.getter(name="machineConfiguration")
+    def machine_configuration(self) -> _builtins.str:
+        """
+        The final machine configuration YAML.
+        """
+        return pulumi.get(self, "machine_configuration")
+
+
+class AwaitableRenderMachineConfigResult(RenderMachineConfigResult):
+    # pylint: disable=using-constant-test
+    def __await__(self):
+        if False:
+            yield self
+        return RenderMachineConfigResult(
+            machine_configuration=self.machine_configuration)
+
+
+def render_machine_config(configuration: Optional[_builtins.str] = None,
+                          current_configuration: Optional[_builtins.str] = None,
+                          user_config_patches: Optional[_builtins.str] = None,
+                          opts: Optional[pulumi.InvokeOptions] = None) -> AwaitableRenderMachineConfigResult:
+    """
+    Render the machine configuration exactly as the Apply resource sends it to a node.
+    The same merge pipeline is used: user patches are merged into the generated configuration
+    and images of Kubernetes components are kept from the running configuration if it is provided.
+    No node is contacted.
+
+
+    :param _builtins.str configuration: Generated machine configuration. 
+           This can be retrieved from the cluster resource.
+    :param _builtins.str current_configuration: Configuration currently running on the node, e.g. spec of `talosctl get machineconfig`. 
+           If set, images of Kubernetes components are taken from it to prevent downgrades.
+    :param _builtins.str user_config_patches: User-provided machine configuration patches as a multi-document YAML. 
+           This can be retrieved from the cluster resource.
+    """
+    __args__ = dict()
+    __args__['configuration'] = configuration
+    __args__['currentConfiguration'] = current_configuration
+    __args__['userConfigPatches'] = user_config_patches
+    opts = pulumi.InvokeOptions.merge(_utilities.get_invoke_opts_defaults(), opts)
+    __ret__ = pulumi.runtime.invoke('talos-cluster:index:renderMachineConfig', __args__, opts=opts, typ=RenderMachineConfigResult).value
+
+    return AwaitableRenderMachineConfigResult(
+        machine_configuration=pulumi.get(__ret__, 'machine_configuration'))
+def render_machine_config_output(configuration: Optional[pulumi.Input[_builtins.str]] = None,
+                                 current_configuration: Optional[pulumi.Input[Optional[_builtins.str]]] = None,
+                                 user_config_patches: Optional[pulumi.Input[Optional[_builtins.str]]] = None,
+                                 opts: Optional[Union[pulumi.InvokeOptions, pulumi.InvokeOutputOptions]] = None) -> pulumi.Output[RenderMachineConfigResult]:
+    """
+    Render the machine configuration exactly as the Apply resource sends it to a node.
+    The same merge pipeline is used: user patches are merged into the generated configuration
+    and images of Kubernetes components are kept from the running configuration if it is provided.
+    No node is contacted.
+
+
+    :param _builtins.str configuration: Generated machine configuration. 
+           This can be retrieved from the cluster resource.
+    :param _builtins.str current_configuration: Configuration currently running on the node, e.g. spec of `talosctl get machineconfig`. 
+           If set, images of Kubernetes components are taken from it to prevent downgrades.
+    :param _builtins.str user_config_patches: User-provided machine configuration patches as a multi-document YAML. 
+           This can be retrieved from the cluster resource.
+    """
+    __args__ = dict()
+    __args__['configuration'] = configuration
+    __args__['currentConfiguration'] = current_configuration
+    __args__['userConfigPatches'] = user_config_patches
+    opts = pulumi.InvokeOutputOptions.merge(_utilities.get_invoke_opts_defaults(), opts)
+    __ret__ = pulumi.runtime.invoke_output('talos-cluster:index:renderMachineConfig', __args__, opts=opts, typ=RenderMachineConfigResult)
+    return __ret__.apply(lambda __response__: RenderMachineConfigResult(
+        machine_configuration=pulumi.get(__response__, 'machine_configuration')))